@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"net/http"
 	"os"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
 	"github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	"github.com/cosmos/cosmos-sdk/x/params"
@@ -30,6 +32,7 @@ import (
 
 	"github.com/dfinance/dnode/cmd/config"
 	"github.com/dfinance/dnode/helpers"
+	"github.com/dfinance/dnode/helpers/metrics"
 	"github.com/dfinance/dnode/x/core"
 	"github.com/dfinance/dnode/x/currencies"
 	"github.com/dfinance/dnode/x/currencies_register"
@@ -65,6 +68,7 @@ var (
 		params.AppModuleBasic{},
 		slashing.AppModuleBasic{},
 		supply.AppModuleBasic{},
+		crisis.AppModuleBasic{},
 		poa.AppModuleBasic{},
 		currencies.AppModuleBasic{},
 		currencies_register.AppModuleBasic{},
@@ -98,6 +102,7 @@ type DnServiceApp struct {
 	stakingKeeper  staking.Keeper
 	distrKeeper    distribution.Keeper
 	slashingKeeper slashing.Keeper
+	crisisKeeper   crisis.Keeper
 	poaKeeper      poa.Keeper
 	ccKeeper       currencies.Keeper
 	msKeeper       multisig.Keeper
@@ -110,6 +115,9 @@ type DnServiceApp struct {
 	// vm connection
 	vmConn     *grpc.ClientConn
 	vmListener net.Listener
+
+	// dedicated metrics server
+	metricsServer *http.Server
 }
 
 // Initialize connection to VM server.
@@ -128,6 +136,25 @@ func (app *DnServiceApp) InitializeVMConnection(addr string) {
 // Close VM connection and DS server stops.
 func (app DnServiceApp) CloseConnections() {
 	app.vmKeeper.CloseConnections()
+
+	if err := metrics.StopServer(app.metricsServer); err != nil {
+		app.Logger().Error(fmt.Sprintf("Stopping metrics server: %v", err))
+	}
+}
+
+// Start dedicated Prometheus metrics server (if configured).
+func (app *DnServiceApp) InitializeMetricsServer(listenAddr string) {
+	if listenAddr == "" {
+		return
+	}
+
+	var err error
+
+	app.Logger().Info(fmt.Sprintf("Starting metrics server, address: %s", listenAddr))
+	app.metricsServer, err = metrics.StartServer(listenAddr)
+	if err != nil {
+		panic(err)
+	}
 }
 
 // Initialize listener to listen for connections from VM for data server.
@@ -153,7 +180,8 @@ func MakeCodec() *codec.Codec {
 }
 
 // NewDnServiceApp is a constructor function for dfinance blockchain.
-func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, baseAppOptions ...func(*BaseApp)) *DnServiceApp {
+// invCheckPeriod: assert registered invariants every N blocks (0 - disabled).
+func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, invCheckPeriod uint, baseAppOptions ...func(*BaseApp)) *DnServiceApp {
 	cdc := MakeCodec()
 
 	bApp := NewBaseApp(appName, logger, db, auth.DefaultTxDecoder(cdc), baseAppOptions...)
@@ -187,6 +215,10 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 		tkeys:   tkeys,
 	}
 
+	// initialize metrics
+	metrics.EnablePrometheus()
+	app.InitializeMetricsServer(config.MetricsListen)
+
 	// initialize connections
 	app.InitializeVMDataServer(config.DataListen)
 	app.InitializeVMConnection(config.Address)
@@ -195,6 +227,13 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 	// 1 dfi == 1000000000000000000
 	sdk.PowerReduction = sdk.NewIntFromBigInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
 
+	// The ParamsKeeper handles parameter storage for the application.
+	app.paramsKeeper = params.NewKeeper(
+		app.cdc,
+		keys[params.StoreKey],
+		tkeys[params.TStoreKey],
+	)
+
 	// Initializing vm keeper.
 	var err error
 	app.vmKeeper = vm.NewKeeper(
@@ -203,13 +242,14 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 		app.vmConn,
 		app.vmListener,
 		config,
+		app.paramsKeeper.Subspace(vm.DefaultParamspace),
 	)
 
-	// The ParamsKeeper handles parameter storage for the application.
-	app.paramsKeeper = params.NewKeeper(
+	// Initializing currencies register keeper (provides VM balance paths for denoms).
+	app.crKeeper = currencies_register.NewKeeper(
 		app.cdc,
-		keys[params.StoreKey],
-		tkeys[params.TStoreKey],
+		keys[currencies_register.StoreKey],
+		app.vmKeeper,
 	)
 
 	// The AccountKeeper handles address -> account lookups.
@@ -218,14 +258,18 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 		keys[auth.StoreKey],
 		app.paramsKeeper.Subspace(auth.DefaultParamspace),
 		app.vmKeeper,
+		app.crKeeper,
 		auth.ProtoBaseAccount,
 	)
 
 	// The BankKeeper allows you perform sdk.Coins interactions.
-	app.bankKeeper = bank.NewBaseKeeper(
-		app.accountKeeper,
-		app.paramsKeeper.Subspace(bank.DefaultParamspace),
-		app.ModuleAccountAddrs(),
+	// Wrapped to update VM accounts event handles on coins transfers.
+	app.bankKeeper = vmauth.NewVMBankKeeper(
+		bank.NewBaseKeeper(
+			app.accountKeeper,
+			app.paramsKeeper.Subspace(bank.DefaultParamspace),
+			app.ModuleAccountAddrs(),
+		),
 	)
 
 	// The SupplyKeeper collects transaction fees and renders them to the fee distribution module.
@@ -242,16 +286,12 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 	// Initialize currency keeper.
 	app.ccKeeper = currencies.NewKeeper(
 		app.bankKeeper,
+		app.supplyKeeper,
+		app.crKeeper,
 		keys[currencies.StoreKey],
 		cdc,
 	)
 
-	app.crKeeper = currencies_register.NewKeeper(
-		app.cdc,
-		keys[currencies_register.StoreKey],
-		app.vmKeeper,
-	)
-
 	// Initializing distribution keeper.
 	app.distrKeeper = distribution.NewKeeper(
 		cdc,
@@ -271,6 +311,14 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 		app.paramsKeeper.Subspace(slashing.DefaultParamspace),
 	)
 
+	// Initialize crisis keeper (asserts registered invariants).
+	app.crisisKeeper = crisis.NewKeeper(
+		app.paramsKeeper.Subspace(crisis.DefaultParamspace),
+		invCheckPeriod,
+		app.supplyKeeper,
+		auth.FeeCollectorName,
+	)
+
 	// Initialize staking keeper.
 	app.stakingKeeper = *stakingKeeper.SetHooks(
 		staking.NewMultiStakingHooks(
@@ -305,6 +353,11 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 		app.vmKeeper,
 	)
 
+	// Registering VM data source middlewares provided by other modules.
+	app.vmKeeper.RegisterDSDataMiddleware(vm.NewProposerMiddleware(app.resolveProposer))
+	app.vmKeeper.RegisterDSDataMiddleware(app.oracleKeeper.NewPriceInfoMiddleware())
+	app.vmKeeper.RegisterVMWriteSetHandler(app.accountKeeper.NewVMWriteSetHandler())
+
 	// Initializing multisignature manager.
 	app.mm = core.NewMsManager(
 		genaccounts.NewAppModule(app.accountKeeper),
@@ -312,25 +365,30 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 		vmauth.NewAppModule(app.accountKeeper),
 		bank.NewAppModule(app.bankKeeper, app.accountKeeper),
 		supply.NewAppModule(app.supplyKeeper, app.accountKeeper),
+		crisis.NewAppModule(&app.crisisKeeper),
 		slashing.NewAppModule(app.slashingKeeper, app.accountKeeper, app.stakingKeeper),
 		distribution.NewAppModule(app.distrKeeper, app.accountKeeper, app.supplyKeeper, app.stakingKeeper),
 		staking.NewAppModule(app.stakingKeeper, app.accountKeeper, app.supplyKeeper),
 		poa.NewAppMsModule(app.poaKeeper),
-		currencies.NewAppMsModule(app.ccKeeper),
-		currencies_register.NewAppModule(app.crKeeper),
+		currencies.NewAppMsModule(app.ccKeeper, app.accountKeeper),
+		currencies_register.NewAppModule(app.crKeeper, app.supplyKeeper),
 		multisig.NewAppModule(app.msKeeper, app.poaKeeper),
 		oracle.NewAppModule(app.oracleKeeper),
-		vm.NewAppModule(app.vmKeeper),
+		vm.NewAppMsModule(app.vmKeeper),
 	)
 
 	app.mm.SetOrderBeginBlockers(distribution.ModuleName, slashing.ModuleName)
-	app.mm.SetOrderEndBlockers(staking.ModuleName, multisig.ModuleName, oracle.ModuleName)
+	app.mm.SetOrderEndBlockers(crisis.ModuleName, staking.ModuleName, multisig.ModuleName, oracle.ModuleName)
 
 	// Sets the order of Genesis - Order matters, genutil is to always come last
 	// NOTE: The genutils moodule must occur after staking so that pools are
 	// properly initialized with tokens from genesis accounts.
+	// NOTE: currencies register must occur before genesis accounts as it provides denoms VM balance paths.
+	// NOTE: currencies must occur after supply as it checks total supply matches accounts coins.
+	// NOTE: crisis asserts registered invariants on genesis init.
 	app.mm.SetOrderInitGenesis(
 		vm.ModuleName,
+		currencies_register.ModuleName,
 		genaccounts.ModuleName,
 		distribution.ModuleName,
 		staking.ModuleName,
@@ -341,12 +399,12 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 		poa.ModuleName,
 		currencies.ModuleName,
 		multisig.ModuleName,
-		vm.ModuleName,
 		oracle.ModuleName,
-		currencies_register.ModuleName,
+		crisis.ModuleName,
 		genutil.ModuleName,
 	)
 
+	app.mm.RegisterInvariants(&app.crisisKeeper)
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
 	app.mm.RegisterMsRoutes(app.msRouter)
 
@@ -358,6 +416,7 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 		core.NewAnteHandler(
 			app.accountKeeper,
 			app.supplyKeeper,
+			app.vmKeeper,
 			auth.DefaultSigVerificationGasConsumer,
 		),
 	)
@@ -369,6 +428,7 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 	if err != nil {
 		tmOs.Exit(err.Error())
 	}
+	app.vmKeeper.ResetDSCache(app.LastBlockHeight() + 1)
 
 	// Temporary solution, but seems works.
 	// Set context for reading data from DS store.
@@ -376,11 +436,30 @@ func NewDnServiceApp(logger log.Logger, db dbm.DB, config *config.VMConfig, base
 	dsContext := app.GetDSContext()
 	app.vmKeeper.SetDSContext(dsContext)
 	app.vmKeeper.StartDSServer(dsContext)
+	app.vmKeeper.StartHealthProber(app.Logger())
 	time.Sleep(1 * time.Second) // need for DS to initialize stdlib, will be removed later.
 
 	return app
 }
 
+// Resolve block proposer consensus address to validator operator account address.
+func (app *DnServiceApp) resolveProposer(ctx sdk.Context, consAddress sdk.ConsAddress) (sdk.AccAddress, bool) {
+	validator, found := app.stakingKeeper.GetValidatorByConsAddr(ctx, consAddress)
+	if !found {
+		return nil, false
+	}
+
+	return sdk.AccAddress(validator.OperatorAddress), true
+}
+
+// Commit app state, VM data source read cache is reset for the next block.
+func (app *DnServiceApp) Commit() abci.ResponseCommit {
+	res := app.BaseApp.Commit()
+	app.vmKeeper.ResetDSCache(app.LastBlockHeight() + 1)
+
+	return res
+}
+
 // ModuleAccountAddrs returns all the app's module account addresses.
 func (app *DnServiceApp) ModuleAccountAddrs() map[string]bool {
 	modAccAddrs := make(map[string]bool)
@@ -430,6 +509,12 @@ func (app *DnServiceApp) ExportAppStateAndValidators(forZeroHeight bool, jailWhi
 	// as if they could withdraw from the start of the next block.
 	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
 
+	if forZeroHeight {
+		if err := app.prepForZeroHeightGenesis(ctx, jailWhiteList); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	genState := app.mm.ExportGenesis(ctx)
 	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
@@ -557,10 +557,14 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (gInfo sdk.
 				)
 
 			default:
-				if err, ok := r.(error); ok {
-					if vm.ErrVMCrashed.Is(err) {
+				if vmErr, ok := r.(error); ok && vm.ErrVMCrashed.Is(vmErr) {
+					// simulation is a query: report VM failure to the client instead of halting the node.
+					if mode != runTxModeSimulate {
 						panic(r)
 					}
+
+					err = vmErr
+					break
 				}
 
 				err = sdkerrors.Wrap(
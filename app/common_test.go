@@ -6,11 +6,13 @@ import (
 	"bytes"
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"sort"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -24,19 +26,17 @@ import (
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/test/bufconn"
 
 	dnConfig "github.com/dfinance/dnode/cmd/config"
 	vmConfig "github.com/dfinance/dnode/cmd/config"
+	"github.com/dfinance/dnode/helpers/tests"
 	"github.com/dfinance/dnode/x/core"
+	"github.com/dfinance/dnode/x/currencies_register"
 	"github.com/dfinance/dnode/x/genaccounts"
 	msMsgs "github.com/dfinance/dnode/x/multisig/msgs"
 	msTypes "github.com/dfinance/dnode/x/multisig/types"
 	"github.com/dfinance/dnode/x/oracle"
 	poaTypes "github.com/dfinance/dnode/x/poa/types"
-	"github.com/dfinance/dnode/x/vm"
-	"github.com/dfinance/dnode/x/vmauth"
 )
 
 var (
@@ -59,7 +59,6 @@ var (
 		"0x10A1c1CB95c92EC31D3f22C66Eef1d9f3F258c6B",
 		"0xe0FC04FA2d34a66B779fd5CEe748268032a146c0",
 	}
-	bufferSize = 1024 * 1024
 )
 
 // Type that combines an Address with the privKey and pubKey to that address
@@ -136,68 +135,48 @@ func CreateGenAccounts(numAccs int, genCoins sdk.Coins) (genAccs []*auth.BaseAcc
 }
 
 const (
-	DefaultMockVMAddress  = "127.0.0.1:0" // Default virtual machine address to connect from Cosmos SDK.
-	DefaultMockDataListen = "127.0.0.1:0" // Default data server address to listen for connections from VM.
+	DefaultMockDataListen = "127.0.0.1:0" // Default data server address to listen for connections from VM (0 - free port).
 
-	FlagVMMockAddress = "vm.mock.address"
-	FlagDSMockListen  = "ds.mock.listen"
+	FlagDSMockListen = "ds.mock.listen"
 )
 
 var (
-	vmMockAddress  *string
 	dataListenMock *string
 )
 
 func MockVMConfig() *vmConfig.VMConfig {
-	return &vmConfig.VMConfig{
-		Address:    *vmMockAddress,
-		DataListen: *dataListenMock,
-	}
+	config := vmConfig.DefaultVMConfig()
+	config.DataListen = *dataListenMock
+
+	return config
 }
 
 func init() {
-	if flag.Lookup(FlagVMMockAddress) == nil {
-		vmMockAddress = flag.String(FlagVMMockAddress, DefaultMockVMAddress, "mocked address of virtual machine server client/server")
-	}
-
 	if flag.Lookup(FlagDSMockListen) == nil {
 		dataListenMock = flag.String(FlagDSMockListen, DefaultMockDataListen, "address of mocked data server to launch/connect")
 	}
-
-	if err := vmauth.AddDenomPath(currency1Symbol, "00"); err != nil {
-		panic(err)
-	}
-
-	if err := vmauth.AddDenomPath(currency2Symbol, "01"); err != nil {
-		panic(err)
-	}
-
-	if err := vmauth.AddDenomPath(currency3Symbol, "11"); err != nil {
-		panic(err)
-	}
 }
 
-type VMServer struct {
-	vm.UnimplementedVMServiceServer
-}
-
-func newTestDnApp() (*DnServiceApp, *grpc.Server) {
+// Create app connected to in-process VM mock server (VM mock reads data via app DS server).
+func newTestDnApp() (*DnServiceApp, *tests.VMMockServer) {
 	config := MockVMConfig()
 
-	vmListener := bufconn.Listen(bufferSize)
-
-	vmServer := VMServer{}
-	server := grpc.NewServer()
-
-	vm.RegisterVMServiceServer(server, &vmServer)
-
-	go func() {
-		if err := server.Serve(vmListener); err != nil {
+	// VM mock connects to DS server before it is started, so DS address must be known
+	if host, port, err := net.SplitHostPort(config.DataListen); err == nil && port == "0" {
+		_, freePort, err := server.FreeTCPAddr()
+		if err != nil {
 			panic(err)
 		}
-	}()
+		config.DataListen = net.JoinHostPort(host, freePort)
+	}
 
-	return NewDnServiceApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "sdk/app"), dbm.NewMemDB(), config), server
+	vmMock, vmPort, err := tests.NewVMMockServerWithNetTransport(config.DataListen)
+	if err != nil {
+		panic(err)
+	}
+	config.Address = "127.0.0.1:" + vmPort
+
+	return NewDnServiceApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "sdk/app"), dbm.NewMemDB(), config, 0), vmMock
 }
 
 func getGenesis(app *DnServiceApp, chainID, monikerID string, accs []*auth.BaseAccount, privValidatorKey *ed25519.PrivKeyEd25519) ([]byte, error) {
@@ -272,6 +251,15 @@ func getGenesis(app *DnServiceApp, chainID, monikerID string, accs []*auth.BaseA
 			oracleGenesis.Params.Nominees = append(oracleGenesis.Params.Nominees, accs[i].Address.String())
 		}
 		genesisState[oracle.ModuleName] = codec.MustMarshalJSONIndent(app.cdc, oracleGenesis)
+
+		crGenesis := currencies_register.GenesisState{}
+		app.cdc.MustUnmarshalJSON(genesisState[currencies_register.ModuleName], &crGenesis)
+		crGenesis.DenomPaths = append(crGenesis.DenomPaths,
+			currencies_register.GenesisDenomPath{Denom: currency1Symbol, Path: "00"},
+			currencies_register.GenesisDenomPath{Denom: currency2Symbol, Path: "01"},
+			currencies_register.GenesisDenomPath{Denom: currency3Symbol, Path: "11"},
+		)
+		genesisState[currencies_register.ModuleName] = codec.MustMarshalJSONIndent(app.cdc, crGenesis)
 	}
 
 	// generate node validator genTx and update genutil module genesis
@@ -349,7 +337,7 @@ func genTx(msgs []sdk.Msg, accnums []uint64, seq []uint64, priv ...crypto.PrivKe
 
 	fee := auth.StdFee{
 		Amount: sdk.Coins{{Denom: dnConfig.MainDenom, Amount: sdk.NewInt(1)}},
-		Gas:    250000,
+		Gas:    300000,
 	}
 
 	for i, p := range priv {
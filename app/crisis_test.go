@@ -0,0 +1,94 @@
+// +build unit
+
+package app
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"github.com/stretchr/testify/require"
+
+	dnConfig "github.com/dfinance/dnode/cmd/config"
+	"github.com/dfinance/dnode/x/common_vm"
+	"github.com/dfinance/dnode/x/currencies"
+	"github.com/dfinance/dnode/x/currencies_register"
+	"github.com/dfinance/dnode/x/vmauth"
+)
+
+// Check registered invariant by module and route, returns true if invariant is broken.
+func isInvariantBroken(t *testing.T, app *DnServiceApp, ctx sdk.Context, moduleName, route string) bool {
+	for _, invRoute := range app.crisisKeeper.Routes() {
+		if invRoute.ModuleName == moduleName && invRoute.Route == route {
+			_, broken := invRoute.Invar(ctx)
+			return broken
+		}
+	}
+
+	require.Failf(t, "invariant not registered", "%s/%s", moduleName, route)
+
+	return false
+}
+
+func Test_Invariants(t *testing.T) {
+	t.Parallel()
+	app, server := newTestDnApp()
+	defer app.CloseConnections()
+	defer server.Stop()
+
+	genAccs, _, _, genPrivKeys := CreateGenAccounts(10, GenDefCoins(t))
+
+	_, err := setGenesis(t, app, genAccs)
+	require.NoError(t, err)
+
+	recipientIdx, recipientAddr, recipientPrivKey := uint(0), genAccs[0].Address, genPrivKeys[0]
+
+	// issue and destroy currency
+	issueCurrency(t, app, currency1Symbol, amount.MulRaw(2), 0, "1", issue1ID, recipientIdx, genAccs, genPrivKeys, true)
+	destroyCurrency(t, app, chainID, currency1Symbol, amount, recipientAddr, recipientPrivKey, true)
+
+	// all invariants are held
+	require.NotPanics(t, func() {
+		app.crisisKeeper.AssertInvariants(GetContext(app, true))
+	})
+
+	// VM balance resource removed
+	{
+		ctx, _ := GetContext(app, true).CacheContext()
+		path, ok := app.crKeeper.GetDenomPath(ctx, dnConfig.MainDenom)
+		require.True(t, ok)
+
+		app.vmKeeper.DelValue(ctx, &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(recipientAddr), Path: path})
+		require.True(t, isInvariantBroken(t, app, ctx, vmauth.InvariantsRoute, "vm-balances"))
+	}
+
+	// VM balance amount doesn't match account coins
+	{
+		ctx, _ := GetContext(app, true).CacheContext()
+		path, ok := app.crKeeper.GetDenomPath(ctx, dnConfig.MainDenom)
+		require.True(t, ok)
+
+		acc := app.accountKeeper.AccountKeeper.GetAccount(ctx, recipientAddr)
+		vmAmount := acc.GetCoins().AmountOf(dnConfig.MainDenom).AddRaw(1)
+		app.vmKeeper.SetValue(ctx, &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(recipientAddr), Path: path}, vmauth.BalanceToBytes(vmauth.BalanceResource{Value: vmAmount.BigInt()}))
+		require.True(t, isInvariantBroken(t, app, ctx, vmauth.InvariantsRoute, "vm-balances"))
+	}
+
+	// currency supply doesn't match total supply
+	{
+		ctx, _ := GetContext(app, true).CacheContext()
+		app.supplyKeeper.SetSupply(ctx, app.supplyKeeper.GetSupply(ctx).Inflate(sdk.NewCoins(sdk.NewCoin(currency1Symbol, sdk.OneInt()))))
+		require.True(t, isInvariantBroken(t, app, ctx, currencies.ModuleName, "supply"))
+	}
+
+	// currency info total supply exceeded
+	{
+		ctx, _ := GetContext(app, true).CacheContext()
+		info, err := app.crKeeper.GetCurrencyInfo(ctx, dnConfig.MainDenom)
+		require.NoError(t, err)
+
+		excess := sdk.NewIntFromBigInt(info.TotalSupply).Add(sdk.OneInt())
+		app.supplyKeeper.SetSupply(ctx, app.supplyKeeper.GetSupply(ctx).Inflate(sdk.NewCoins(sdk.NewCoin(dnConfig.MainDenom, excess))))
+		require.True(t, isInvariantBroken(t, app, ctx, currencies_register.ModuleName, "currencies-info"))
+	}
+}
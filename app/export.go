@@ -0,0 +1,68 @@
+// Zero height genesis export.
+package app
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/staking/exported"
+)
+
+// Prepare state for a fresh start at zero height (chain restart / hard fork).
+// Only height dependent state is reset: distribution rewards periods, slashing signing infos and VM modules deploy height.
+// Validators are managed by PoA module, so jail whitelist is not supported.
+func (app *DnServiceApp) prepForZeroHeightGenesis(ctx sdk.Context, jailWhiteList []string) error {
+	if len(jailWhiteList) > 0 {
+		return fmt.Errorf("jail whitelist is not supported")
+	}
+
+	// distribution: withdraw all validators commission and delegators rewards,
+	// as delegators starting info and slash events refer to block heights.
+	app.stakingKeeper.IterateValidators(ctx, func(_ int64, val exported.ValidatorI) (stop bool) {
+		_, _ = app.distrKeeper.WithdrawValidatorCommission(ctx, val.GetOperator())
+		return false
+	})
+
+	dels := app.stakingKeeper.GetAllDelegations(ctx)
+	for _, delegation := range dels {
+		_, _ = app.distrKeeper.WithdrawDelegationRewards(ctx, delegation.DelegatorAddress, delegation.ValidatorAddress)
+	}
+
+	app.distrKeeper.DeleteAllValidatorSlashEvents(ctx)
+	app.distrKeeper.DeleteAllValidatorHistoricalRewards(ctx)
+
+	// distribution: reinitialize validators and delegations at zero height.
+	height := ctx.BlockHeight()
+	ctx = ctx.WithBlockHeight(0)
+
+	app.stakingKeeper.IterateValidators(ctx, func(_ int64, val exported.ValidatorI) (stop bool) {
+		// donate any unwithdrawn outstanding reward fraction tokens to the community pool.
+		scraps := app.distrKeeper.GetValidatorOutstandingRewards(ctx, val.GetOperator())
+		feePool := app.distrKeeper.GetFeePool(ctx)
+		feePool.CommunityPool = feePool.CommunityPool.Add(scraps...)
+		app.distrKeeper.SetFeePool(ctx, feePool)
+
+		app.distrKeeper.Hooks().AfterValidatorCreated(ctx, val.GetOperator())
+		return false
+	})
+
+	for _, del := range dels {
+		app.distrKeeper.Hooks().BeforeDelegationCreated(ctx, del.DelegatorAddress, del.ValidatorAddress)
+		app.distrKeeper.Hooks().AfterDelegationModified(ctx, del.DelegatorAddress, del.ValidatorAddress)
+	}
+
+	ctx = ctx.WithBlockHeight(height)
+
+	// slashing: reset signing infos start height.
+	app.slashingKeeper.IterateValidatorSigningInfos(ctx, func(addr sdk.ConsAddress, info slashing.ValidatorSigningInfo) (stop bool) {
+		info.StartHeight = 0
+		app.slashingKeeper.SetValidatorSigningInfo(ctx, addr, info)
+		return false
+	})
+
+	// vm: reset modules deploy height (VM storage is exported as is).
+	app.vmKeeper.PrepForZeroHeightGenesis(ctx)
+
+	return nil
+}
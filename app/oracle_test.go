@@ -488,6 +488,30 @@ func Test_OracleSetAsset(t *testing.T) {
 		require.Len(t, asset.Oracles, 2)
 		require.True(t, asset.Oracles[0].Address.Equals(newOracleAcc1))
 		require.True(t, asset.Oracles[1].Address.Equals(newOracleAcc2))
+		require.Equal(t, oracle.AggregationMedian, asset.Aggregation)
+	}
+
+	// check setting asset aggregation strategy
+	{
+		updAssetCode := "dn2test1"
+
+		asset := oracle.NewAsset(updAssetCode, oracle.Oracles{{Address: newOracleAcc1, Weight: 1}, {Address: newOracleAcc2, Weight: 2}}, true)
+		asset.Aggregation = oracle.AggregationWeightedMedian
+		msg := oracle.MsgSetAsset{
+			Nominee: nomineeAddr,
+			Denom:   updAssetCode,
+			Asset:   asset,
+		}
+		senderAcc := GetAccountCheckTx(app, nomineeAddr)
+
+		tx := genTx([]sdk.Msg{msg}, []uint64{senderAcc.GetAccountNumber()}, []uint64{senderAcc.GetSequence()}, nomineePrivKey)
+		CheckDeliverTx(t, app, tx)
+
+		asset, found := app.oracleKeeper.GetAsset(GetContext(app, true), updAssetCode)
+		require.True(t, found)
+		require.Equal(t, oracle.AggregationWeightedMedian, asset.Aggregation)
+		require.EqualValues(t, 1, asset.Oracles[0].Weight)
+		require.EqualValues(t, 2, asset.Oracles[1].Weight)
 	}
 }
 
@@ -0,0 +1,54 @@
+// +build unit
+
+package app
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dfinance/dnode/helpers/tests"
+	"github.com/dfinance/dnode/x/common_vm"
+	"github.com/dfinance/dnode/x/vm"
+)
+
+func Test_VMExecuteScriptMock(t *testing.T) {
+	t.Parallel()
+	app, vmMock := newTestDnApp()
+	defer app.CloseConnections()
+	defer vmMock.Stop()
+
+	genAccs, _, _, genPrivKeys := CreateGenAccounts(7, GenDefCoins(t))
+	_, err := setGenesis(t, app, genAccs)
+	require.NoError(t, err)
+
+	senderAcc, senderPrivKey := GetAccountCheckTx(app, genAccs[0].Address), genPrivKeys[0]
+
+	// script writes value to sender resource
+	path := &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(senderAcc.GetAddress()), Path: []byte{0x1, 0x2}}
+	value := []byte{0xAA, 0xBB}
+	vmMock.SetExecHandler(func(contract *vm_grpc.VMContract, _ tests.VMMockDataSource) (*vm_grpc.VMExecuteResponse, error) {
+		writeSet := []*vm_grpc.VMValue{{Type: vm_grpc.VmWriteOp_Value, Path: path, Value: value}}
+		return tests.NewVMMockKeepResponse(1000, writeSet, nil), nil
+	})
+
+	msg := vm.MsgExecuteScript{Signer: senderAcc.GetAddress(), Script: []byte{0x1}}
+	tx := genTx([]sdk.Msg{msg}, []uint64{senderAcc.GetAccountNumber()}, []uint64{senderAcc.GetSequence()}, senderPrivKey)
+	CheckDeliverTx(t, app, tx)
+
+	// simulation and delivery requests (empty health check requests are skipped)
+	ctx := GetContext(app, true)
+	requests := make([]*vm_grpc.VMExecuteRequest, 0)
+	for _, req := range vmMock.Requests() {
+		if len(req.Contracts) > 0 {
+			requests = append(requests, req)
+		}
+	}
+	require.Len(t, requests, 2)
+	require.Equal(t, app.vmKeeper.GetParams(ctx).MaxGasPerScript, requests[0].Contracts[0].MaxGasAmount)
+	require.Less(t, requests[1].Contracts[0].MaxGasAmount, requests[0].Contracts[0].MaxGasAmount)
+
+	require.Equal(t, value, app.vmKeeper.GetValue(ctx, path))
+}
@@ -3,6 +3,7 @@ package config
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -29,10 +30,26 @@ const (
 	DefaultDataListen = "tcp://127.0.0.1:50052" // Default data server address to listen for connections from VM.
 
 	// Default retry configs.
-	DefaultMaxAttempts       = 0   // Default VM retry attempts.
-	DefaultInitialBackoff    = 100 // Default VM 100 milliseconds for retry attempts.
-	DefaultMaxBackoff        = 150 // Default VM max backoff.
-	DefaultBackoffMultiplier = 0.1 // Default backoff multiplayer (10
+	DefaultMaxAttempts        = 0    // Default VM retry attempts.
+	DefaultInitialBackoff     = 100  // Default VM 100 milliseconds for retry attempts.
+	DefaultMaxBackoff         = 150  // Default VM max backoff.
+	DefaultBackoffMultiplier  = 0.1  // Default backoff multiplayer (10
+	DefaultDeliverMaxAttempts = 1000 // Default VM retry attempts cap for DeliverTx.
+
+	// VM failure policies (VM is not reachable / max retry attempts reached).
+	// Policies are applied to CheckTx / simulation, DeliverTx always halts the node.
+	VMFailurePolicyHalt          = "halt"           // panic and halt the node.
+	VMFailurePolicyFailTx        = "fail_tx"        // fail transaction with "VM is not available" error code.
+	VMFailurePolicyRetryDeadline = "retry_deadline" // retry until deadline, then fail transaction.
+
+	// Default VM failure policy and health check configs.
+	DefaultVMFailurePolicy     = VMFailurePolicyHalt // Default VM failure policy.
+	DefaultRetryDeadline       = 5000                // Default VM retry deadline in ms (retry_deadline policy).
+	DefaultHealthCheckInterval = 1000                // Default VM health check interval in ms.
+	DefaultHealthCheckTimeout  = 500                 // Default VM health check request timeout in ms.
+
+	// Default data server read cache size (entries).
+	DefaultDSCacheSize = 1024
 )
 
 // Virtual machine connection config (see config/vm.toml).
@@ -42,21 +59,73 @@ type VMConfig struct {
 
 	// Retry policy.
 	// Example how backoff works - https://stackoverflow.com/questions/43224683/what-does-backoffmultiplier-mean-in-defaultretrypolicy.
-	MaxAttempts       int     `mapstructure:"vm_retry_max_attempts"`       // maximum attempts for retry, for infinity retry - use 0.
-	InitialBackoff    int     `mapstructure:"vm_retry_initial_backoff"`    // initial back off in ms.
-	MaxBackoff        int     `mapstructure:"vm_retry_max_backoff"`        // max backoff in ms.
-	BackoffMultiplier float64 `mapstructure:"vm_retry_backoff_multiplier"` // backoff multiplier.
+	MaxAttempts        int     `mapstructure:"vm_retry_max_attempts"`       // maximum attempts for retry, for infinity retry - use 0.
+	InitialBackoff     int     `mapstructure:"vm_retry_initial_backoff"`    // initial back off in ms.
+	MaxBackoff         int     `mapstructure:"vm_retry_max_backoff"`        // max backoff in ms.
+	BackoffMultiplier  float64 `mapstructure:"vm_retry_backoff_multiplier"` // backoff multiplier.
+	DeliverMaxAttempts int     `mapstructure:"vm_deliver_max_attempts"`     // maximum attempts for retry on DeliverTx (caps infinity retry), 0 - no cap.
+
+	// Failure policy and health check.
+	FailurePolicy       string `mapstructure:"vm_failure_policy"`        // VM failure policy: halt, fail_tx, retry_deadline.
+	RetryDeadline       int    `mapstructure:"vm_retry_deadline"`        // retry deadline in ms (retry_deadline policy).
+	HealthCheckInterval int    `mapstructure:"vm_health_check_interval"` // health check interval in ms, 0 - disabled.
+	HealthCheckTimeout  int    `mapstructure:"vm_health_check_timeout"`  // health check request timeout in ms.
+
+	// Data server.
+	DSCacheSize int `mapstructure:"vm_data_cache_size"` // data server read cache size (entries), 0 - disabled.
+
+	// Metrics.
+	MetricsListen string `mapstructure:"vm_metrics_listen"` // dedicated Prometheus metrics server listen address, empty - disabled.
+}
+
+// Validate VM config.
+func (c VMConfig) Validate() error {
+	switch c.FailurePolicy {
+	case "", VMFailurePolicyHalt, VMFailurePolicyFailTx:
+	case VMFailurePolicyRetryDeadline:
+		if c.RetryDeadline <= 0 {
+			return fmt.Errorf("vm_retry_deadline should be positive for %q failure policy", c.FailurePolicy)
+		}
+	default:
+		return fmt.Errorf("vm_failure_policy: unknown policy %q", c.FailurePolicy)
+	}
+
+	if c.DeliverMaxAttempts < 0 {
+		return fmt.Errorf("vm_deliver_max_attempts should be non-negative")
+	}
+
+	if c.HealthCheckInterval < 0 {
+		return fmt.Errorf("vm_health_check_interval should be non-negative")
+	}
+
+	if c.HealthCheckInterval > 0 && c.HealthCheckTimeout <= 0 {
+		return fmt.Errorf("vm_health_check_timeout should be positive")
+	}
+
+	if c.DSCacheSize < 0 {
+		return fmt.Errorf("vm_data_cache_size should be non-negative")
+	}
+
+	return nil
 }
 
 // Default VM configuration.
 func DefaultVMConfig() *VMConfig {
 	return &VMConfig{
-		Address:           DefaultVMAddress,
-		DataListen:        DefaultDataListen,
-		MaxAttempts:       DefaultMaxAttempts,
-		InitialBackoff:    DefaultInitialBackoff,
-		MaxBackoff:        DefaultMaxBackoff,
-		BackoffMultiplier: DefaultBackoffMultiplier,
+		Address:            DefaultVMAddress,
+		DataListen:         DefaultDataListen,
+		MaxAttempts:        DefaultMaxAttempts,
+		InitialBackoff:     DefaultInitialBackoff,
+		MaxBackoff:         DefaultMaxBackoff,
+		BackoffMultiplier:  DefaultBackoffMultiplier,
+		DeliverMaxAttempts: DefaultDeliverMaxAttempts,
+
+		FailurePolicy:       DefaultVMFailurePolicy,
+		RetryDeadline:       DefaultRetryDeadline,
+		HealthCheckInterval: DefaultHealthCheckInterval,
+		HealthCheckTimeout:  DefaultHealthCheckTimeout,
+
+		DSCacheSize: DefaultDSCacheSize,
 	}
 }
 
@@ -102,5 +171,9 @@ func ReadVMConfig(rootDir string) (*VMConfig, error) {
 		panic(err)
 	}
 
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", configFilePath, err)
+	}
+
 	return config, nil
 }
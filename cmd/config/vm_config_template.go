@@ -43,4 +43,45 @@ vm_retry_max_backoff = {{ .MaxBackoff }}
 ## Backoff multiplier.
 ## Default is 0.1 
 vm_retry_backoff_multiplier = {{ .BackoffMultiplier }}
+
+## Retry max attempts on DeliverTx, caps vm_retry_max_attempts (including infinity attempts).
+## Node halts once attempts are exhausted on DeliverTx.
+## Default is 1000, 0 - no cap.
+vm_deliver_max_attempts = {{ .DeliverMaxAttempts }}
+
+# VM failure policy (VM is not reachable / max retry attempts reached).
+## halt - panic and halt the node (default).
+## fail_tx - fail transaction with "VM is not available" error code.
+## retry_deadline - retry until deadline (vm_retry_deadline), then fail transaction.
+## Note: policy doesn't cover DeliverTx, it is applied to CheckTx / simulation only.
+## DeliverTx retries up to vm_deliver_max_attempts and then always halts the node
+## (failing tx depending on local VM availability leads to state divergence between validators).
+vm_failure_policy = "{{ .FailurePolicy }}"
+
+## Retry deadline in ms (retry_deadline policy).
+## Default is 5000ms.
+vm_retry_deadline = {{ .RetryDeadline }}
+
+# VM health check settings.
+# VM is marked down if health check fails, transactions with VM messages are rejected on CheckTx while VM is down.
+
+## Health check interval in ms.
+## Default is 1000ms, 0 - to disable.
+vm_health_check_interval = {{ .HealthCheckInterval }}
+
+## Health check request timeout in ms.
+## Default is 500ms.
+vm_health_check_timeout = {{ .HealthCheckTimeout }}
+
+# VM data server read cache size (entries).
+# Cache contains VM storage values read by VM during the current block, it is reset on commit.
+## Default is 1024, 0 - to disable.
+vm_data_cache_size = {{ .DSCacheSize }}
+
+# Metrics settings.
+# Prometheus metrics are also served by Tendermint instrumentation endpoint (if enabled in config.toml).
+
+## Dedicated Prometheus metrics server listen address (host:port).
+## Default is empty - disabled.
+vm_metrics_listen = "{{ .MetricsListen }}"
 `
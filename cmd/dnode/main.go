@@ -31,6 +31,13 @@ import (
 	vmCli "github.com/dfinance/dnode/x/vm/client/cli"
 )
 
+const (
+	flagInvCheckPeriod = "inv-check-period"
+)
+
+// Assert registered invariants every N blocks (0 - disabled).
+var invCheckPeriod uint
+
 // @title Dfinance dnode REST API
 // @version 1.0
 
@@ -77,6 +84,7 @@ func main() {
 	)
 
 	server.AddCommands(ctx, cdc, rootCmd, newApp, exportAppStateAndTMValidators)
+	rootCmd.PersistentFlags().UintVar(&invCheckPeriod, flagInvCheckPeriod, 0, "Assert registered invariants every N blocks (0 - disabled)")
 
 	// configure crash logging
 	if err := logger.SetupSentry(version.ServerName, version.Version, version.Commit); err != nil {
@@ -101,7 +109,7 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 		panic(err)
 	}
 
-	return app.NewDnServiceApp(logger, db, config)
+	return app.NewDnServiceApp(logger, db, config, invCheckPeriod)
 }
 
 // Exports genesis data and validators.
@@ -114,7 +122,7 @@ func exportAppStateAndTMValidators(
 	}
 
 	if height != -1 {
-		dnApp := app.NewDnServiceApp(logger, db, config)
+		dnApp := app.NewDnServiceApp(logger, db, config, invCheckPeriod)
 		err := dnApp.LoadHeight(height)
 		if err != nil {
 			return nil, nil, err
@@ -122,7 +130,7 @@ func exportAppStateAndTMValidators(
 		return dnApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 	}
 
-	dnApp := app.NewDnServiceApp(logger, db, config)
+	dnApp := app.NewDnServiceApp(logger, db, config, invCheckPeriod)
 	return dnApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 }
 
@@ -0,0 +1,226 @@
+// Prometheus metrics for VM, data source and modules.
+// Metrics are registered within default Prometheus registry and could be served by Tendermint instrumentation endpoint
+// (instrumentation.prometheus config option) and / or by dedicated listener (vm_metrics_listen VM config option).
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// Metrics namespace.
+	Namespace = "dnode"
+
+	// Metrics subsystems.
+	SubsystemVM       = "vm"
+	SubsystemDS       = "ds"
+	SubsystemOracle   = "oracle"
+	SubsystemMultisig = "multisig"
+
+	// Labels.
+	LabelResult  = "result"
+	LabelMethod  = "method"
+	LabelMsgType = "msg_type"
+	LabelAsset   = "asset"
+	LabelStatus  = "status"
+
+	// Label values.
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+
+	// Multisig call status label values.
+	MultisigStatusSubmitted = "submitted"
+	MultisigStatusExecuted  = "executed"
+	MultisigStatusFailed    = "failed"
+	MultisigStatusRejected  = "rejected"
+)
+
+var (
+	current    = NopMetrics()
+	enableOnce sync.Once
+)
+
+// Metrics contains VM, data source and modules metrics.
+type Metrics struct {
+	// VM request attempts (retryExecReq), labels: result.
+	VMRequestAttempts metrics.Counter
+	// VM request attempt durations in seconds, labels: result.
+	VMRequestDuration metrics.Histogram
+	// VM requests failed after all retries.
+	VMRequestFailures metrics.Counter
+	// VM gas used per message, labels: msg_type.
+	VMGasUsed metrics.Histogram
+	// VM availability (1 - up, 0 - down).
+	VMUp metrics.Gauge
+
+	// Data source requests, labels: method.
+	DSRequests metrics.Counter
+	// Data source requests with no data found, labels: method.
+	DSMisses metrics.Counter
+	// Data source requests served by middlewares.
+	DSMiddlewareHits metrics.Counter
+	// Data source read cache hits.
+	DSCacheHits metrics.Counter
+	// Data source read cache misses.
+	DSCacheMisses metrics.Counter
+
+	// Oracle current price updates, labels: asset.
+	OraclePriceUpdates metrics.Counter
+
+	// Multisig calls, labels: status (submitted, executed, failed, rejected).
+	MultisigCalls metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+func PrometheusMetrics() *Metrics {
+	return &Metrics{
+		VMRequestAttempts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemVM,
+			Name:      "request_attempts",
+			Help:      "Number of VM execution request attempts.",
+		}, []string{LabelResult}),
+		VMRequestDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemVM,
+			Name:      "request_duration_seconds",
+			Help:      "VM execution request attempt duration in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.001, 2, 15),
+		}, []string{LabelResult}),
+		VMRequestFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemVM,
+			Name:      "request_failures",
+			Help:      "Number of VM execution requests failed after all retry attempts.",
+		}, nil),
+		VMGasUsed: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemVM,
+			Name:      "gas_used",
+			Help:      "VM gas used per message.",
+			Buckets:   stdprometheus.ExponentialBuckets(100, 4, 10),
+		}, []string{LabelMsgType}),
+		VMUp: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemVM,
+			Name:      "up",
+			Help:      "VM availability (1 - up, 0 - down).",
+		}, nil),
+		DSRequests: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemDS,
+			Name:      "requests",
+			Help:      "Number of data source requests.",
+		}, []string{LabelMethod}),
+		DSMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemDS,
+			Name:      "misses",
+			Help:      "Number of data source requests with no data found.",
+		}, []string{LabelMethod}),
+		DSMiddlewareHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemDS,
+			Name:      "middleware_hits",
+			Help:      "Number of data source requests served by middlewares.",
+		}, nil),
+		DSCacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemDS,
+			Name:      "cache_hits",
+			Help:      "Number of data source read cache hits.",
+		}, nil),
+		DSCacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemDS,
+			Name:      "cache_misses",
+			Help:      "Number of data source read cache misses.",
+		}, nil),
+		OraclePriceUpdates: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemOracle,
+			Name:      "price_updates",
+			Help:      "Number of current price updates.",
+		}, []string{LabelAsset}),
+		MultisigCalls: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: SubsystemMultisig,
+			Name:      "calls",
+			Help:      "Number of multisig calls by status.",
+		}, []string{LabelStatus}),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		VMRequestAttempts:  discard.NewCounter(),
+		VMRequestDuration:  discard.NewHistogram(),
+		VMRequestFailures:  discard.NewCounter(),
+		VMGasUsed:          discard.NewHistogram(),
+		VMUp:               discard.NewGauge(),
+		DSRequests:         discard.NewCounter(),
+		DSMisses:           discard.NewCounter(),
+		DSMiddlewareHits:   discard.NewCounter(),
+		DSCacheHits:        discard.NewCounter(),
+		DSCacheMisses:      discard.NewCounter(),
+		OraclePriceUpdates: discard.NewCounter(),
+		MultisigCalls:      discard.NewCounter(),
+	}
+}
+
+// Get current metrics (no-op metrics if Prometheus metrics are not enabled).
+func Get() *Metrics {
+	return current
+}
+
+// Enable Prometheus metrics (registered only once within default Prometheus registry).
+// Should be called on app initialization before metrics are used.
+func EnablePrometheus() {
+	enableOnce.Do(func() {
+		current = PrometheusMetrics()
+		current.VMUp.Set(1)
+	})
+}
+
+// Start dedicated Prometheus metrics HTTP server (non-blocking).
+func StartServer(listenAddr string) (*http.Server, error) {
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("metrics listener %q: %w", listenAddr, err)
+	}
+
+	srv := &http.Server{
+		Handler: promhttp.InstrumentMetricHandler(
+			stdprometheus.DefaultRegisterer, promhttp.HandlerFor(
+				stdprometheus.DefaultGatherer,
+				promhttp.HandlerOpts{},
+			),
+		),
+	}
+
+	go func() {
+		srv.Serve(listener)
+	}()
+
+	return srv, nil
+}
+
+// Stop metrics HTTP server.
+func StopServer(srv *http.Server) error {
+	if srv == nil {
+		return nil
+	}
+
+	return srv.Shutdown(context.Background())
+}
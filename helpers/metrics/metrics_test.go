@@ -0,0 +1,45 @@
+// +build unit
+
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/stretchr/testify/require"
+)
+
+// Check metrics are served by dedicated server once Prometheus metrics are enabled.
+func TestMetrics_Server(t *testing.T) {
+	// no-op metrics by default
+	Get().DSRequests.With(LabelMethod, "get_raw").Add(1)
+
+	EnablePrometheus()
+	EnablePrometheus()
+
+	Get().DSRequests.With(LabelMethod, "get_raw").Add(2)
+	Get().MultisigCalls.With(LabelStatus, MultisigStatusSubmitted).Add(1)
+
+	_, port, err := server.FreeTCPAddr()
+	require.NoError(t, err)
+
+	srv, err := StartServer("127.0.0.1:" + port)
+	require.NoError(t, err)
+	defer StopServer(srv)
+
+	resp, err := http.Get("http://127.0.0.1:" + port + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	lines := strings.Split(string(body), "\n")
+	require.Contains(t, lines, `dnode_ds_requests{method="get_raw"} 2`)
+	require.Contains(t, lines, `dnode_multisig_calls{status="submitted"} 1`)
+	require.Contains(t, lines, `dnode_vm_up 1`)
+}
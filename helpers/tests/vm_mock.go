@@ -0,0 +1,346 @@
+package tests
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/dfinance/dvm-proto/go/ds_grpc"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"google.golang.org/grpc"
+
+	"github.com/dfinance/dnode/helpers"
+)
+
+const (
+	// Default gas used by mocked execution.
+	VMMockDefaultGasUsed = 1
+	// Move executed status code (VM execution succeeded).
+	VMMockExecutedStatus = 4001
+)
+
+// Check types.
+var (
+	_ vm_grpc.VMServiceServer  = (*VMMockServer)(nil)
+	_ vm_grpc.VMCompilerServer = (*VMMockServer)(nil)
+)
+
+// Data source reader used by mocked execution to read values via DS server.
+type VMMockDataSource interface {
+	GetRaw(address, path []byte) ([]byte, error)
+}
+
+// Mocked contract execution handler.
+type VMMockExecHandler func(contract *vm_grpc.VMContract, ds VMMockDataSource) (*vm_grpc.VMExecuteResponse, error)
+
+// In-process VM server mock: VM and compiler services.
+// Executions are processed by canned responses queue (FIFO) and then by exec handler (default one if not set).
+// Compilation results are taken from registered sources.
+type VMMockServer struct {
+	vm_grpc.UnimplementedVMServiceServer
+	vm_grpc.UnimplementedVMCompilerServer
+
+	mu sync.Mutex
+
+	server   *grpc.Server
+	dsConn   *grpc.ClientConn
+	dsClient ds_grpc.DSServiceClient
+
+	responses   []*vm_grpc.VMExecuteResponse
+	errors      []error
+	execHandler VMMockExecHandler
+	compiled    map[string][]byte
+	requests    []*vm_grpc.VMExecuteRequest
+}
+
+// Create VM server mock.
+func NewVMMockServer() *VMMockServer {
+	return &VMMockServer{
+		execHandler: VMMockDefaultExecHandler,
+		compiled:    make(map[string][]byte),
+	}
+}
+
+// Create and start VM server mock on a free TCP port connected to DS server.
+func NewVMMockServerWithNetTransport(dsServerAddress string) (retServer *VMMockServer, retPort string, retErr error) {
+	_, port, err := server.FreeTCPAddr()
+	if err != nil {
+		retErr = fmt.Errorf("FreeTCPAddr (VMMock): %w", err)
+		return
+	}
+	retPort = port
+
+	listener, err := net.Listen("tcp", "127.0.0.1:"+port)
+	if err != nil {
+		retErr = fmt.Errorf("listen (VMMock): %w", err)
+		return
+	}
+
+	retServer = NewVMMockServer()
+	if err := retServer.ConnectDataSource(dsServerAddress); err != nil {
+		listener.Close()
+		retErr = err
+		return
+	}
+	retServer.Start(listener)
+
+	return
+}
+
+// Start serving on listener (non-blocking).
+func (s *VMMockServer) Start(listener net.Listener) {
+	s.server = grpc.NewServer()
+	vm_grpc.RegisterVMServiceServer(s.server, s)
+	vm_grpc.RegisterVMCompilerServer(s.server, s)
+
+	go func() {
+		s.server.Serve(listener)
+	}()
+}
+
+// Stop serving and close DS connection.
+func (s *VMMockServer) Stop() {
+	if s.server != nil {
+		s.server.Stop()
+	}
+
+	if s.dsConn != nil {
+		s.dsConn.Close()
+	}
+}
+
+// Connect to DS server by address (UNIX/TCP).
+func (s *VMMockServer) ConnectDataSource(address string) error {
+	conn, err := helpers.GetGRpcClientConnection(address, 0)
+	if err != nil {
+		return fmt.Errorf("DS connection (VMMock): %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dsConn = conn
+	s.dsClient = ds_grpc.NewDSServiceClient(conn)
+
+	return nil
+}
+
+// Set DS client (for custom transports like bufconn).
+func (s *VMMockServer) SetDataSourceClient(client ds_grpc.DSServiceClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dsClient = client
+}
+
+// Add canned response for the next executed contract.
+func (s *VMMockServer) PushResponse(resps ...*vm_grpc.VMExecuteResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses = append(s.responses, resps...)
+}
+
+// Add gRPC error for the next execution request.
+func (s *VMMockServer) PushError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errors = append(s.errors, err)
+}
+
+// Set execution handler used when no canned responses left (nil resets to default).
+func (s *VMMockServer) SetExecHandler(handler VMMockExecHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if handler == nil {
+		handler = VMMockDefaultExecHandler
+	}
+	s.execHandler = handler
+}
+
+// Register compilation result for source code.
+func (s *VMMockServer) SetCompiled(source string, bytecode []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.compiled[source] = bytecode
+}
+
+// Get received execution requests.
+func (s *VMMockServer) Requests() []*vm_grpc.VMExecuteRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*vm_grpc.VMExecuteRequest(nil), s.requests...)
+}
+
+// Reset canned responses, errors and received requests.
+func (s *VMMockServer) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses, s.errors, s.requests = nil, nil, nil
+	s.execHandler = VMMockDefaultExecHandler
+}
+
+// Read value from DS server.
+func (s *VMMockServer) getRaw(address, path []byte) ([]byte, error) {
+	s.mu.Lock()
+	client := s.dsClient
+	s.mu.Unlock()
+
+	if client == nil {
+		return nil, fmt.Errorf("DS client not connected")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.GetRaw(ctx, &ds_grpc.DSAccessPath{Address: address, Path: path})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.ErrorCode != ds_grpc.DSRawResponse_NONE {
+		return nil, fmt.Errorf("DS error %s: %s", resp.ErrorCode.String(), resp.ErrorMessage)
+	}
+
+	return resp.Blob, nil
+}
+
+// Execute contracts using canned responses / exec handler.
+func (s *VMMockServer) ExecuteContracts(_ context.Context, req *vm_grpc.VMExecuteRequest) (*vm_grpc.VMExecuteResponses, error) {
+	ds := vmMockRequestDataSource{server: s}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	if len(s.errors) > 0 {
+		err := s.errors[0]
+		s.errors = s.errors[1:]
+		s.mu.Unlock()
+
+		return nil, err
+	}
+	s.mu.Unlock()
+
+	resps := &vm_grpc.VMExecuteResponses{
+		Executions: make([]*vm_grpc.VMExecuteResponse, 0, len(req.Contracts)),
+	}
+
+	for _, contract := range req.Contracts {
+		s.mu.Lock()
+		handler := s.execHandler
+		var resp *vm_grpc.VMExecuteResponse
+		if len(s.responses) > 0 {
+			resp = s.responses[0]
+			s.responses = s.responses[1:]
+		}
+		s.mu.Unlock()
+
+		if resp == nil {
+			var err error
+			if resp, err = handler(contract, ds); err != nil {
+				return nil, err
+			}
+		}
+
+		resps.Executions = append(resps.Executions, resp)
+	}
+
+	return resps, nil
+}
+
+// Data source reader for exec handlers.
+type vmMockRequestDataSource struct {
+	server *VMMockServer
+}
+
+// Read value from DS server within execution.
+func (ds vmMockRequestDataSource) GetRaw(address, path []byte) ([]byte, error) {
+	return ds.server.getRaw(address, path)
+}
+
+// Compile source using registered compilation results.
+func (s *VMMockServer) Compile(_ context.Context, req *vm_grpc.MvIrSourceFile) (*vm_grpc.CompilationResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bytecode, ok := s.compiled[req.Text]
+	if !ok {
+		return &vm_grpc.CompilationResult{Errors: []string{"source not registered in VM mock"}}, nil
+	}
+
+	return &vm_grpc.CompilationResult{Bytecode: bytecode}, nil
+}
+
+// Default execution handler: module bytecode is written under contract address, scripts are kept with no changes.
+func VMMockDefaultExecHandler(contract *vm_grpc.VMContract, _ VMMockDataSource) (*vm_grpc.VMExecuteResponse, error) {
+	if contract.ContractType != vm_grpc.ContractType_Module {
+		return NewVMMockKeepResponse(VMMockDefaultGasUsed, nil, nil), nil
+	}
+
+	address, err := hex.DecodeString(strings.TrimPrefix(contract.Address, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("contract address %q: %w", contract.Address, err)
+	}
+
+	codeHash := sha256.Sum256(contract.Code)
+	writeSet := []*vm_grpc.VMValue{
+		{
+			Type:  vm_grpc.VmWriteOp_Value,
+			Value: contract.Code,
+			Path: &vm_grpc.VMAccessPath{
+				Address: address,
+				Path:    append([]byte{0x0}, codeHash[:]...),
+			},
+		},
+	}
+
+	return NewVMMockKeepResponse(VMMockDefaultGasUsed, writeSet, nil), nil
+}
+
+// Create execution response with keep status.
+func NewVMMockKeepResponse(gasUsed uint64, writeSet []*vm_grpc.VMValue, events []*vm_grpc.VMEvent) *vm_grpc.VMExecuteResponse {
+	return &vm_grpc.VMExecuteResponse{
+		WriteSet: writeSet,
+		Events:   events,
+		GasUsed:  gasUsed,
+		Status:   vm_grpc.ContractStatus_Keep,
+		StatusStruct: &vm_grpc.VMStatus{
+			MajorStatus: VMMockExecutedStatus,
+		},
+	}
+}
+
+// Create execution response with keep status and error code (aborted execution).
+func NewVMMockErrorResponse(gasUsed, majorStatus, subStatus uint64, message string) *vm_grpc.VMExecuteResponse {
+	return &vm_grpc.VMExecuteResponse{
+		GasUsed: gasUsed,
+		Status:  vm_grpc.ContractStatus_Keep,
+		StatusStruct: &vm_grpc.VMStatus{
+			MajorStatus: majorStatus,
+			SubStatus:   subStatus,
+			Message:     message,
+		},
+	}
+}
+
+// Create execution response with discard status.
+func NewVMMockDiscardResponse(majorStatus, subStatus uint64, message string) *vm_grpc.VMExecuteResponse {
+	return &vm_grpc.VMExecuteResponse{
+		Status: vm_grpc.ContractStatus_Discard,
+		StatusStruct: &vm_grpc.VMStatus{
+			MajorStatus: majorStatus,
+			SubStatus:   subStatus,
+			Message:     message,
+		},
+	}
+}
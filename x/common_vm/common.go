@@ -2,6 +2,7 @@ package common_vm
 
 import (
 	"bytes"
+	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/dfinance/dvm-proto/go/vm_grpc"
@@ -10,6 +11,8 @@ import (
 const (
 	// Default address length.
 	VMAddressLength = 24
+	// Resource access path tag.
+	ResourcePathTag = 0x01
 )
 
 var (
@@ -18,9 +21,27 @@ var (
 	ZeroAddress  = make([]byte, VMAddressLength)
 )
 
+// Context key for current VM tx sender.
+type txSenderCtxKey struct{}
+
 // Data server middleware type.
 type DSDataMiddleware func(ctx sdk.Context, path *vm_grpc.VMAccessPath) ([]byte, error)
 
+// VM execution write set handler type (called after write set is applied to VM storage).
+type VMWriteSetHandler func(ctx sdk.Context, writeSet []*vm_grpc.VMValue)
+
+// Interface for other keepers to register data server middlewares (on app initialization).
+type DSMiddlewareRegistry interface {
+	// Register data middleware, middlewares are processed in registration order.
+	RegisterDSDataMiddleware(md DSDataMiddleware)
+}
+
+// Interface for other keepers to register VM execution write set handlers (on app initialization).
+type VMWriteSetHandlerRegistry interface {
+	// Register write set handler, handlers are processed in registration order.
+	RegisterVMWriteSetHandler(handler VMWriteSetHandler)
+}
+
 // Interface for other keepers to get/set data.
 type VMStorage interface {
 	// Access path for oracle.
@@ -35,6 +56,26 @@ type VMStorage interface {
 
 	// Has value in VM storage.
 	HasValue(ctx sdk.Context, accessPath *vm_grpc.VMAccessPath) bool
+
+	// Iterate over all VM storage values, handler returns true to stop iteration.
+	IterateValues(ctx sdk.Context, handler func(accessPath *vm_grpc.VMAccessPath, value []byte) (stop bool))
+}
+
+// Interface to check VM availability (used by ante handler to reject VM messages while VM is down).
+type VMHealthChecker interface {
+	// Check VM is available.
+	IsVMAvailable() bool
+	// Check msg route is handled by VM (tx msg or multisig call msg route).
+	IsVMRoute(msgRoute string) bool
+}
+
+// Interface to get VM balance resource paths registered for denoms (used by accounts keeper).
+type DenomPathsProvider interface {
+	// Get balance resource path for denom, returns false if path is not registered.
+	GetDenomPath(ctx sdk.Context, denom string) ([]byte, bool)
+	// Iterate over registered denom paths (sorted by denom), handler returns true to stop iteration.
+	// Paths are cached per block and read is not charged (registry is read on every account read / write).
+	IterateDenomPaths(ctx sdk.Context, handler func(denom string, path []byte) (stop bool))
 }
 
 // Make path for storage from VMAccessPath.
@@ -49,7 +90,61 @@ func MakePathKey(path *vm_grpc.VMAccessPath) []byte {
 	)
 }
 
+// Parse storage key made by MakePathKey to VMAccessPath.
+// Address is expected to be VMAddressLength bytes, otherwise key is split by the first delimiter after address.
+func ParsePathKey(key []byte) (*vm_grpc.VMAccessPath, error) {
+	prefix := append(append([]byte{}, VMKey...), KeyDelimiter...)
+	if !bytes.HasPrefix(key, prefix) {
+		return nil, fmt.Errorf("key %X: VM prefix not found", key)
+	}
+	key = key[len(prefix):]
+
+	addrLen := VMAddressLength
+	if len(key) < addrLen+len(KeyDelimiter) || !bytes.Equal(key[addrLen:addrLen+len(KeyDelimiter)], KeyDelimiter) {
+		addrLen = bytes.Index(key, KeyDelimiter)
+		if addrLen < 0 {
+			return nil, fmt.Errorf("key %X: address delimiter not found", key)
+		}
+	}
+
+	return &vm_grpc.VMAccessPath{
+		Address: key[:addrLen],
+		Path:    key[addrLen+len(KeyDelimiter):],
+	}, nil
+}
+
+// Get synthetic resource access path under zero address (data is provided by DS middlewares, not stored).
+// Path is derived from "0x0::{module}::{name}<{typeParams}>" struct tag the same way VM does.
+func GetSyntheticResourcePath(module, name string, typeParams ...StructTag) *vm_grpc.VMAccessPath {
+	return NewStructTag(module, name, typeParams...).AccessPath(ZeroAddress)
+}
+
+// Check access paths are equal.
+func IsPathEqual(path1, path2 *vm_grpc.VMAccessPath) bool {
+	return bytes.Equal(path1.Address, path2.Address) && bytes.Equal(path1.Path, path2.Path)
+}
+
+// Set current VM tx sender to context (used by DS middlewares).
+func WithTxSender(ctx sdk.Context, sender sdk.AccAddress) sdk.Context {
+	return ctx.WithValue(txSenderCtxKey{}, sender)
+}
+
+// Get current VM tx sender from context.
+func GetTxSender(ctx sdk.Context) (sdk.AccAddress, bool) {
+	sender, ok := ctx.Value(txSenderCtxKey{}).(sdk.AccAddress)
+	return sender, ok && !sender.Empty()
+}
+
 // Convert bech32 to libra hex.
 func Bech32ToLibra(addr sdk.AccAddress) []byte {
 	return append(addr, make([]byte, 4)...)
 }
+
+// Convert libra address to bech32, returns false if address is not converted from bech32.
+func LibraToBech32(addr []byte) (sdk.AccAddress, bool) {
+	if len(addr) != VMAddressLength || !bytes.Equal(addr[sdk.AddrLen:], make([]byte, VMAddressLength-sdk.AddrLen)) {
+		return nil, false
+	}
+
+	return append(sdk.AccAddress{}, addr[:sdk.AddrLen]...), true
+}
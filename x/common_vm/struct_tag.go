@@ -0,0 +1,85 @@
+package common_vm
+
+import (
+	"strings"
+
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"github.com/dfinance/lcs"
+	"github.com/tendermint/crypto/sha3"
+)
+
+const (
+	// Move TypeTag enum struct variant index (struct tag type parameters).
+	typeTagStructIdx = 7
+	// Move StructTag hash salt (VM hashes LCS encoded struct tag prefixed with salt hash).
+	structTagHashSalt = "move_core_types::language_storage::StructTag@@$$LIBRA$$@@"
+
+	// Currencies modules (currency struct tag is DFI::T for "dfi" and Coins::{DENOM} for others).
+	dfiDenom          = "dfi"
+	dfiCurrencyModule = "DFI"
+	dfiCurrencyName   = "T"
+	coinsModule       = "Coins"
+)
+
+// Move struct tag (resource type identifier), resource access path is derived from its LCS encoded form.
+// Only struct type parameters are supported.
+type StructTag struct {
+	Address    []byte `lcs:"len=24"`
+	Module     string
+	Name       string
+	TypeParams []interface{} `lcs:"enum=TypeTag"` // *StructTag values (TypeTag::Struct variant)
+}
+
+// LCS TypeTag enum variants (only struct variant is supported).
+func (StructTag) EnumTypes() []lcs.EnumVariant {
+	return []lcs.EnumVariant{
+		{
+			Name:     "TypeTag",
+			Value:    typeTagStructIdx,
+			Template: (*StructTag)(nil),
+		},
+	}
+}
+
+// Get resource access path: resource tag followed by struct tag hash.
+func (tag StructTag) AccessPath(address []byte) *vm_grpc.VMAccessPath {
+	bz, err := lcs.Marshal(tag)
+	if err != nil {
+		panic(err)
+	}
+
+	salt := sha3.Sum256([]byte(structTagHashSalt))
+	hash := sha3.New256()
+	hash.Write(salt[:])
+	hash.Write(bz)
+
+	return &vm_grpc.VMAccessPath{
+		Address: address,
+		Path:    hash.Sum([]byte{ResourcePathTag}),
+	}
+}
+
+// Create struct tag for module / struct under zero address.
+func NewStructTag(module, name string, typeParams ...StructTag) StructTag {
+	tag := StructTag{
+		Address:    ZeroAddress,
+		Module:     module,
+		Name:       name,
+		TypeParams: make([]interface{}, 0, len(typeParams)),
+	}
+
+	for i := range typeParams {
+		tag.TypeParams = append(tag.TypeParams, &typeParams[i])
+	}
+
+	return tag
+}
+
+// Get currency struct tag for denom: DFI::T for "dfi", Coins::{DENOM} (upper case) for others.
+func NewCurrencyStructTag(denom string) StructTag {
+	if denom == dfiDenom {
+		return NewStructTag(dfiCurrencyModule, dfiCurrencyName)
+	}
+
+	return NewStructTag(coinsModule, strings.ToUpper(denom))
+}
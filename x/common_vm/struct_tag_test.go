@@ -0,0 +1,54 @@
+// +build unit
+
+package common_vm
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Check resource paths match paths computed by VM (currencies genesis balance and info paths).
+func TestStructTag_AccessPath(t *testing.T) {
+	testCases := []struct {
+		denom       string
+		balancePath string
+		infoPath    string
+	}{
+		{
+			denom:       "dfi",
+			balancePath: "0136364df51f98e43cbe92dfcfdd807c7fc626aa9690793ebf7d301cfe0de72f11",
+			infoPath:    "011c53cd211c8dd6f27b977dbcf497d6650944f764d15cebf75dcc17f8e2bfa5f4",
+		},
+		{
+			denom:       "eth",
+			balancePath: "01f7c5cca156342bec3ac2bfb6fc439683da605ab7bc3f58881427861c8fc44577",
+			infoPath:    "01b7c72e9510f8bd1bfb20b45f5de59d9289798b6413722cb341aa7c0db02b52bb",
+		},
+		{
+			denom:       "btc",
+			balancePath: "01d09b573d3bea2a3853ad447728fa476925894836352c46711fb7604e962b2903",
+			infoPath:    "018640c82fe545f74fe72e54cc655c43b3eb465d8ce9f902a61b4d3a0ab99aab33",
+		},
+		{
+			denom:       "usdt",
+			balancePath: "01affc8719224641c76bb20b65e4a26c002a39bbc82b753410976002ad7931b270",
+			infoPath:    "016f04631b2df14f2199ad915ae7f620c58c12ac8f6728356c543dbfb719e283cc",
+		},
+	}
+
+	for _, tc := range testCases {
+		currency := NewCurrencyStructTag(tc.denom)
+
+		balancePath := NewStructTag("Account", "Balance", currency).AccessPath(ZeroAddress)
+		require.Equal(t, tc.balancePath, hex.EncodeToString(balancePath.Path), "%s: balance path", tc.denom)
+		require.Equal(t, ZeroAddress, balancePath.Address)
+
+		infoPath := NewStructTag("Dfinance", "Info", currency).AccessPath(ZeroAddress)
+		require.Equal(t, tc.infoPath, hex.EncodeToString(infoPath.Path), "%s: info path", tc.denom)
+	}
+
+	// synthetic resource path is a zero address struct tag path
+	require.Equal(t, NewStructTag("Chain", "ChainInfo").AccessPath(ZeroAddress), GetSyntheticResourcePath("Chain", "ChainInfo"))
+}
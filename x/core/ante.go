@@ -7,15 +7,17 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/ante"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
 
+	"github.com/dfinance/dnode/x/common_vm"
 	"github.com/dfinance/dnode/x/vmauth"
 )
 
 // NewAnteHandler return custom AnteHandler.
-// Adds DenomDecorator and uses standard decorators (standard AnteHandler).
+// Adds DenomDecorator, VMHealthDecorator and uses standard decorators (standard AnteHandler).
 // Some decorators are a copy of 'github.com/cosmos/cosmos-sdk/x/auth/ante' decorators, but using vmauth.VMAccountKeeper.
-func NewAnteHandler(ak vmauth.VMAccountKeeper, supplyKeeper types.SupplyKeeper, sigGasConsumer auth.SignatureVerificationGasConsumer) sdk.AnteHandler {
+func NewAnteHandler(ak vmauth.VMAccountKeeper, supplyKeeper types.SupplyKeeper, vmHealth common_vm.VMHealthChecker, sigGasConsumer auth.SignatureVerificationGasConsumer) sdk.AnteHandler {
 	return sdk.ChainAnteDecorators(
 		NewDenomDecorator(),
+		NewVMHealthDecorator(vmHealth),
 		ante.NewSetUpContextDecorator(),
 		ante.NewMempoolFeeDecorator(),
 		ante.NewValidateBasicDecorator(),
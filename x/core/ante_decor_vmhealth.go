@@ -0,0 +1,39 @@
+package core
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+// VMHealthDecorator rejects transactions with VM messages on CheckTx (and simulation) while VM is marked down,
+// so VM outage doesn't fill the mempool with transactions that can't be executed.
+// VM messages wrapped into multisig calls are rejected too.
+type VMHealthDecorator struct {
+	vmHealth common_vm.VMHealthChecker
+}
+
+func NewVMHealthDecorator(vmHealth common_vm.VMHealthChecker) VMHealthDecorator {
+	return VMHealthDecorator{
+		vmHealth: vmHealth,
+	}
+}
+
+func (vd VMHealthDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	if (ctx.IsCheckTx() || simulate) && !vd.vmHealth.IsVMAvailable() {
+		for _, msg := range tx.GetMsgs() {
+			if vd.vmHealth.IsVMRoute(msg.Route()) {
+				return ctx, sdkErrors.Wrapf(ErrVMUnavailable, "msg %s/%s", msg.Route(), msg.Type())
+			}
+
+			if wrapper, ok := msg.(MsMsgWrapper); ok {
+				if msMsg := wrapper.GetMsMsg(); msMsg != nil && vd.vmHealth.IsVMRoute(msMsg.Route()) {
+					return ctx, sdkErrors.Wrapf(ErrVMUnavailable, "msg %s/%s: multisig call msg %s/%s", msg.Route(), msg.Type(), msMsg.Route(), msMsg.Type())
+				}
+			}
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
@@ -5,6 +5,7 @@
 package core
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -20,7 +21,9 @@ import (
 	log "github.com/tendermint/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
 
-	"github.com/dfinance/dnode/x/vm"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+
+	"github.com/dfinance/dnode/x/common_vm"
 	"github.com/dfinance/dnode/x/vmauth"
 )
 
@@ -28,6 +31,96 @@ var (
 	WrongFees = sdk.Coins{sdk.NewCoin("eth", sdk.NewInt(1))} // wrong fees denom (eth).
 )
 
+// VM storage mock (x/vm keeper can't be used as x/vm imports x/core).
+type vmStorageMock struct {
+	storeKey sdk.StoreKey
+}
+
+func (storage vmStorageMock) GetOracleAccessPath(_ string) *vm_grpc.VMAccessPath {
+	return &vm_grpc.VMAccessPath{}
+}
+
+func (storage vmStorageMock) SetValue(ctx sdk.Context, accessPath *vm_grpc.VMAccessPath, value []byte) {
+	ctx.KVStore(storage.storeKey).Set(common_vm.MakePathKey(accessPath), value)
+}
+
+func (storage vmStorageMock) GetValue(ctx sdk.Context, accessPath *vm_grpc.VMAccessPath) []byte {
+	return ctx.KVStore(storage.storeKey).Get(common_vm.MakePathKey(accessPath))
+}
+
+func (storage vmStorageMock) HasValue(ctx sdk.Context, accessPath *vm_grpc.VMAccessPath) bool {
+	return ctx.KVStore(storage.storeKey).Has(common_vm.MakePathKey(accessPath))
+}
+
+func (storage vmStorageMock) IterateValues(ctx sdk.Context, handler func(accessPath *vm_grpc.VMAccessPath, value []byte) bool) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(storage.storeKey), append(common_vm.VMKey, common_vm.KeyDelimiter...))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		accessPath, err := common_vm.ParsePathKey(iterator.Key())
+		if err != nil {
+			panic(err)
+		}
+
+		if handler(accessPath, iterator.Value()) {
+			break
+		}
+	}
+}
+
+func (storage vmStorageMock) DelValue(ctx sdk.Context, accessPath *vm_grpc.VMAccessPath) {
+	ctx.KVStore(storage.storeKey).Delete(common_vm.MakePathKey(accessPath))
+}
+
+// Denom paths provider mock (x/currencies_register keeper can't be used as it imports x/core).
+type denomPathsMock map[string][]byte
+
+func (paths denomPathsMock) GetDenomPath(_ sdk.Context, denom string) ([]byte, bool) {
+	path, ok := paths[denom]
+	return path, ok
+}
+
+func (paths denomPathsMock) IterateDenomPaths(_ sdk.Context, handler func(denom string, path []byte) bool) {
+	denoms := make([]string, 0, len(paths))
+	for denom := range paths {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+
+	for _, denom := range denoms {
+		if handler(denom, paths[denom]) {
+			return
+		}
+	}
+}
+
+// VM health checker mock.
+type vmHealthMock struct {
+	available bool
+}
+
+func (vmHealth vmHealthMock) IsVMAvailable() bool {
+	return vmHealth.available
+}
+
+func (vmHealth vmHealthMock) IsVMRoute(msgRoute string) bool {
+	return msgRoute == types.NewTestMsg().Route()
+}
+
+// Multisig call msg mock wrapping another msg.
+type msMsgWrapperMock struct {
+	*sdk.TestMsg
+	msMsg MsMsg
+}
+
+func (msg msMsgWrapperMock) Route() string {
+	return "multisig"
+}
+
+func (msg msMsgWrapperMock) GetMsMsg() MsMsg {
+	return msg.msMsg
+}
+
 type testInput struct {
 	cdc *codec.Codec
 	ctx sdk.Context
@@ -55,10 +148,11 @@ func setupTestInput() testInput {
 	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
 	ms.LoadLatestVersion()
 
-	vmk := vm.NewKeeper(vmCapKey, cdc, nil, nil, nil)
+	vmk := vmStorageMock{storeKey: vmCapKey}
 
 	ps := subspace.NewSubspace(cdc, keyParams, tkeyParams, types.DefaultParamspace)
-	ak := vmauth.NewVMAccountKeeper(cdc, authCapKey, ps, vmk, types.ProtoBaseAccount)
+	denomPaths := denomPathsMock{"dfi": {0x00}, "eth": {0x01}}
+	ak := vmauth.NewVMAccountKeeper(cdc, authCapKey, ps, vmk, denomPaths, types.ProtoBaseAccount)
 	sk := mock.NewDummySupplyKeeper(*ak.AccountKeeper)
 
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "test-chain-id", Height: 1}, false, log.NewNopLogger())
@@ -100,7 +194,7 @@ func TestAnteHandlerWrongZeroFee(t *testing.T) {
 	privs, accNums, seqs := []crypto.PrivKey{priv}, []uint64{0}, []uint64{0}
 	tx := types.NewTestTx(input.ctx, msgs, privs, accNums, seqs, fee)
 
-	ah := NewAnteHandler(input.ak, input.sk, auth.DefaultSigVerificationGasConsumer)
+	ah := NewAnteHandler(input.ak, input.sk, vmHealthMock{available: true}, auth.DefaultSigVerificationGasConsumer)
 	checkInvalidTx(t, ah, input.ctx, tx, true, ErrFeeRequired)
 }
 
@@ -124,7 +218,7 @@ func TestAnteHandlerWrongFeeDenom(t *testing.T) {
 	privs, accNums, seqs := []crypto.PrivKey{priv}, []uint64{0}, []uint64{0}
 	tx := types.NewTestTx(input.ctx, msgs, privs, accNums, seqs, fee)
 
-	ah := NewAnteHandler(input.ak, input.sk, auth.DefaultSigVerificationGasConsumer)
+	ah := NewAnteHandler(input.ak, input.sk, vmHealthMock{available: true}, auth.DefaultSigVerificationGasConsumer)
 	checkInvalidTx(t, ah, input.ctx, tx, true, ErrWrongFeeDenom)
 }
 
@@ -148,6 +242,70 @@ func TestCorrectDenomFees(t *testing.T) {
 	privs, accNums, seqs := []crypto.PrivKey{priv}, []uint64{0}, []uint64{0}
 	tx := types.NewTestTx(input.ctx, msgs, privs, accNums, seqs, fee)
 
-	ah := NewAnteHandler(input.ak, input.sk, auth.DefaultSigVerificationGasConsumer)
+	ah := NewAnteHandler(input.ak, input.sk, vmHealthMock{available: true}, auth.DefaultSigVerificationGasConsumer)
 	checkValidTx(t, ah, input.ctx, tx, true)
 }
+
+// nolint:errcheck
+// test VM messages are rejected on CheckTx while VM is not available.
+func TestAnteHandlerVMUnavailable(t *testing.T) {
+	input := setupTestInput()
+
+	priv, _, addr := types.KeyTestPubAddr()
+	acc := input.ak.NewAccountWithAddress(input.ctx, addr)
+
+	acc.SetCoins(DefaultFees)
+
+	input.ak.SetAccount(input.ctx, acc)
+	msg := types.NewTestMsg(addr)
+	fee := auth.StdFee{Gas: 200000, Amount: DefaultFees}
+
+	msgs := []sdk.Msg{msg}
+
+	privs, accNums, seqs := []crypto.PrivKey{priv}, []uint64{0}, []uint64{0}
+	tx := types.NewTestTx(input.ctx, msgs, privs, accNums, seqs, fee)
+
+	ah := NewAnteHandler(input.ak, input.sk, vmHealthMock{available: false}, auth.DefaultSigVerificationGasConsumer)
+
+	// CheckTx
+	_, err := ah(input.ctx.WithIsCheckTx(true), tx, false)
+	require.Error(t, err)
+	require.True(t, ErrVMUnavailable.Is(err))
+
+	// simulation
+	_, err = ah(input.ctx, tx, true)
+	require.Error(t, err)
+	require.True(t, ErrVMUnavailable.Is(err))
+
+	// DeliverTx is not affected
+	checkValidTx(t, ah, input.ctx, tx, false)
+}
+
+// test VM messages wrapped into multisig calls are rejected on CheckTx while VM is not available.
+func TestAnteHandlerVMUnavailableMsMsg(t *testing.T) {
+	input := setupTestInput()
+
+	priv, _, addr := types.KeyTestPubAddr()
+	acc := input.ak.NewAccountWithAddress(input.ctx, addr)
+
+	acc.SetCoins(DefaultFees)
+
+	input.ak.SetAccount(input.ctx, acc)
+	msg := msMsgWrapperMock{TestMsg: types.NewTestMsg(addr), msMsg: types.NewTestMsg(addr)}
+	fee := auth.StdFee{Gas: 200000, Amount: DefaultFees}
+
+	msgs := []sdk.Msg{msg}
+
+	privs, accNums, seqs := []crypto.PrivKey{priv}, []uint64{0}, []uint64{0}
+	tx := types.NewTestTx(input.ctx, msgs, privs, accNums, seqs, fee)
+
+	ah := NewAnteHandler(input.ak, input.sk, vmHealthMock{available: false}, auth.DefaultSigVerificationGasConsumer)
+
+	// CheckTx
+	_, err := ah(input.ctx.WithIsCheckTx(true), tx, false)
+	require.Error(t, err)
+	require.True(t, ErrVMUnavailable.Is(err))
+
+	// DeliverTx is not affected
+	checkValidTx(t, ah, input.ctx, tx, false)
+}
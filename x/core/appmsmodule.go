@@ -13,6 +13,11 @@ type MsMsg interface {
 	ValidateBasic() error
 }
 
+// Message wrapping multisignature call message (multisig submit call), used to inspect wrapped messages in ante handler.
+type MsMsgWrapper interface {
+	GetMsMsg() MsMsg
+}
+
 // Multisignature handler.
 type MsHandler func(ctx sdk.Context, msg MsMsg) error
 
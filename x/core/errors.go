@@ -14,4 +14,6 @@ var (
 	ErrFeeRequired = sdkErrors.Register(Codespace, 101, "tx must contain fees")
 	// StdTx Fee.Amount wrong denom
 	ErrWrongFeeDenom = sdkErrors.Register(Codespace, 102, "tx must contain fees with a different denom")
+	// StdTx contains VM messages, but VM is not available
+	ErrVMUnavailable = sdkErrors.Register(Codespace, 103, "VM is not available, VM messages are rejected")
 )
@@ -0,0 +1,34 @@
+package currencies
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/exported"
+
+	"github.com/dfinance/dnode/x/currencies/types"
+)
+
+// Initialize genesis for this module.
+// Genesis total supply must match genesis accounts coins: currencies issued / destroyed before supply tracking was added
+// are missing from the total supply of genesis exported by previous versions. Such genesis is rejected,
+// it should be migrated by removing the supply module total supply (supply module recalculates it from accounts coins).
+func (keeper Keeper) InitGenesis(ctx sdk.Context, accountKeeper types.AccountKeeper) {
+	accountsTotal := sdk.NewCoins()
+	accountKeeper.IterateAccounts(ctx, func(acc exported.Account) bool {
+		accountsTotal = accountsTotal.Add(acc.GetCoins()...)
+		return false
+	})
+
+	supplyTotal := keeper.supplyKeeper.GetSupply(ctx).GetTotal()
+	for _, coin := range accountsTotal.Add(supplyTotal...) {
+		if accountsTotal.AmountOf(coin.Denom).Equal(supplyTotal.AmountOf(coin.Denom)) {
+			continue
+		}
+
+		panic(fmt.Errorf(
+			"%s genesis: total supply %q doesn't match accounts coins %q (remove supply module total supply from genesis to recalculate it)",
+			types.ModuleName, supplyTotal, accountsTotal,
+		))
+	}
+}
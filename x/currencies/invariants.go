@@ -0,0 +1,35 @@
+// Currencies module invariants.
+package currencies
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dfinance/dnode/x/currencies/types"
+)
+
+// Register currencies module invariants.
+func RegisterInvariants(ir sdk.InvariantRegistry, keeper Keeper) {
+	ir.RegisterRoute(types.ModuleName, "supply", SupplyInvariant(keeper))
+}
+
+// Check issued currencies supply matches total supply (supply module) for currencies denoms.
+func SupplyInvariant(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var broken bool
+
+		total := keeper.supplyKeeper.GetSupply(ctx).GetTotal()
+		keeper.IterateCurrencies(ctx, func(currency types.Currency) bool {
+			if totalAmount := total.AmountOf(currency.Symbol); !totalAmount.Equal(currency.Supply) {
+				broken = true
+				msg += fmt.Sprintf("\t%s: currency supply %s, total supply %s\n", currency.Symbol, currency.Supply, totalAmount)
+			}
+
+			return false
+		})
+
+		return sdk.FormatInvariant(types.ModuleName, "supply", fmt.Sprintf("currencies supply mismatch:\n%s", msg)), broken
+	}
+}
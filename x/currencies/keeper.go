@@ -6,23 +6,29 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 
+	"github.com/dfinance/dnode/x/common_vm"
 	"github.com/dfinance/dnode/x/currencies/types"
 )
 
 // Currency keeper struct.
 type Keeper struct {
-	coinKeeper bank.Keeper
-	cdc        *cdcCodec.Codec
-	storeKey   sdk.StoreKey
+	coinKeeper   bank.Keeper
+	supplyKeeper supply.Keeper
+	denomPaths   common_vm.DenomPathsProvider
+	cdc          *cdcCodec.Codec
+	storeKey     sdk.StoreKey
 }
 
 // Create new currency keeper.
-func NewKeeper(coinKeeper bank.Keeper, storeKey sdk.StoreKey, cdc *cdcCodec.Codec) Keeper {
+func NewKeeper(coinKeeper bank.Keeper, supplyKeeper supply.Keeper, denomPaths common_vm.DenomPathsProvider, storeKey sdk.StoreKey, cdc *cdcCodec.Codec) Keeper {
 	return Keeper{
-		coinKeeper: coinKeeper,
-		storeKey:   storeKey,
-		cdc:        cdc,
+		coinKeeper:   coinKeeper,
+		supplyKeeper: supplyKeeper,
+		denomPaths:   denomPaths,
+		storeKey:     storeKey,
+		cdc:          cdc,
 	}
 }
 
@@ -36,17 +42,27 @@ func (keeper Keeper) DestroyCurrency(ctx sdk.Context, chainID, symbol, recipient
 
 	newCoin := sdk.NewCoin(symbol, amount)
 
-	_, err := keeper.coinKeeper.SubtractCoins(ctx, spender, sdk.Coins{newCoin})
+	if _, err := keeper.coinKeeper.SubtractCoins(ctx, spender, sdk.Coins{newCoin}); err != nil {
+		return err
+	}
+
+	// Destroyed coins are removed from total supply.
+	keeper.supplyKeeper.SetSupply(ctx, keeper.supplyKeeper.GetSupply(ctx).Deflate(sdk.Coins{newCoin}))
 
-	return err
+	return nil
 }
 
 // Issue currency.
+// Currency must have registered VM balance path: account coins are mirrored to VM balances.
 func (keeper Keeper) IssueCurrency(ctx sdk.Context, symbol string, amount sdk.Int, decimals int8, recipient sdk.AccAddress, issueID string) error {
 	if keeper.hasIssue(ctx, issueID) {
 		return sdkErrors.Wrap(types.ErrExistsIssue, issueID)
 	}
 
+	if _, ok := keeper.denomPaths.GetDenomPath(ctx, symbol); !ok {
+		return sdkErrors.Wrap(types.ErrNotRegisteredPath, symbol)
+	}
+
 	var isNew bool
 
 	if isNew = keeper.doesCurrencyExists(ctx, symbol); !isNew {
@@ -68,9 +84,14 @@ func (keeper Keeper) IssueCurrency(ctx sdk.Context, symbol string, amount sdk.In
 
 	newCoin := sdk.NewCoin(symbol, amount)
 
-	_, err := keeper.coinKeeper.AddCoins(ctx, recipient, sdk.Coins{newCoin})
+	if _, err := keeper.coinKeeper.AddCoins(ctx, recipient, sdk.Coins{newCoin}); err != nil {
+		return err
+	}
+
+	// Issued coins are added to total supply.
+	keeper.supplyKeeper.SetSupply(ctx, keeper.supplyKeeper.GetSupply(ctx).Inflate(sdk.Coins{newCoin}))
 
-	return err
+	return nil
 }
 
 // Deprecated: Get currency by denom/symbol.
@@ -85,6 +106,22 @@ func (keeper Keeper) GetCurrency(ctx sdk.Context, symbol string) types.Currency
 	return currency
 }
 
+// Iterate over all currencies (ordered by symbol), iteration stops if handler returns true.
+func (keeper Keeper) IterateCurrencies(ctx sdk.Context, handler func(currency types.Currency) (stop bool)) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.CurrencyKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var currency types.Currency
+		keeper.cdc.MustUnmarshalBinaryBare(iterator.Value(), &currency)
+
+		if handler(currency) {
+			break
+		}
+	}
+}
+
 // Get currency issue by id.
 func (keeper Keeper) GetIssue(ctx sdk.Context, issueID string) types.Issue {
 	store := ctx.KVStore(keeper.storeKey)
@@ -37,6 +37,24 @@ var (
 	}
 )
 
+// Denom paths provider mock (only registered denoms can be issued).
+type testDenomPathsProvider struct {
+	paths map[string][]byte
+}
+
+func (p testDenomPathsProvider) GetDenomPath(_ sdk.Context, denom string) ([]byte, bool) {
+	path, ok := p.paths[denom]
+	return path, ok
+}
+
+func (p testDenomPathsProvider) IterateDenomPaths(_ sdk.Context, handler func(denom string, path []byte) (stop bool)) {
+	for denom, path := range p.paths {
+		if handler(denom, path) {
+			break
+		}
+	}
+}
+
 type testInput struct {
 	cdc *codec.Codec
 	ctx sdk.Context
@@ -87,6 +105,7 @@ func setupTestInput(t *testing.T) testInput {
 	sdk.RegisterCodec(input.cdc)
 	codec.RegisterCrypto(input.cdc)
 	multisig.RegisterCodec(input.cdc)
+	supply.RegisterCodec(input.cdc)
 
 	db := dbm.NewMemDB()
 	mstore := store.NewCommitMultiStore(db)
@@ -126,11 +145,14 @@ func setupTestInput(t *testing.T) testInput {
 	// Initializing currencies module
 	input.target = NewKeeper(
 		input.bankKeeper,
+		input.supplyKeeper,
+		testDenomPathsProvider{paths: map[string][]byte{symbol: {0x00}, "tst": {0x01}}},
 		input.keyCC,
 		input.cdc,
 	)
 
 	input.ctx = sdk.NewContext(mstore, abci.Header{ChainID: "test-chain-id"}, false, log.NewNopLogger())
+	input.supplyKeeper.SetSupply(input.ctx, supply.NewSupply(sdk.NewCoins()))
 	// input.accountKeeper.SetParams(input.ctx, auth.DefaultParams())
 	// input.bankKeeper.SetSendEnabled(input.ctx, true)
 
@@ -159,6 +181,11 @@ func TestKeeper_IssueCurrency(t *testing.T) {
 	require.Error(t, target.IssueCurrency(ctx, symbol, amount, 0, addr, issue1))
 	require.Error(t, target.IssueCurrency(ctx, symbol, amount, 2, addr, issue2))
 	require.True(t, target.coinKeeper.GetCoins(ctx, addr).AmountOf(symbol).Equal(amount))
+
+	// currency without registered VM balance path
+	tests.CheckExpectedErr(t, types.ErrNotRegisteredPath, target.IssueCurrency(ctx, "unknown", amount, 0, addr, issue2))
+	require.False(t, target.hasIssue(ctx, issue2))
+	require.True(t, target.coinKeeper.GetCoins(ctx, addr).AmountOf("unknown").IsZero())
 }
 
 func TestKeeper_DestroyCurrency(t *testing.T) {
@@ -181,9 +208,11 @@ func TestKeeper_DestroyCurrency(t *testing.T) {
 	require.Error(t, target.IssueCurrency(ctx, symbol, amount, 0, addr, issue1))
 	require.Error(t, target.IssueCurrency(ctx, symbol, amount, 2, addr, issue2))
 	require.True(t, target.coinKeeper.GetCoins(ctx, addr).AmountOf(symbol).Equal(amount))
+	require.True(t, input.supplyKeeper.GetSupply(ctx).GetTotal().AmountOf(symbol).Equal(amount))
 
 	// destroy currency
 	require.NoError(t, target.DestroyCurrency(ctx, ctx.ChainID(), symbol, recipient.String(), amount, addr))
+	require.True(t, input.supplyKeeper.GetSupply(ctx).GetTotal().AmountOf(symbol).IsZero())
 }
 
 func TestKeeper_GetDestroy(t *testing.T) {
@@ -303,3 +332,30 @@ func Test_IssueHugeAmount(t *testing.T) {
 	require.Nil(t, target.IssueCurrency(ctx, issueMsg.Symbol, issueMsg.Amount, issueMsg.Decimals, issueMsg.Recipient, "issue2"))
 	require.Equal(t, big.NewInt(0).Add(bigInt, bigInt).String(), target.coinKeeper.GetCoins(ctx, addr).AmountOf(symbol).BigInt().String())
 }
+
+func TestKeeper_InitGenesis(t *testing.T) {
+	t.Parallel()
+
+	input := setupTestInput(t)
+	ctx := input.ctx
+	target := input.target
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	acc := input.accountKeeper.NewAccountWithAddress(ctx, addr)
+	require.NoError(t, acc.SetCoins(sdk.NewCoins(sdk.NewInt64Coin("dfi", 100), sdk.NewInt64Coin(symbol, 10))))
+	input.accountKeeper.SetAccount(ctx, acc)
+
+	// supply matches accounts coins
+	input.supplyKeeper.SetSupply(ctx, supply.NewSupply(acc.GetCoins()))
+	require.NotPanics(t, func() {
+		target.InitGenesis(ctx, input.accountKeeper)
+	})
+
+	// genesis exported before supply tracking: issued coins are missing, destroyed coins are not deducted
+	exportedSupply := sdk.NewCoins(sdk.NewInt64Coin("dfi", 100), sdk.NewInt64Coin("btc", 5))
+	input.supplyKeeper.SetSupply(ctx, supply.NewSupply(exportedSupply))
+	require.Panics(t, func() {
+		target.InitGenesis(ctx, input.accountKeeper)
+	})
+	require.True(t, input.supplyKeeper.GetSupply(ctx).GetTotal().IsEqual(exportedSupply), "supply must not be changed")
+}
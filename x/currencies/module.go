@@ -62,14 +62,16 @@ func (AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 // PoA module.
 type AppModule struct {
 	AppModuleBasic
-	ccKeeper Keeper
+	ccKeeper      Keeper
+	accountKeeper types.AccountKeeper
 }
 
 // Create new PoA module.
-func NewAppMsModule(ccKeeper Keeper) core.AppMsModule {
+func NewAppMsModule(ccKeeper Keeper, accountKeeper types.AccountKeeper) core.AppMsModule {
 	return AppModule{
 		AppModuleBasic: AppModuleBasic{},
 		ccKeeper:       ccKeeper,
+		accountKeeper:  accountKeeper,
 	}
 }
 
@@ -79,7 +81,9 @@ func (AppModule) Name() string {
 }
 
 // Register module invariants.
-func (AppModule) RegisterInvariants(_ sdk.InvariantRegistry) {}
+func (app AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	RegisterInvariants(ir, app.ccKeeper)
+}
 
 // Base route of module (for handler).
 func (AppModule) Route() string { return types.Router }
@@ -108,6 +112,8 @@ func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) []abci.Validato
 
 // Initialize genesis.
 func (app AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
+	app.ccKeeper.InitGenesis(ctx, app.accountKeeper)
+
 	return []abci.ValidatorUpdate{}
 }
 
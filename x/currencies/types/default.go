@@ -15,13 +15,14 @@ const (
 )
 
 var (
-	KeyDelimiter = []byte(":")
-	DestroyQueue = []byte("destroy")
+	KeyDelimiter      = []byte(":")
+	DestroyQueue      = []byte("destroy")
+	CurrencyKeyPrefix = []byte("currency:")
 )
 
 // Key for storing currency
 func GetCurrencyKey(symbol string) []byte {
-	return []byte(fmt.Sprintf("currency:%s", symbol))
+	return []byte(fmt.Sprintf("%s%s", CurrencyKeyPrefix, symbol))
 }
 
 // Key for issues
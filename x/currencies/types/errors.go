@@ -23,4 +23,6 @@ var (
 	ErrNotExistCurrency  = sdkErrors.Register(ModuleName, 107, "currency not found")
 	// Msg.Recipient is empty.
 	ErrWrongRecipient    = sdkErrors.Register(ModuleName, 108, "empty recipient is not allowed")
+	// Currency VM balance path is not registered (currencies_register module).
+	ErrNotRegisteredPath = sdkErrors.Register(ModuleName, 109, "currency VM balance path is not registered")
 )
@@ -0,0 +1,11 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/exported"
+)
+
+// Account keeper used to check supply on genesis init.
+type AccountKeeper interface {
+	IterateAccounts(ctx sdk.Context, process func(exported.Account) (stop bool))
+}
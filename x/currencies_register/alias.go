@@ -8,14 +8,22 @@ import (
 const (
 	ModuleName = types.ModuleName
 	StoreKey   = types.StoreKey
+	RouterKey  = types.RouterKey
 )
 
 type (
-	Keeper          = keeper.Keeper
-	GenesisState    = types.GenesisState
-	GenesisCurrency = types.GenesisCurrency
+	Keeper           = keeper.Keeper
+	GenesisState     = types.GenesisState
+	GenesisCurrency  = types.GenesisCurrency
+	GenesisDenomPath = types.GenesisDenomPath
+	MsgAddDenomPath  = types.MsgAddDenomPath
+	SupplyKeeper     = types.SupplyKeeper
 )
 
 var (
-	NewKeeper = keeper.NewKeeper
+	NewKeeper           = keeper.NewKeeper
+	NewMsgAddDenomPath  = types.NewMsgAddDenomPath
+	DefaultGenesisState = types.DefaultGenesisState
+	RegisterCodec       = types.RegisterCodec
+	RegisterInvariants  = keeper.RegisterInvariants
 )
@@ -14,7 +14,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 
-	"github.com/dfinance/dnode/x/currencies_register"
+	"github.com/dfinance/dnode/x/currencies_register/internal/types"
 )
 
 const (
@@ -25,9 +25,9 @@ const (
 func AddGenesisCurrencyInfo(ctx *server.Context, cdc *codec.Codec,
 	defaultNodeHome, defaultClientHome string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "add-currency-info [denom] [decimals] [totalSupply] [path]",
-		Short: "Add currency info to genesis.json",
-		Args:  cobra.ExactArgs(4),
+		Use:   "add-currency-info [denom] [decimals] [totalSupply] [path] [balancePath]",
+		Short: "Add currency info (with optional VM balance resource path) to genesis.json",
+		Args:  cobra.RangeArgs(4, 5),
 		RunE: func(_ *cobra.Command, args []string) error {
 			config := ctx.Config
 			config.SetRoot(viper.GetString(cli.HomeFlag))
@@ -52,6 +52,15 @@ func AddGenesisCurrencyInfo(ctx *server.Context, cdc *codec.Codec,
 				return fmt.Errorf("path is not a correct hex %q: %v", args[3], err)
 			}
 
+			balancePath := ""
+			if len(args) > 4 {
+				bz, err := types.ParseDenomPath(args[4])
+				if err != nil {
+					return fmt.Errorf("incorrect balance path: %v", err)
+				}
+				balancePath = hex.EncodeToString(bz)
+			}
+
 			// retrieve the app state
 			genFile := config.GenesisFile()
 			appState, genDoc, err := genutil.GenesisStateFromGenFile(cdc, genFile)
@@ -59,8 +68,8 @@ func AddGenesisCurrencyInfo(ctx *server.Context, cdc *codec.Codec,
 				return err
 			}
 
-			genesisState := currencies_register.GenesisState{}
-			cdc.MustUnmarshalJSON(appState[currencies_register.ModuleName], &genesisState)
+			genesisState := types.GenesisState{}
+			cdc.MustUnmarshalJSON(appState[types.ModuleName], &genesisState)
 			// find dublicated
 			found := -1
 			for i, genCurr := range genesisState.Currencies {
@@ -74,17 +83,30 @@ func AddGenesisCurrencyInfo(ctx *server.Context, cdc *codec.Codec,
 				genesisState.Currencies[found].Path = hex.EncodeToString(path)
 				genesisState.Currencies[found].TotalSupply = totalSupply
 				genesisState.Currencies[found].Decimals = uint8(decimals)
+				genesisState.Currencies[found].BalancePath = balancePath
 			} else {
-				genesisState.Currencies = append(genesisState.Currencies, currencies_register.GenesisCurrency{
+				genesisState.Currencies = append(genesisState.Currencies, types.GenesisCurrency{
 					Path:        hex.EncodeToString(path),
 					Denom:       denom,
 					Decimals:    uint8(decimals),
 					TotalSupply: totalSupply,
+					BalancePath: balancePath,
 				})
 			}
 
+			// balance path is defined by currency, remove standalone one
+			if balancePath != "" {
+				denomPaths := make([]types.GenesisDenomPath, 0, len(genesisState.DenomPaths))
+				for _, genPath := range genesisState.DenomPaths {
+					if genPath.Denom != denom {
+						denomPaths = append(denomPaths, genPath)
+					}
+				}
+				genesisState.DenomPaths = denomPaths
+			}
+
 			genesisStateBz := cdc.MustMarshalJSON(genesisState)
-			appState[currencies_register.ModuleName] = genesisStateBz
+			appState[types.ModuleName] = genesisStateBz
 
 			appStateJSON, err := cdc.MarshalJSON(appState)
 			if err != nil {
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	cliBldrCtx "github.com/cosmos/cosmos-sdk/client/context"
+	sdkClient "github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	txBldrCtx "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/spf13/cobra"
+	codec "github.com/tendermint/go-amino"
+
+	"github.com/dfinance/dnode/x/currencies_register/internal/types"
+	msMsg "github.com/dfinance/dnode/x/multisig/msgs"
+)
+
+// GetTxCmd returns the transaction commands for this module.
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   types.ModuleName,
+		Short: "Currencies register transactions commands",
+	}
+
+	txCmd.AddCommand(sdkClient.PostCommands(PostMsAddDenomPath(cdc))...)
+
+	return txCmd
+}
+
+// Register VM balance resource path for denom via multisignature.
+func PostMsAddDenomPath(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "ms-add-denom-path [denom] [path] [uniqueID]",
+		Short:   "register VM balance resource path (hex) for denom via multisignature",
+		Example: "ms-add-denom-path xrp 01c5d4bd9b0ff1b6fb8ee20a4ee0bd5e0bff56fc5e1ed0a6d52ad3b5c6c64fb9e4 add_xrp_path --from my_account --fees 1dfi",
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := txBldrCtx.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := cliBldrCtx.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+			accGetter := txBldrCtx.NewAccountRetriever(cliCtx)
+
+			if err := accGetter.EnsureExists(cliCtx.FromAddress); err != nil {
+				return fmt.Errorf("provide correct parameter for --from flag: %v", err)
+			}
+
+			path, err := hex.DecodeString(args[1])
+			if err != nil {
+				return fmt.Errorf("%s argument %q: %w", "path", args[1], err)
+			}
+
+			msAddPath := types.NewMsgAddDenomPath(args[0], path)
+			msg := msMsg.NewMsgSubmitCall(msAddPath, args[2], cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			cliCtx.WithOutput(os.Stdout)
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,13 @@
+package currencies_register
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// New message handler for currencies register module (module messages are handled only via multisignature calls).
+func NewHandler(_ Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		return nil, sdkErrors.Wrapf(sdkErrors.ErrUnknownRequest, "unrecognized currencies register msg type: %v", msg.Type())
+	}
+}
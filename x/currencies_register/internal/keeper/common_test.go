@@ -70,6 +70,22 @@ func (storage VMStorageImpl) HasValue(ctx sdk.Context, accessPath *vm_grpc.VMAcc
 	return store.Has(common_vm.MakePathKey(accessPath))
 }
 
+func (storage VMStorageImpl) IterateValues(ctx sdk.Context, handler func(accessPath *vm_grpc.VMAccessPath, value []byte) bool) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(storage.storeKey), append(common_vm.VMKey, common_vm.KeyDelimiter...))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		accessPath, err := common_vm.ParsePathKey(iterator.Key())
+		if err != nil {
+			panic(err)
+		}
+
+		if handler(accessPath, iterator.Value()) {
+			break
+		}
+	}
+}
+
 // Get test input.
 func GetTestInput(t *testing.T) testInput {
 	input := testInput{
@@ -0,0 +1,85 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/dfinance/dnode/x/currencies_register/internal/types"
+)
+
+// Register VM balance resource path for denom.
+func (keeper Keeper) AddDenomPath(ctx sdk.Context, denom string, path []byte) error {
+	store := ctx.KVStore(keeper.storeKey)
+	keyPath := types.GetDenomPathKey(denom)
+
+	if store.Has(keyPath) {
+		return sdkErrors.Wrap(types.ErrDenomPathExists, fmt.Sprintf("denom %q", denom))
+	}
+
+	if len(path) == 0 {
+		return sdkErrors.Wrap(types.ErrWrongDenomPath, fmt.Sprintf("denom %q: empty", denom))
+	}
+
+	bz, err := keeper.cdc.MarshalBinaryBare(types.NewCurrencyPath(path))
+	if err != nil {
+		return err
+	}
+	store.Set(keyPath, bz)
+	keeper.pathsCache.invalidate(ctx.BlockHeight())
+
+	return nil
+}
+
+// Get VM balance resource path for denom.
+func (keeper Keeper) GetDenomPath(ctx sdk.Context, denom string) ([]byte, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+
+	bz := store.Get(types.GetDenomPathKey(denom))
+	if bz == nil {
+		return nil, false
+	}
+
+	var currencyPath types.CurrencyPath
+	keeper.cdc.MustUnmarshalBinaryBare(bz, &currencyPath)
+
+	return currencyPath.Path, true
+}
+
+// Iterate over registered VM balance resource paths (sorted by denom).
+// Registry is read on every account read / write, so paths are cached per block and read is not charged.
+func (keeper Keeper) IterateDenomPaths(ctx sdk.Context, handler func(denom string, path []byte) (stop bool)) {
+	entries, ok := keeper.pathsCache.get(ctx.BlockHeight())
+	if !ok {
+		entries = keeper.readDenomPaths(ctx)
+		keeper.pathsCache.set(ctx.BlockHeight(), entries)
+	}
+
+	for _, entry := range entries {
+		if handler(entry.denom, entry.path) {
+			break
+		}
+	}
+}
+
+// Read registered VM balance resource paths from the store (sorted by denom).
+func (keeper Keeper) readDenomPaths(ctx sdk.Context) []denomPathEntry {
+	store := ctx.WithGasMeter(sdk.NewInfiniteGasMeter()).KVStore(keeper.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.DenomPathKeyPrefix)
+	defer iterator.Close()
+
+	var entries []denomPathEntry
+	for ; iterator.Valid(); iterator.Next() {
+		var currencyPath types.CurrencyPath
+		keeper.cdc.MustUnmarshalBinaryBare(iterator.Value(), &currencyPath)
+
+		entries = append(entries, denomPathEntry{
+			denom: string(iterator.Key()[len(types.DenomPathKeyPrefix):]),
+			path:  currencyPath.Path,
+		})
+	}
+
+	return entries
+}
@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	"sync"
+)
+
+// Registered VM balance resource path.
+type denomPathEntry struct {
+	denom string
+	path  []byte
+}
+
+// VM balance resource paths cache for a block height.
+// Registry change invalidates the cache and disables it for the rest of the block:
+// the change might be reverted (failed tx / multisig call dry run), so the paths are read from the store.
+type denomPathsCache struct {
+	mu          sync.Mutex
+	valid       bool
+	height      int64
+	dirtyHeight int64
+	entries     []denomPathEntry
+}
+
+// Create new cache.
+func newDenomPathsCache() *denomPathsCache {
+	return &denomPathsCache{
+		dirtyHeight: -1,
+	}
+}
+
+// Get cached paths for block height.
+func (c *denomPathsCache) get(height int64) ([]denomPathEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.valid || c.height != height || c.dirtyHeight == height {
+		return nil, false
+	}
+
+	return c.entries, true
+}
+
+// Cache paths for block height (skipped if registry was changed within the block).
+func (c *denomPathsCache) set(height int64, entries []denomPathEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dirtyHeight == height {
+		return
+	}
+
+	c.valid, c.height, c.entries = true, height, entries
+}
+
+// Invalidate cache on registry change.
+func (c *denomPathsCache) invalidate(height int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.valid, c.dirtyHeight, c.entries = false, height, nil
+}
@@ -0,0 +1,79 @@
+// +build unit
+
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dfinance/dnode/x/currencies_register/internal/types"
+)
+
+// Test add/get/iterate denom paths.
+func TestKeeper_DenomPaths(t *testing.T) {
+	input := GetTestInput(t)
+
+	_, ok := input.keeper.GetDenomPath(input.ctx, "eth")
+	require.False(t, ok)
+
+	require.NoError(t, input.keeper.AddDenomPath(input.ctx, "eth", []byte{0x01}))
+	require.NoError(t, input.keeper.AddDenomPath(input.ctx, "btc", []byte{0x02}))
+
+	path, ok := input.keeper.GetDenomPath(input.ctx, "eth")
+	require.True(t, ok)
+	require.Equal(t, []byte{0x01}, path)
+
+	// existing denom
+	err := input.keeper.AddDenomPath(input.ctx, "eth", []byte{0x03})
+	require.True(t, types.ErrDenomPathExists.Is(err))
+
+	// empty path
+	err = input.keeper.AddDenomPath(input.ctx, "usdt", nil)
+	require.True(t, types.ErrWrongDenomPath.Is(err))
+
+	var denoms []string
+	input.keeper.IterateDenomPaths(input.ctx, func(denom string, path []byte) bool {
+		denoms = append(denoms, denom)
+		return false
+	})
+	require.Equal(t, []string{"btc", "eth"}, denoms)
+}
+
+// Test denom paths cache is invalidated on registry change and reverted changes are not cached.
+func TestKeeper_DenomPathsCache(t *testing.T) {
+	input := GetTestInput(t)
+
+	getDenoms := func(ctx sdk.Context) []string {
+		var denoms []string
+		input.keeper.IterateDenomPaths(ctx, func(denom string, path []byte) bool {
+			denoms = append(denoms, denom)
+			return false
+		})
+
+		return denoms
+	}
+
+	ctx := input.ctx.WithBlockHeight(1)
+	require.NoError(t, input.keeper.AddDenomPath(ctx, "eth", []byte{0x01}))
+	require.Equal(t, []string{"eth"}, getDenoms(ctx))
+
+	// next block: paths are cached
+	ctx = ctx.WithBlockHeight(2)
+	require.Equal(t, []string{"eth"}, getDenoms(ctx))
+
+	// change within the block is visible
+	require.NoError(t, input.keeper.AddDenomPath(ctx, "btc", []byte{0x02}))
+	require.Equal(t, []string{"btc", "eth"}, getDenoms(ctx))
+
+	// reverted change is not visible
+	cacheCtx, _ := ctx.CacheContext()
+	require.NoError(t, input.keeper.AddDenomPath(cacheCtx, "usdt", []byte{0x03}))
+	require.Equal(t, []string{"btc", "eth", "usdt"}, getDenoms(cacheCtx))
+	require.Equal(t, []string{"btc", "eth"}, getDenoms(ctx))
+
+	ctx = ctx.WithBlockHeight(3)
+	require.Equal(t, []string{"btc", "eth"}, getDenoms(ctx))
+	require.Equal(t, []string{"btc", "eth"}, getDenoms(ctx))
+}
@@ -3,6 +3,7 @@ package keeper
 import (
 	"encoding/hex"
 	"encoding/json"
+	"sort"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -15,6 +16,7 @@ var (
 )
 
 // Init genesis from json.
+// Well-known denoms VM balance paths are registered if genesis doesn't contain them (genesis migration).
 func (keeper Keeper) InitGenesis(ctx sdk.Context, data json.RawMessage) error {
 	store := ctx.KVStore(keeper.storeKey)
 
@@ -34,6 +36,35 @@ func (keeper Keeper) InitGenesis(ctx sdk.Context, data json.RawMessage) error {
 		if err != nil {
 			return err
 		}
+
+		if genCurr.BalancePath != "" {
+			if err := keeper.addGenesisDenomPath(ctx, genCurr.Denom, genCurr.BalancePath); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, genPath := range state.DenomPaths {
+		if err := keeper.addGenesisDenomPath(ctx, genPath.Denom, genPath.Path); err != nil {
+			return err
+		}
+	}
+
+	wellKnownPaths := types.WellKnownDenomPaths()
+	wellKnownDenoms := make([]string, 0, len(wellKnownPaths))
+	for denom := range wellKnownPaths {
+		wellKnownDenoms = append(wellKnownDenoms, denom)
+	}
+	sort.Strings(wellKnownDenoms)
+
+	for _, denom := range wellKnownDenoms {
+		if _, ok := keeper.GetDenomPath(ctx, denom); ok {
+			continue
+		}
+
+		if err := keeper.addGenesisDenomPath(ctx, denom, wellKnownPaths[denom]); err != nil {
+			return err
+		}
 	}
 
 	store.Set(genesisKey, data)
@@ -41,9 +72,42 @@ func (keeper Keeper) InitGenesis(ctx sdk.Context, data json.RawMessage) error {
 	return nil
 }
 
-// Export initialized genesis.
+// Export initialized genesis with denom paths registered after genesis.
 func (keeper Keeper) ExportGenesis(ctx sdk.Context) json.RawMessage {
 	store := ctx.KVStore(keeper.storeKey)
 
-	return store.Get(genesisKey)
+	bz := store.Get(genesisKey)
+	if bz == nil {
+		return nil
+	}
+
+	var state types.GenesisState
+	keeper.cdc.MustUnmarshalJSON(bz, &state)
+
+	currencyPaths := make(map[string]string, len(state.Currencies))
+	for _, genCurr := range state.Currencies {
+		currencyPaths[genCurr.Denom] = genCurr.BalancePath
+	}
+
+	state.DenomPaths = nil
+	keeper.IterateDenomPaths(ctx, func(denom string, path []byte) bool {
+		hexPath := hex.EncodeToString(path)
+		if currencyPaths[denom] != hexPath {
+			state.DenomPaths = append(state.DenomPaths, types.GenesisDenomPath{Denom: denom, Path: hexPath})
+		}
+
+		return false
+	})
+
+	return keeper.cdc.MustMarshalJSON(state)
+}
+
+// Add genesis HEX denom path.
+func (keeper Keeper) addGenesisDenomPath(ctx sdk.Context, denom, path string) error {
+	bzPath, err := types.ParseDenomPath(path)
+	if err != nil {
+		return err
+	}
+
+	return keeper.AddDenomPath(ctx, denom, bzPath)
 }
@@ -3,6 +3,7 @@
 package keeper
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -50,3 +51,54 @@ func TestKeeper_ExportGenesis(t *testing.T) {
 	exported := input.keeper.ExportGenesis(input.ctx)
 	require.EqualValues(t, bz, exported)
 }
+
+// Test export genesis contains denom paths registered after genesis.
+func TestKeeper_ExportGenesisDenomPaths(t *testing.T) {
+	input := GetTestInput(t)
+
+	defaultGenesis := types.DefaultGenesisState()
+	bz, err := input.keeper.cdc.MarshalJSON(defaultGenesis)
+	require.NoError(t, err)
+	require.NoError(t, input.keeper.InitGenesis(input.ctx, bz))
+
+	for _, genCurr := range defaultGenesis.Currencies {
+		_, ok := input.keeper.GetDenomPath(input.ctx, genCurr.Denom)
+		require.True(t, ok)
+	}
+	for _, genPath := range defaultGenesis.DenomPaths {
+		_, ok := input.keeper.GetDenomPath(input.ctx, genPath.Denom)
+		require.True(t, ok)
+	}
+
+	require.NoError(t, input.keeper.AddDenomPath(input.ctx, "xrp", []byte{0x01}))
+
+	var exported types.GenesisState
+	require.NoError(t, input.keeper.cdc.UnmarshalJSON(input.keeper.ExportGenesis(input.ctx), &exported))
+	require.Equal(t, defaultGenesis.Currencies, exported.Currencies)
+	require.Len(t, exported.DenomPaths, len(defaultGenesis.DenomPaths)+1)
+	require.Contains(t, exported.DenomPaths, types.GenesisDenomPath{Denom: "xrp", Path: "01"})
+}
+
+// Test init genesis registers well-known denom paths missing in genesis (legacy genesis).
+func TestKeeper_InitGenesisWellKnownDenomPaths(t *testing.T) {
+	input := GetTestInput(t)
+
+	legacyGenesis := types.DefaultGenesisState()
+	legacyGenesis.Currencies[0].BalancePath = ""
+	legacyGenesis.DenomPaths = nil
+
+	bz, err := input.keeper.cdc.MarshalJSON(legacyGenesis)
+	require.NoError(t, err)
+	require.NoError(t, input.keeper.InitGenesis(input.ctx, bz))
+
+	for denom, hexPath := range types.WellKnownDenomPaths() {
+		path, ok := input.keeper.GetDenomPath(input.ctx, denom)
+		require.True(t, ok, denom)
+		require.Equal(t, hexPath, hex.EncodeToString(path), denom)
+	}
+
+	// registered paths are exported
+	var exported types.GenesisState
+	require.NoError(t, input.keeper.cdc.UnmarshalJSON(input.keeper.ExportGenesis(input.ctx), &exported))
+	require.Len(t, exported.DenomPaths, len(types.WellKnownDenomPaths()))
+}
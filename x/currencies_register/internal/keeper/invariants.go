@@ -0,0 +1,49 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dfinance/dnode/x/currencies_register/internal/types"
+)
+
+// Register currencies register invariants.
+func RegisterInvariants(ir sdk.InvariantRegistry, keeper Keeper, supplyKeeper types.SupplyKeeper) {
+	ir.RegisterRoute(types.ModuleName, "currencies-info", CurrenciesInfoInvariant(keeper, supplyKeeper))
+}
+
+// Check currencies info stored in VM storage are consistent: resource is readable, denom matches registered one
+// and total supply is not exceeded by supply (supply module).
+func CurrenciesInfoInvariant(keeper Keeper, supplyKeeper types.SupplyKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var broken bool
+
+		total := supplyKeeper.GetSupply(ctx).GetTotal()
+		keeper.IterateCurrencyDenoms(ctx, func(denom string) bool {
+			info, err := keeper.GetCurrencyInfo(ctx, denom)
+			switch {
+			case err != nil:
+				broken = true
+				msg += fmt.Sprintf("\t%s: %v\n", denom, err)
+			case !bytes.Equal(info.Denom, []byte(denom)):
+				broken = true
+				msg += fmt.Sprintf("\t%s: currency info denom %q\n", denom, string(info.Denom))
+			case info.TotalSupply == nil || info.TotalSupply.Sign() < 0:
+				broken = true
+				msg += fmt.Sprintf("\t%s: invalid total supply %v\n", denom, info.TotalSupply)
+			default:
+				if totalAmount := total.AmountOf(denom); totalAmount.BigInt().Cmp(info.TotalSupply) > 0 {
+					broken = true
+					msg += fmt.Sprintf("\t%s: currency info total supply %s, supply %s\n", denom, info.TotalSupply, totalAmount)
+				}
+			}
+
+			return false
+		})
+
+		return sdk.FormatInvariant(types.ModuleName, "currencies-info", fmt.Sprintf("inconsistent currencies info:\n%s", msg)), broken
+	}
+}
@@ -19,14 +19,17 @@ type Keeper struct {
 	storeKey sdk.StoreKey // Store key.
 
 	vmStorage common_vm.VMStorage // virtual machine storage.
+
+	pathsCache *denomPathsCache // VM balance resource paths cache.
 }
 
 // Create new keeper.
 func NewKeeper(cdc *amino.Codec, storeKey sdk.StoreKey, vmStorage common_vm.VMStorage) Keeper {
 	return Keeper{
-		cdc:       cdc,
-		storeKey:  storeKey,
-		vmStorage: vmStorage,
+		cdc:        cdc,
+		storeKey:   storeKey,
+		vmStorage:  vmStorage,
+		pathsCache: newDenomPathsCache(),
 	}
 }
 
@@ -110,3 +113,16 @@ func (keeper Keeper) GetCurrencyInfo(ctx sdk.Context, denom string) (types.Curre
 
 	return currInfo, nil
 }
+
+// Iterate over registered currencies denoms (sorted), iteration stops if handler returns true.
+func (keeper Keeper) IterateCurrencyDenoms(ctx sdk.Context, handler func(denom string) (stop bool)) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.CurrencyPathKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if handler(string(iterator.Key()[len(types.CurrencyPathKeyPrefix):])) {
+			break
+		}
+	}
+}
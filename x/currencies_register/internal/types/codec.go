@@ -5,7 +5,13 @@ import "github.com/cosmos/cosmos-sdk/codec"
 // module codec
 var ModuleCdc *codec.Codec
 
+// Register amino types for currencies register module.
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgAddDenomPath{}, ModuleName+"/MsgAddDenomPath", nil)
+}
+
 func init() {
 	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
 	ModuleCdc.Seal()
 }
@@ -7,9 +7,20 @@ import (
 const (
 	ModuleName = "currencies_register"
 	StoreKey   = ModuleName
+	RouterKey  = "currenciesregister"
+)
+
+var (
+	CurrencyPathKeyPrefix = []byte("currency_path:")
+	DenomPathKeyPrefix    = []byte("denom_path:")
 )
 
 // Get currency path key.
 func GetCurrencyPathKey(denom string) []byte {
-	return []byte(fmt.Sprintf("currency_path:%s", denom))
+	return []byte(fmt.Sprintf("%s%s", CurrencyPathKeyPrefix, denom))
+}
+
+// Get denom VM balance resource path key.
+func GetDenomPathKey(denom string) []byte {
+	return []byte(fmt.Sprintf("%s%s", DenomPathKeyPrefix, denom))
 }
@@ -11,4 +11,6 @@ var (
 	ErrLcsMarshal      = sdkErrors.Register(ModuleName, 103, "can't marshall lcs")
 	ErrNotFound        = sdkErrors.Register(ModuleName, 104, "currency not found")
 	ErrLcsUnmarshal    = sdkErrors.Register(ModuleName, 105, "unmarshal lcs")
+	ErrDenomPathExists = sdkErrors.Register(ModuleName, 106, "denom path already registered")
+	ErrWrongDenomPath  = sdkErrors.Register(ModuleName, 107, "wrong denom path")
 )
@@ -1,21 +1,106 @@
 package types
 
-import sdk "github.com/cosmos/cosmos-sdk/types"
+import (
+	"encoding/hex"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
 
 // Genesis state for currencies register.
+// BalancePath is optional: VM balance resource path for denom.
 type GenesisCurrency struct {
 	Path        string  `json:"path"`
 	Denom       string  `json:"denom"`
 	Decimals    uint8   `json:"decimals"`
 	TotalSupply sdk.Int `json:"totalSupply"`
+	BalancePath string  `json:"balancePath,omitempty"`
+}
+
+// Genesis VM balance resource path for denom (currency info is not registered).
+type GenesisDenomPath struct {
+	Denom string `json:"denom"`
+	Path  string `json:"path"`
 }
 
 // Genesis state to add before start.
 type GenesisState struct {
-	Currencies []GenesisCurrency `json:"currencies"`
+	Currencies []GenesisCurrency  `json:"currencies"`
+	DenomPaths []GenesisDenomPath `json:"denomPaths"`
+}
+
+// Validate genesis state: denoms and paths.
+// Currency without VM balance path is rejected unless denom is a well-known one (see WellKnownDenomPaths).
+func (s GenesisState) Validate() error {
+	denoms := make(map[string]bool)
+	paths := make(map[string]bool)
+
+	checkDenomPath := func(denom, path string) error {
+		if paths[denom] {
+			return fmt.Errorf("doubled denom path %q in genesis", denom)
+		}
+		paths[denom] = true
+
+		if _, err := ParseDenomPath(path); err != nil {
+			return fmt.Errorf("denom %q: %w", denom, err)
+		}
+
+		return nil
+	}
+
+	for _, genCurr := range s.Currencies {
+		denom := genCurr.Denom
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return fmt.Errorf("can't validate denom %q: %v", denom, err)
+		}
+
+		if denoms[denom] {
+			return fmt.Errorf("doubled currency %q in genesis", denom)
+		}
+		denoms[denom] = true
+
+		if genCurr.BalancePath != "" {
+			if err := checkDenomPath(denom, genCurr.BalancePath); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, genPath := range s.DenomPaths {
+		if err := sdk.ValidateDenom(genPath.Denom); err != nil {
+			return fmt.Errorf("can't validate denom %q: %v", genPath.Denom, err)
+		}
+
+		if err := checkDenomPath(genPath.Denom, genPath.Path); err != nil {
+			return err
+		}
+	}
+
+	wellKnownPaths := WellKnownDenomPaths()
+	for _, genCurr := range s.Currencies {
+		if _, ok := wellKnownPaths[genCurr.Denom]; !paths[genCurr.Denom] && !ok {
+			return fmt.Errorf("currency %q VM balance path is not registered in genesis", genCurr.Denom)
+		}
+	}
+
+	return nil
 }
 
-// Default genesis state with DFI info.
+// Parse HEX VM balance resource path.
+func ParseDenomPath(path string) ([]byte, error) {
+	bz, err := hex.DecodeString(path)
+	if err != nil {
+		return nil, fmt.Errorf("path %q is not a correct hex: %v", path, err)
+	}
+
+	if len(bz) == 0 {
+		return nil, fmt.Errorf("path is empty")
+	}
+
+	return bz, nil
+}
+
+// Default genesis state with DFI info and standard denoms VM balance paths.
 func DefaultGenesisState() GenesisState {
 	dfiVal, _ := sdk.NewIntFromString("100000000000000000000000000")
 
@@ -26,7 +111,38 @@ func DefaultGenesisState() GenesisState {
 				Denom:       "dfi",
 				Decimals:    18,
 				TotalSupply: dfiVal,
+				BalancePath: "0136364df51f98e43cbe92dfcfdd807c7fc626aa9690793ebf7d301cfe0de72f11",
+			},
+		},
+		DenomPaths: []GenesisDenomPath{
+			{
+				Denom: "btc",
+				Path:  "01d09b573d3bea2a3853ad447728fa476925894836352c46711fb7604e962b2903",
+			},
+			{
+				Denom: "eth",
+				Path:  "01f7c5cca156342bec3ac2bfb6fc439683da605ab7bc3f58881427861c8fc44577",
+			},
+			{
+				Denom: "usdt",
+				Path:  "01affc8719224641c76bb20b65e4a26c002a39bbc82b753410976002ad7931b270",
 			},
 		},
 	}
 }
+
+// Well-known denoms VM balance paths (from the default genesis state).
+// Used as a fallback for genesis created before VM balance paths were moved to genesis (no balancePath / denomPaths).
+func WellKnownDenomPaths() map[string]string {
+	state := DefaultGenesisState()
+
+	paths := make(map[string]string, len(state.Currencies)+len(state.DenomPaths))
+	for _, genCurr := range state.Currencies {
+		paths[genCurr.Denom] = genCurr.BalancePath
+	}
+	for _, genPath := range state.DenomPaths {
+		paths[genPath.Denom] = genPath.Path
+	}
+
+	return paths
+}
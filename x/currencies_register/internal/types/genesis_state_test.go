@@ -21,3 +21,50 @@ func TestDefaultGenesisState(t *testing.T) {
 	require.EqualValues(t, 18, defaultGenesis.Currencies[0].Decimals)
 	require.EqualValues(t, dfiVal.String(), defaultGenesis.Currencies[0].TotalSupply.String())
 }
+
+// Test genesis validation.
+func TestGenesisState_Validate(t *testing.T) {
+	require.NoError(t, DefaultGenesisState().Validate())
+
+	// doubled denom path
+	{
+		state := DefaultGenesisState()
+		state.DenomPaths = append(state.DenomPaths, GenesisDenomPath{Denom: "dfi", Path: "01"})
+		require.Error(t, state.Validate())
+	}
+
+	// wrong path
+	{
+		state := DefaultGenesisState()
+		state.DenomPaths[0].Path = "zz"
+		require.Error(t, state.Validate())
+
+		state.DenomPaths[0].Path = ""
+		require.Error(t, state.Validate())
+	}
+
+	// wrong denom
+	{
+		state := DefaultGenesisState()
+		state.DenomPaths[0].Denom = "1"
+		require.Error(t, state.Validate())
+	}
+
+	// currency without path
+	{
+		state := DefaultGenesisState()
+		state.Currencies = append(state.Currencies, GenesisCurrency{Path: "01", Denom: "xrp", Decimals: 8, TotalSupply: sdk.ZeroInt()})
+		require.Error(t, state.Validate())
+
+		state.DenomPaths = append(state.DenomPaths, GenesisDenomPath{Denom: "xrp", Path: "01"})
+		require.NoError(t, state.Validate())
+	}
+
+	// well-known currency without path (legacy genesis)
+	{
+		state := DefaultGenesisState()
+		state.Currencies[0].BalancePath = ""
+		state.DenomPaths = nil
+		require.NoError(t, state.Validate())
+	}
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	MsgAddDenomPathType = "add_denom_path"
+)
+
+// Multisignature message to register VM balance resource path for denom.
+type MsgAddDenomPath struct {
+	Denom string `json:"denom"`
+	Path  []byte `json:"path"`
+}
+
+func NewMsgAddDenomPath(denom string, path []byte) MsgAddDenomPath {
+	return MsgAddDenomPath{
+		Denom: denom,
+		Path:  path,
+	}
+}
+
+func (MsgAddDenomPath) Route() string {
+	return RouterKey
+}
+
+func (MsgAddDenomPath) Type() string {
+	return MsgAddDenomPathType
+}
+
+func (msg MsgAddDenomPath) ValidateBasic() error {
+	if err := sdk.ValidateDenom(msg.Denom); err != nil {
+		return sdkErrors.Wrapf(sdkErrors.ErrInvalidCoins, "denom %q: %v", msg.Denom, err)
+	}
+
+	if len(msg.Path) == 0 {
+		return sdkErrors.Wrap(ErrWrongDenomPath, "empty")
+	}
+
+	return nil
+}
@@ -2,7 +2,6 @@ package currencies_register
 
 import (
 	"encoding/json"
-	"fmt"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -12,12 +11,15 @@ import (
 	"github.com/spf13/cobra"
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/dfinance/dnode/x/core"
+	"github.com/dfinance/dnode/x/currencies_register/cli"
 	"github.com/dfinance/dnode/x/currencies_register/internal/types"
 )
 
 var (
 	_ module.AppModule      = AppModule{}
 	_ module.AppModuleBasic = AppModuleBasic{}
+	_ core.AppMsModule      = AppModule{}
 )
 
 type AppModuleBasic struct{}
@@ -27,8 +29,9 @@ func (AppModuleBasic) Name() string {
 	return ModuleName
 }
 
-// Registering codecs (empty for now).
-func (module AppModuleBasic) RegisterCodec(_ *codec.Codec) {
+// Registering codecs.
+func (module AppModuleBasic) RegisterCodec(cdc *codec.Codec) {
+	types.RegisterCodec(cdc)
 }
 
 // Validate exists genesis.
@@ -36,22 +39,7 @@ func (AppModuleBasic) ValidateGenesis(data json.RawMessage) error {
 	var state types.GenesisState
 	types.ModuleCdc.MustUnmarshalJSON(data, &state)
 
-	denoms := make(map[string]bool, 0)
-
-	for _, genCurr := range state.Currencies {
-		denom := genCurr.Denom
-		if err := sdk.ValidateDenom(denom); err != nil {
-			return fmt.Errorf("can't validate denom %q: %v", denom, err)
-		}
-
-		if denoms[denom] {
-			return fmt.Errorf("doubled currency %q in genesis", denom)
-		}
-
-		denoms[denom] = true
-	}
-
-	return nil
+	return state.Validate()
 }
 
 // Generate default genesis.
@@ -63,8 +51,8 @@ func (module AppModuleBasic) DefaultGenesis() json.RawMessage {
 func (AppModuleBasic) RegisterRESTRoutes(_ context.CLIContext, _ *mux.Router) {}
 
 // Get transaction commands for CLI.
-func (AppModuleBasic) GetTxCmd(_ *codec.Codec) *cobra.Command {
-	return nil
+func (AppModuleBasic) GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	return cli.GetTxCmd(cdc)
 }
 
 // Get query commands for CLI.
@@ -75,14 +63,16 @@ func (AppModuleBasic) GetQueryCmd(_ *codec.Codec) *cobra.Command {
 // VM module.
 type AppModule struct {
 	AppModuleBasic
-	keeper Keeper
+	keeper       Keeper
+	supplyKeeper SupplyKeeper
 }
 
 // Create new VM module.
-func NewAppModule(keeper Keeper) AppModule {
+func NewAppModule(keeper Keeper, supplyKeeper SupplyKeeper) AppModule {
 	return AppModule{
 		AppModuleBasic: AppModuleBasic{},
 		keeper:         keeper,
+		supplyKeeper:   supplyKeeper,
 	}
 }
 
@@ -92,14 +82,21 @@ func (AppModule) Name() string {
 }
 
 // Register module invariants.
-func (AppModule) RegisterInvariants(_ sdk.InvariantRegistry) {}
+func (app AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	RegisterInvariants(ir, app.keeper, app.supplyKeeper)
+}
 
 // Base route of module (for handler).
-func (AppModule) Route() string { return "" }
+func (AppModule) Route() string { return types.RouterKey }
 
 // Create new handler.
 func (app AppModule) NewHandler() sdk.Handler {
-	return nil
+	return NewHandler(app.keeper)
+}
+
+// Create new multisignature handler.
+func (app AppModule) NewMsHandler() core.MsHandler {
+	return NewMsHandler(app.keeper)
 }
 
 // Get route for querier.
@@ -0,0 +1,27 @@
+// Implements multisignature message handler for currencies register module.
+package currencies_register
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/dfinance/dnode/x/core"
+)
+
+// Handler for currencies register multisignature messages, registers denoms VM balance paths.
+func NewMsHandler(keeper Keeper) core.MsHandler {
+	return func(ctx sdk.Context, msg core.MsMsg) error {
+		switch msg := msg.(type) {
+		case MsgAddDenomPath:
+			return handleMsMsgAddDenomPath(ctx, keeper, msg)
+
+		default:
+			return sdkErrors.Wrapf(sdkErrors.ErrUnknownRequest, "unrecognized currencies register multisig msg type: %v", msg.Type())
+		}
+	}
+}
+
+// Handle add denom path message.
+func handleMsMsgAddDenomPath(ctx sdk.Context, keeper Keeper, msg MsgAddDenomPath) error {
+	return keeper.AddDenomPath(ctx, msg.Denom, msg.Path)
+}
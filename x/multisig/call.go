@@ -5,6 +5,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/dfinance/dnode/helpers/metrics"
 	"github.com/dfinance/dnode/x/core"
 	"github.com/dfinance/dnode/x/multisig/types"
 )
@@ -40,6 +41,10 @@ func (keeper Keeper) SubmitCall(ctx sdk.Context, msg core.MsMsg, uniqueID string
 		return err
 	}
 
+	if !ctx.IsCheckTx() {
+		metrics.Get().MultisigCalls.With(metrics.LabelStatus, metrics.MultisigStatusSubmitted).Add(1)
+	}
+
 	return nil
 }
 
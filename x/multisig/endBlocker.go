@@ -8,6 +8,8 @@ import (
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/dfinance/dnode/helpers/metrics"
+	"github.com/dfinance/dnode/x/core"
 	"github.com/dfinance/dnode/x/multisig/types"
 	"github.com/dfinance/dnode/x/poa"
 )
@@ -15,6 +17,7 @@ import (
 // Implements end blocker to process active calls and their confirmations.
 func EndBlocker(ctx sdk.Context, keeper Keeper, poaKeeper poa.Keeper) []abci.Event {
 	logger := keeper.Logger(ctx)
+	callsMetric := metrics.Get().MultisigCalls
 	resEvents := sdk.NewEventManager()
 
 	start := ctx.BlockHeight() - keeper.GetIntervalToExecute(ctx)
@@ -49,16 +52,15 @@ func EndBlocker(ctx sdk.Context, keeper Keeper, poaKeeper poa.Keeper) []abci.Eve
 			call := keeper.getCallById(ctx, callId)
 			call.Approved = true
 
-			handler := keeper.router.GetRoute(call.Msg.Route())
-
 			cacheCtx, writeCache := ctx.CacheContext()
-			err := handler(cacheCtx, call.Msg)
+			err := executeCall(cacheCtx, keeper, call.Msg)
 
 			if err != nil {
 				// call execution failed, write it to status
 				call.Failed = true
 				call.Error = err.Error()
 
+				callsMetric.With(metrics.LabelStatus, metrics.MultisigStatusFailed).Add(1)
 				resEvents.EmitEvent(sdk.NewEvent("failed", sdk.Attribute{Key: "callId", Value: fmt.Sprintf("%d", callId)}))
 
 				logger.Info(fmt.Sprintf("Failed execution of %d call, error: %s, marked as failed", callId, err.Error()))
@@ -66,6 +68,7 @@ func EndBlocker(ctx sdk.Context, keeper Keeper, poaKeeper poa.Keeper) []abci.Eve
 				call.Executed = true
 				writeCache()
 
+				callsMetric.With(metrics.LabelStatus, metrics.MultisigStatusExecuted).Add(1)
 				resEvents.EmitEvent(sdk.NewEvent("executed", sdk.Attribute{Key: "callId", Value: fmt.Sprintf("%d", callId)}))
 
 				logger.Info(fmt.Sprintf("Call %d executed completed", callId))
@@ -100,6 +103,7 @@ func EndBlocker(ctx sdk.Context, keeper Keeper, poaKeeper poa.Keeper) []abci.Eve
 			keeper.saveCallById(ctx, callId, call)
 			keeper.removeCallFromQueue(ctx, callId, call.Height)
 
+			callsMetric.With(metrics.LabelStatus, metrics.MultisigStatusRejected).Add(1)
 			resEvents.EmitEvent(sdk.NewEvent("reject-call", sdk.Attribute{Key: "callId", Value: fmt.Sprintf("%d", start)}))
 			logger.Info(fmt.Sprintf("Removing %d call as not approved in time", callId))
 		}
@@ -107,3 +111,12 @@ func EndBlocker(ctx sdk.Context, keeper Keeper, poaKeeper poa.Keeper) []abci.Eve
 
 	return resEvents.ABCIEvents()
 }
+
+// Execute call message handler, handler panic is returned as an error (call is marked as failed, block is not halted).
+func executeCall(ctx sdk.Context, keeper Keeper, msg core.MsMsg) (retErr error) {
+	defer sdkErrors.Recover(&retErr)
+
+	handler := keeper.router.GetRoute(msg.Route())
+
+	return handler(ctx, msg)
+}
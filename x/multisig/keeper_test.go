@@ -29,7 +29,8 @@ const (
 	ethAddress3 = "0x29D7d1dd5B6f9C864d9db560D72a247c178aE88B"
 	ethAddress4 = "0x29D7d1dd5B6f9C864d9db560D72a247c178aE89B"
 	//
-	msgRouteNoop = "noop"
+	msgRouteNoop  = "noop"
+	msgRoutePanic = "panic"
 )
 
 var (
@@ -71,18 +72,18 @@ func (ti *testInput) ModuleAccountAddrs() map[string]bool {
 }
 
 type TestMsg struct {
-	msgRoute string
-	msgType  string
+	MsgRoute string
+	MsgType  string
 }
 
-func (m TestMsg) Route() string            { return m.msgRoute }
-func (m TestMsg) Type() string             { return m.msgType }
+func (m TestMsg) Route() string            { return m.MsgRoute }
+func (m TestMsg) Type() string             { return m.MsgType }
 func (m TestMsg) ValidateBasic() error { return nil }
 
 func NewTestMsg(msgRoute, msgType string) TestMsg {
 	return TestMsg{
-		msgRoute: msgRoute,
-		msgType:  msgType,
+		MsgRoute: msgRoute,
+		MsgType:  msgType,
 	}
 }
 
@@ -138,6 +139,13 @@ func setupTestInput(t *testing.T) testInput {
 	input.msRouter.AddRoute(msgRouteNoop, func(ctx sdk.Context, msg core.MsMsg) error {
 		return nil
 	})
+	// panics on execution only (call is checked by handler on submit)
+	input.msRouter.AddRoute(msgRoutePanic, func(ctx sdk.Context, msg core.MsMsg) error {
+		if ctx.BlockHeight() > 0 {
+			panic("handler panic")
+		}
+		return nil
+	})
 
 	input.cdc.RegisterConcrete(TestMsg{}, "multisig/test-msg", nil)
 	input.target = NewKeeper(input.keyMs, input.cdc, input.msRouter, input.paramsKeeper.Subspace(mstypes.DefaultParamspace))
@@ -221,6 +229,30 @@ func TestKeeper_SubmitCallUniqueness(t *testing.T) {
 	}
 }
 
+func TestEndBlocker_CallHandlerPanic(t *testing.T) {
+	t.Parallel()
+
+	input := setupTestInput(t)
+	ctx := input.ctx
+	target := input.target
+	target.InitGenesis(ctx, mstypes.GenesisState{Parameters: mstypes.DefaultParams()})
+
+	// call is confirmed by submitter (no validators, one confirmation is enough)
+	addr := sdk.AccAddress([]byte("addr1"))
+	require.NoError(t, target.SubmitCall(ctx, NewTestMsg(msgRoutePanic, "panic"), "1", addr))
+
+	require.NotPanics(t, func() {
+		EndBlocker(ctx.WithBlockHeight(1), target, input.poaKeeper)
+	})
+
+	call, err := target.GetCall(ctx, 0)
+	require.NoError(t, err)
+	require.True(t, call.Approved)
+	require.True(t, call.Failed)
+	require.False(t, call.Executed)
+	require.Contains(t, call.Error, "handler panic")
+}
+
 func TestModule_ValidateGenesis(t *testing.T) {
 	t.Parallel()
 
@@ -48,6 +48,11 @@ func (msg MsgSubmitCall) ValidateBasic() error {
 	return nil
 }
 
+// Get wrapped multisig call message (implements core.MsMsgWrapper).
+func (msg MsgSubmitCall) GetMsMsg() core.MsMsg {
+	return msg.Msg
+}
+
 func (msg MsgSubmitCall) GetSignBytes() []byte {
 	bc, err := json.Marshal(msg)
 
@@ -6,25 +6,27 @@ import (
 )
 
 type (
-	GenesisState       = types.GenesisState
-	MsgPostPrice       = types.MsgPostPrice
-	Params             = types.Params
-	ParamSubspace      = types.ParamSubspace
-	QueryRawPricesResp = types.QueryRawPricesResp
-	QueryAssetsResp    = types.QueryAssetsResp
-	Asset              = types.Asset
-	Assets             = types.Assets
-	Oracle             = types.Oracle
-	Oracles            = types.Oracles
-	CurrentPrice       = types.CurrentPrice
-	PostedPrice        = types.PostedPrice
-	SortDecs           = types.SortDecs
-	Keeper             = keeper.Keeper
-	MsgAddOracle       = types.MsgAddOracle
-	MsgSetOracles      = types.MsgSetOracles
-	MsgAddAsset        = types.MsgAddAsset
-	MsgSetAsset        = types.MsgSetAsset
-	PostPriceParams    = types.PostPriceParams
+	GenesisState        = types.GenesisState
+	MsgPostPrice        = types.MsgPostPrice
+	Params              = types.Params
+	ParamSubspace       = types.ParamSubspace
+	QueryRawPricesResp  = types.QueryRawPricesResp
+	QueryAssetsResp     = types.QueryAssetsResp
+	Asset               = types.Asset
+	Assets              = types.Assets
+	Oracle              = types.Oracle
+	Oracles             = types.Oracles
+	CurrentPrice        = types.CurrentPrice
+	PostedPrice         = types.PostedPrice
+	SortDecs            = types.SortDecs
+	Keeper              = keeper.Keeper
+	MsgAddOracle        = types.MsgAddOracle
+	MsgSetOracles       = types.MsgSetOracles
+	MsgAddAsset         = types.MsgAddAsset
+	MsgSetAsset         = types.MsgSetAsset
+	PostPriceParams     = types.PostPriceParams
+	PriceInfo           = types.PriceInfo
+	AggregationStrategy = types.AggregationStrategy
 )
 
 const (
@@ -33,6 +35,10 @@ const (
 	QuerierRoute      = types.QuerierRoute
 	DefaultParamspace = types.DefaultParamspace
 	StoreKey          = types.StoreKey
+	// aggregation strategies
+	AggregationMedian         = types.AggregationMedian
+	AggregationTrimmedMean    = types.AggregationTrimmedMean
+	AggregationWeightedMedian = types.AggregationWeightedMedian
 )
 
 var (
@@ -54,4 +60,6 @@ var (
 	NewParams           = types.NewParams
 	DefaultParams       = types.DefaultParams
 	NewQuerier          = keeper.NewQuerier
+
+	GetPriceInfoAccessPath = keeper.GetPriceInfoAccessPath
 )
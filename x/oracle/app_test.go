@@ -47,6 +47,9 @@ func (storage VMStorageImpl) HasValue(ctx sdk.Context, accessPath *vm_grpc.VMAcc
 func (storage VMStorageImpl) DelValue(ctx sdk.Context, accessPath *vm_grpc.VMAccessPath) {
 }
 
+func (storage VMStorageImpl) IterateValues(ctx sdk.Context, handler func(accessPath *vm_grpc.VMAccessPath, value []byte) bool) {
+}
+
 // GenTx generates a signed mock transaction.
 func GenTx(msgs []sdk.Msg, accnums []uint64, seq []uint64, priv ...crypto.PrivKey) auth.StdTx {
 	// Make the transaction free
@@ -97,18 +97,17 @@ func AddAssetGenCmd(ctx *server.Context, cdc *codec.Codec,
 				return fmt.Errorf("%s argument %q: empty", "denom", args[0])
 			}
 
-			oracleArgs := strings.Split(args[1], ",")
-			if len(oracleArgs) == 0 {
+			oracles, err := types.ParseOracles(args[1])
+			if err != nil {
+				return fmt.Errorf("%s argument %q: %w", "oracles", args[1], err)
+			}
+			if len(oracles) == 0 {
 				return fmt.Errorf("%s argument: empty slice", "oracles")
 			}
 
-			oracles := make(types.Oracles, 0, len(oracleArgs))
-			for i, arg := range oracleArgs {
-				addr, err := sdk.AccAddressFromBech32(arg)
-				if err != nil {
-					return fmt.Errorf("%s argument: %q address at index %d: %w", "oracles", arg, i, err)
-				}
-				oracles = append(oracles, types.Oracle{Address: addr})
+			aggregation, err := types.ParseAggregationStrategy(viper.GetString(FlagAggregation))
+			if err != nil {
+				return fmt.Errorf("%s flag: %w", FlagAggregation, err)
 			}
 
 			config := ctx.Config
@@ -134,9 +133,16 @@ func AddAssetGenCmd(ctx *server.Context, cdc *codec.Codec,
 			}
 
 			if foundIdx == -1 {
-				genesisOracle.Params.Assets = append(genesisOracle.Params.Assets, types.NewAsset(denom, oracles, true))
+				asset := types.NewAsset(denom, oracles, true)
+				asset.Aggregation = aggregation
+				genesisOracle.Params.Assets = append(genesisOracle.Params.Assets, asset)
 			} else {
 				genesisOracle.Params.Assets[foundIdx].Oracles = oracles
+				genesisOracle.Params.Assets[foundIdx].Aggregation = aggregation
+			}
+
+			if err := genesisOracle.Params.Validate(); err != nil {
+				return err
 			}
 
 			// update app state
@@ -157,6 +163,7 @@ func AddAssetGenCmd(ctx *server.Context, cdc *codec.Codec,
 
 	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
 	cmd.Flags().String(flagClientHome, defaultClientHome, "client's home directory")
+	addAggregationFlag(cmd)
 
 	return cmd
 }
@@ -11,11 +11,23 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	tmtime "github.com/tendermint/tendermint/types/time"
 
 	"github.com/dfinance/dnode/x/oracle/internal/types"
 )
 
+const (
+	FlagAggregation = "aggregation"
+)
+
+// Add asset aggregation strategy flag to command.
+func addAggregationFlag(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(FlagAggregation, string(types.AggregationMedian), fmt.Sprintf("raw prices aggregation strategy: %s, %s, %s", types.AggregationMedian, types.AggregationTrimmedMean, types.AggregationWeightedMedian))
+
+	return cmd
+}
+
 // GetCmdPostPrice cli command for posting prices.
 func GetCmdPostPrice(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -49,21 +61,24 @@ func GetCmdPostPrice(cdc *codec.Codec) *cobra.Command {
 
 func GetCmdAddOracle(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:     "add-oracle [nominee_key] [denom] [oracle_address]",
-		Example: "dncli oracle add-oracle wallet1a7280dyzp487r7wghr99f6r3h2h2z4gk4d740m eth_usdt wallet1a7260dyzp487r7wghr99f6r3h2h2z4gk4d740k",
-		Short:   "Create a new oracle",
+		Use:     "add-oracle [nominee_key] [denom] [oracle_address[:weight]]",
+		Example: "dncli oracle add-oracle wallet1a7280dyzp487r7wghr99f6r3h2h2z4gk4d740m eth_usdt wallet1a7260dyzp487r7wghr99f6r3h2h2z4gk4d740k:2",
+		Short:   "Create a new oracle (weight is required for weighted_median aggregation)",
 		Args:    cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[0]).WithCodec(cdc)
 
-			oracleAddr, err := sdk.AccAddressFromBech32(args[2])
+			oracles, err := types.ParseOracles(args[2])
 			if err != nil {
 				return fmt.Errorf("%s argument %q: %w", "oracle_address", args[2], err)
 			}
+			if len(oracles) != 1 {
+				return fmt.Errorf("%s argument %q: single oracle expected", "oracle_address", args[2])
+			}
 
-			msg := types.NewMsgAddOracle(cliCtx.GetFromAddress(), args[1], oracleAddr)
+			msg := types.NewMsgAddOracle(cliCtx.GetFromAddress(), args[1], oracles[0].Address, oracles[0].Weight)
 
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
@@ -94,9 +109,9 @@ func GetCmdSetOracles(cdc *codec.Codec) *cobra.Command {
 }
 
 func GetCmdAddAsset(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	return addAggregationFlag(&cobra.Command{
 		Use:     "add-asset [nominee_key] [denom] [oracles]",
-		Example: "dncli oracle add-asset wallet1a7280dyzp487r7wghr99f6r3h2h2z4gk4d740m eth_usdt wallet1a7260dyzp487r7wghr99f6r3h2h2z4gk4d740k",
+		Example: "dncli oracle add-asset wallet1a7280dyzp487r7wghr99f6r3h2h2z4gk4d740m eth_usdt wallet1a7260dyzp487r7wghr99f6r3h2h2z4gk4d740k:2,wallet10ff6y8gm2re6awfwz5dvesar8jq02tx7vcvuxn:1 --aggregation weighted_median",
 		Short:   "Create a new asset",
 		Args:    cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -117,7 +132,13 @@ func GetCmdAddAsset(cdc *codec.Codec) *cobra.Command {
 				return fmt.Errorf("%s argument %q: empty slice", "oracles", args[2])
 			}
 
+			aggregation, err := types.ParseAggregationStrategy(viper.GetString(FlagAggregation))
+			if err != nil {
+				return fmt.Errorf("%s flag: %w", FlagAggregation, err)
+			}
+
 			token := types.NewAsset(denom, oracles, true)
+			token.Aggregation = aggregation
 			if err := token.ValidateBasic(); err != nil {
 				return err
 			}
@@ -126,11 +147,11 @@ func GetCmdAddAsset(cdc *codec.Codec) *cobra.Command {
 
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
-	}
+	})
 }
 
 func GetCmdSetAsset(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	return addAggregationFlag(&cobra.Command{
 		Use:     "set-asset [nominee_key] [denom] [oracles]",
 		Example: "dncli oracle set-asset wallet1a7280dyzp487r7wghr99f6r3h2h2z4gk4d740m eth_usdt wallet1a7260dyzp487r7wghr99f6r3h2h2z4gk4d740k",
 		Short:   "Create a set asset",
@@ -153,7 +174,13 @@ func GetCmdSetAsset(cdc *codec.Codec) *cobra.Command {
 				return fmt.Errorf("%s argument %q: empty slice", "oracles", args[2])
 			}
 
+			aggregation, err := types.ParseAggregationStrategy(viper.GetString(FlagAggregation))
+			if err != nil {
+				return fmt.Errorf("%s flag: %w", FlagAggregation, err)
+			}
+
 			token := types.NewAsset(denom, oracles, true)
+			token.Aggregation = aggregation
 			if err := token.ValidateBasic(); err != nil {
 				return err
 			}
@@ -162,5 +189,5 @@ func GetCmdSetAsset(cdc *codec.Codec) *cobra.Command {
 
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
-	}
+	})
 }
@@ -59,7 +59,7 @@ func handleMsgAddOracle(ctx sdk.Context, k Keeper, msg types.MsgAddOracle) (*sdk
 		return nil, sdkErrors.Wrap(types.ErrInvalidOracle, msg.Oracle.String())
 	}
 
-	if err := k.AddOracle(ctx, msg.Nominee.String(), msg.Denom, msg.Oracle); err != nil {
+	if err := k.AddOracle(ctx, msg.Nominee.String(), msg.Denom, msg.Oracle, msg.Weight); err != nil {
 		return nil, sdkErrors.Wrap(types.ErrInternal, err.Error())
 	}
 
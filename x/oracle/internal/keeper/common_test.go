@@ -49,6 +49,9 @@ func (storage VMStorageImpl) HasValue(ctx sdk.Context, accessPath *vm_grpc.VMAcc
 func (storage VMStorageImpl) DelValue(ctx sdk.Context, accessPath *vm_grpc.VMAccessPath) {
 }
 
+func (storage VMStorageImpl) IterateValues(ctx sdk.Context, handler func(accessPath *vm_grpc.VMAccessPath, value []byte) bool) {
+}
+
 func getMockApp(t *testing.T, numGenAccs int, genState types.GenesisState, genAccs []authexported.Account) testHelper {
 	mApp := mock.NewApp()
 	types.RegisterCodec(mApp.Cdc)
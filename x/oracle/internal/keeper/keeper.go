@@ -1,7 +1,6 @@
 package keeper
 
 import (
-	"sort"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -10,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/params"
 
 	"github.com/dfinance/dnode/helpers"
+	"github.com/dfinance/dnode/helpers/metrics"
 	"github.com/dfinance/dnode/x/common_vm"
 
 	"github.com/dfinance/dnode/x/oracle/internal/types"
@@ -113,7 +113,7 @@ func (k Keeper) SetPrice(
 	return prices[index], nil
 }
 
-// SetCurrentPrices updates the price of an asset to the median of all valid oracle inputs and cleans up previous inputs
+// SetCurrentPrices updates the price of an asset aggregating all valid oracle inputs (using asset aggregation strategy)
 func (k Keeper) SetCurrentPrices(ctx sdk.Context) error {
 	store := ctx.KVStore(k.storeKey)
 	assets := k.GetAssetParams(ctx)
@@ -122,63 +122,41 @@ func (k Keeper) SetCurrentPrices(ctx sdk.Context) error {
 		assetCode := v.AssetCode
 		rawPrices := k.GetRawPrices(ctx, assetCode, ctx.BlockHeight())
 
-		l := len(rawPrices)
-		var medianPrice sdk.Int
-		var medianReceivedAt time.Time
 		// TODO make threshold for acceptance (ie. require 51% of oracles to have posted valid prices
-		if l == 0 {
-			// Error if there are no valid prices in the raw oracle
-			//return types.ErrNoValidPrice(k.codespace)
-			medianPrice = sdk.ZeroInt()
-		} else if l == 1 {
-			// Return immediately if there's only one price
-			medianPrice, medianReceivedAt = rawPrices[0].Price, rawPrices[0].ReceivedAt
-		} else {
-			// sort the prices
-			sort.Slice(rawPrices, func(i, j int) bool {
-				return rawPrices[i].Price.LT(rawPrices[j].Price)
-			})
-			// If there's an even number of prices
-			if l%2 == 0 {
-				// TODO make sure this is safe.
-				// Since it's a price and not a blance, division with precision loss is OK.
-				price1 := rawPrices[l/2-1].Price
-				price2 := rawPrices[l/2].Price
-				sum := price1.Add(price2)
-				divsor := sdk.NewInt(2)
-				medianPrice = sum.Quo(divsor)
-				medianReceivedAt = ctx.BlockTime().UTC()
-			} else {
-				// integer division, so we'll get an integer back, rounded down
-				medianPrice, medianReceivedAt = rawPrices[l/2].Price, rawPrices[l/2].ReceivedAt
-			}
-		}
+		price, receivedAt := v.AggregatePrices(rawPrices, ctx.BlockTime().UTC())
 
-		// check if there is no rawPrices or medianPrice is invalid
-		if medianPrice.IsZero() {
+		// check if there is no rawPrices or aggregated price is invalid
+		if price.IsZero() {
 			continue
 		}
 
 		// check new price for the asset appeared, no need to update after every block
 		oldPrice := k.GetCurrentPrice(ctx, assetCode)
-		if oldPrice.AssetCode != "" && oldPrice.Price.Equal(medianPrice) {
+		if oldPrice.AssetCode != "" && oldPrice.Price.Equal(price) {
 			continue
 		}
 
 		// set the new price for the asset
 		newPrice := types.CurrentPrice{
 			AssetCode:  assetCode,
-			Price:      medianPrice,
-			ReceivedAt: medianReceivedAt,
+			Price:      price,
+			ReceivedAt: receivedAt,
 		}
 
 		store.Set(
 			[]byte(types.CurrentPricePrefix+assetCode), k.cdc.MustMarshalBinaryBare(newPrice),
 		)
 
+		// index price info VM resource path for DS middleware
+		if priceInfoPath, ok := GetPriceInfoAccessPath(assetCode); ok {
+			store.Set(types.GetPriceInfoPathKey(priceInfoPath.Path), []byte(assetCode))
+		}
+
 		// save price to vm storage
 		accessPath := k.vmKeeper.GetOracleAccessPath(newPrice.AssetCode)
 		k.vmKeeper.SetValue(ctx, accessPath, helpers.BigToBytes(newPrice.Price, types.PriceBytesLimit))
+
+		metrics.Get().OraclePriceUpdates.With(metrics.LabelAsset, assetCode).Add(1)
 	}
 
 	return nil
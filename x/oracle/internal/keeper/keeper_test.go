@@ -7,11 +7,15 @@ import (
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"github.com/dfinance/lcs"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmtime "github.com/tendermint/tendermint/types/time"
 
 	"github.com/dfinance/dnode/helpers/tests"
+	"github.com/dfinance/dnode/x/common_vm"
+	"github.com/dfinance/dnode/x/oracle/internal/keeper"
 	"github.com/dfinance/dnode/x/oracle/internal/types"
 )
 
@@ -89,7 +93,7 @@ func TestKeeper_SetAddAsset(t *testing.T) {
 	require.Equal(t, assets[0].AssetCode, "tstusd")
 	require.Equal(t, assets[1].AssetCode, "tst2usd")
 
-	err = helper.keeper.AddOracle(ctx, helper.addrs[0].String(), "tst2usd", helper.addrs[1].Bytes())
+	err = helper.keeper.AddOracle(ctx, helper.addrs[0].String(), "tst2usd", helper.addrs[1].Bytes(), 0)
 	require.Nil(t, err)
 	oracles, err := helper.keeper.GetOracles(ctx, "tst2usd")
 	require.Nil(t, err)
@@ -100,6 +104,47 @@ func TestKeeper_SetAddAsset(t *testing.T) {
 	require.Equal(t, found, false)
 }
 
+// Check oracles changes are validated against asset aggregation strategy.
+func TestKeeper_AddSetOraclesAggregation(t *testing.T) {
+	helper := getMockApp(t, 3, types.GenesisState{}, nil)
+	header := abci.Header{
+		Height: helper.mApp.LastBlockHeight() + 1,
+		Time:   tmtime.Now()}
+	helper.mApp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := helper.mApp.BaseApp.NewContext(false, header)
+
+	nominee, assetCode := helper.addrs[0].String(), "eth_usdt"
+	oracle1 := types.Oracle{Address: helper.addrs[1], Weight: 2}
+	helper.keeper.SetParams(ctx, types.Params{
+		Assets: []types.Asset{
+			{AssetCode: assetCode, Oracles: types.Oracles{oracle1}, Active: true, Aggregation: types.AggregationWeightedMedian},
+		},
+		Nominees: []string{nominee},
+	})
+
+	// zero weight oracle is rejected
+	require.Error(t, helper.keeper.AddOracle(ctx, nominee, assetCode, helper.addrs[2], 0))
+	oracles, err := helper.keeper.GetOracles(ctx, assetCode)
+	require.NoError(t, err)
+	require.Equal(t, types.Oracles{oracle1}, oracles)
+
+	require.Error(t, helper.keeper.SetOracles(ctx, nominee, assetCode, types.Oracles{oracle1, {Address: helper.addrs[2]}}))
+	oracles, err = helper.keeper.GetOracles(ctx, assetCode)
+	require.NoError(t, err)
+	require.Equal(t, types.Oracles{oracle1}, oracles)
+
+	// weighted oracle is added
+	require.NoError(t, helper.keeper.AddOracle(ctx, nominee, assetCode, helper.addrs[2], 1))
+	oracles, err = helper.keeper.GetOracles(ctx, assetCode)
+	require.NoError(t, err)
+	require.Equal(t, types.Oracles{oracle1, {Address: helper.addrs[2], Weight: 1}}, oracles)
+
+	require.NoError(t, helper.keeper.SetOracles(ctx, nominee, assetCode, types.Oracles{{Address: helper.addrs[2], Weight: 3}}))
+	oracles, err = helper.keeper.GetOracles(ctx, assetCode)
+	require.NoError(t, err)
+	require.Equal(t, types.Oracles{{Address: helper.addrs[2], Weight: 3}}, oracles)
+}
+
 // TestKeeper_GetSetPrice Test Posting the price by an oracle
 func TestKeeper_GetSetPrice(t *testing.T) {
 	helper := getMockApp(t, 2, types.GenesisState{}, nil)
@@ -212,6 +257,130 @@ func TestKeeper_GetSetCurrentPrice(t *testing.T) {
 	require.Equal(t, price.Price.Equal(sdk.NewInt(34500000)), true)
 }
 
+// TestKeeper_SetCurrentPricesAggregation tests current price is calculated using asset aggregation strategy
+func TestKeeper_SetCurrentPricesAggregation(t *testing.T) {
+	helper := getMockApp(t, 4, types.GenesisState{}, nil)
+	header := abci.Header{
+		Height: helper.mApp.LastBlockHeight() + 1,
+		Time:   tmtime.Now()}
+	helper.mApp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := helper.mApp.BaseApp.NewContext(false, header)
+
+	nominee := helper.addrs[0].String()
+	oracles := types.Oracles{
+		{Address: helper.addrs[0], Weight: 1},
+		{Address: helper.addrs[1], Weight: 1},
+		{Address: helper.addrs[2], Weight: 1},
+		{Address: helper.addrs[3], Weight: 10},
+	}
+	ap := types.Params{
+		Assets: []types.Asset{
+			types.Asset{AssetCode: "tstusd", Oracles: oracles, Active: true, Aggregation: types.AggregationTrimmedMean},
+		},
+		Nominees: []string{nominee},
+	}
+	helper.keeper.SetParams(ctx, ap)
+
+	setPrices := func() {
+		for i, price := range []int64{33000000, 34000000, 35000000, 90000000} {
+			_, err := helper.keeper.SetPrice(ctx, helper.addrs[i], "tstusd", sdk.NewInt(price), header.Time)
+			require.NoError(t, err)
+		}
+	}
+
+	// trimmed mean: the lowest and the highest prices are dropped
+	setPrices()
+	require.NoError(t, helper.keeper.SetCurrentPrices(ctx))
+	price := helper.keeper.GetCurrentPrice(ctx, "tstusd")
+	require.True(t, price.Price.Equal(sdk.NewInt(34500000)), "price: %s", price.Price)
+
+	// weighted median: the heaviest oracle price
+	asset := types.NewAsset("tstusd", oracles, true)
+	asset.Aggregation = types.AggregationWeightedMedian
+	require.NoError(t, helper.keeper.SetAsset(ctx, nominee, "tstusd", asset))
+
+	setPrices()
+	require.NoError(t, helper.keeper.SetCurrentPrices(ctx))
+	price = helper.keeper.GetCurrentPrice(ctx, "tstusd")
+	require.True(t, price.Price.Equal(sdk.NewInt(90000000)), "price: %s", price.Price)
+
+	// median
+	asset.Aggregation = types.AggregationMedian
+	require.NoError(t, helper.keeper.SetAsset(ctx, nominee, "tstusd", asset))
+
+	setPrices()
+	require.NoError(t, helper.keeper.SetCurrentPrices(ctx))
+	price = helper.keeper.GetCurrentPrice(ctx, "tstusd")
+	require.True(t, price.Price.Equal(sdk.NewInt(34500000)), "price: %s", price.Price)
+}
+
+// Test price info DS middleware returns current price with ReceivedAt timestamp.
+func TestKeeper_PriceInfoMiddleware(t *testing.T) {
+	helper := getMockApp(t, 1, types.GenesisState{}, nil)
+	header := abci.Header{
+		Height: helper.mApp.LastBlockHeight() + 1,
+		Time:   tmtime.Now()}
+	helper.mApp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := helper.mApp.BaseApp.NewContext(false, header)
+	ap := types.Params{
+		Assets: []types.Asset{
+			types.Asset{AssetCode: "eth_usdt", Oracles: types.Oracles{}, Active: true},
+			types.Asset{AssetCode: "tstusd", Oracles: types.Oracles{}, Active: true},
+		},
+	}
+	helper.keeper.SetParams(ctx, ap)
+
+	middleware := helper.keeper.NewPriceInfoMiddleware()
+	path, ok := keeper.GetPriceInfoAccessPath("eth_usdt")
+	require.True(t, ok)
+
+	// path is derived from Oracle::PriceInfo<Coins::ETH, Coins::USDT> struct tag, asset code is case insensitive
+	{
+		tag := common_vm.NewStructTag(types.PriceInfoModule, types.PriceInfoName, common_vm.NewCurrencyStructTag("eth"), common_vm.NewCurrencyStructTag("usdt"))
+		require.Equal(t, tag.AccessPath(common_vm.ZeroAddress), path)
+
+		upperPath, ok := keeper.GetPriceInfoAccessPath("ETH_USDT")
+		require.True(t, ok)
+		require.Equal(t, path, upperPath)
+	}
+
+	// asset code is not a currency pair
+	{
+		_, ok := keeper.GetPriceInfoAccessPath("tstusd")
+		require.False(t, ok)
+		_, ok = keeper.GetPriceInfoAccessPath("eth_")
+		require.False(t, ok)
+	}
+
+	// no current price
+	data, err := middleware(ctx, path)
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	helper.keeper.SetPrice(ctx, helper.addrs[0], "eth_usdt", sdk.NewInt(33000000), header.Time)
+	require.NoError(t, helper.keeper.SetCurrentPrices(ctx))
+
+	data, err = middleware(ctx, path)
+	require.NoError(t, err)
+
+	priceInfo := types.PriceInfo{}
+	require.NoError(t, lcs.Unmarshal(data, &priceInfo))
+	require.EqualValues(t, 33000000, priceInfo.Price)
+	require.EqualValues(t, header.Time.Unix(), priceInfo.ReceivedAt)
+
+	// unknown asset
+	unknownPath, ok := keeper.GetPriceInfoAccessPath("btc_usdt")
+	require.True(t, ok)
+	data, err = middleware(ctx, unknownPath)
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	// same path under non-zero address
+	data, err = middleware(ctx, &vm_grpc.VMAccessPath{Address: helper.addrs[0], Path: path.Path})
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
 func TestKeeper_checkPriceReceivedAtTimestamp(t *testing.T) {
 	helper := getMockApp(t, 2, types.GenesisState{}, nil)
 
@@ -0,0 +1,55 @@
+// Data source middleware providing oracle prices to VM.
+package keeper
+
+import (
+	"bytes"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"github.com/dfinance/lcs"
+
+	"github.com/dfinance/dnode/x/common_vm"
+	"github.com/dfinance/dnode/x/oracle/internal/types"
+)
+
+// Oracle price info middleware: returns asset current price with its ReceivedAt timestamp.
+// Price info is a synthetic resource per asset, asset is resolved by resource path using lookup stored with the price.
+func (k Keeper) NewPriceInfoMiddleware() common_vm.DSDataMiddleware {
+	return func(ctx sdk.Context, path *vm_grpc.VMAccessPath) (data []byte, err error) {
+		if !bytes.Equal(path.Address, common_vm.ZeroAddress) {
+			return
+		}
+
+		bz := ctx.KVStore(k.storeKey).Get(types.GetPriceInfoPathKey(path.Path))
+		if bz == nil {
+			return
+		}
+
+		price := k.GetCurrentPrice(ctx, string(bz))
+		if price.AssetCode == "" {
+			return
+		}
+
+		return lcs.Marshal(types.PriceInfo{
+			Price:      price.Price.Uint64(),
+			ReceivedAt: uint64(price.ReceivedAt.Unix()),
+		})
+	}
+}
+
+// Get price info synthetic resource access path for asset: Oracle::PriceInfo<Base, Quote>.
+// Asset code is expected to be in {base}_{quote} format (case insensitive), returns false otherwise.
+func GetPriceInfoAccessPath(assetCode string) (*vm_grpc.VMAccessPath, bool) {
+	denoms := strings.Split(strings.ToLower(assetCode), types.AssetCodeDelimiter)
+	if len(denoms) != 2 || denoms[0] == "" || denoms[1] == "" {
+		return nil, false
+	}
+
+	path := common_vm.GetSyntheticResourcePath(
+		types.PriceInfoModule, types.PriceInfoName,
+		common_vm.NewCurrencyStructTag(denoms[0]), common_vm.NewCurrencyStructTag(denoms[1]),
+	)
+
+	return path, true
+}
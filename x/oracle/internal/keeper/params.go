@@ -55,7 +55,8 @@ func (k Keeper) GetOracles(ctx sdk.Context, assetCode string) (types.Oracles, er
 }
 
 // AddOracle adds the oracle to the oracle store for specific assetCode
-func (k Keeper) AddOracle(ctx sdk.Context, nominee string, assetCode string, address sdk.AccAddress) error {
+// Asset aggregation is validated with the new oracle (weighted median aggregation requires non-zero weight).
+func (k Keeper) AddOracle(ctx sdk.Context, nominee string, assetCode string, address sdk.AccAddress, weight uint32) error {
 	if !k.IsNominee(ctx, nominee) {
 		return fmt.Errorf("%q is not a valid nominee", nominee)
 	}
@@ -70,7 +71,11 @@ func (k Keeper) AddOracle(ctx sdk.Context, nominee string, assetCode string, add
 	for _, a := range assets {
 		if assetCode == a.AssetCode {
 			oracle := types.NewOracle(address)
+			oracle.Weight = weight
 			a.Oracles = append(a.Oracles, oracle)
+			if err := a.ValidateAggregation(); err != nil {
+				return fmt.Errorf("asset %q: %w", assetCode, err)
+			}
 			found = true
 		}
 		updateAssets = append(updateAssets, a)
@@ -86,6 +91,7 @@ func (k Keeper) AddOracle(ctx sdk.Context, nominee string, assetCode string, add
 }
 
 // SetOracles sets the oracle store for specific assetCode
+// Asset aggregation is validated with the new oracles.
 func (k Keeper) SetOracles(ctx sdk.Context, nominee string, assetCode string, addresses types.Oracles) error {
 	if !k.IsNominee(ctx, nominee) {
 		return fmt.Errorf("%q is not a valid nominee", nominee)
@@ -97,6 +103,9 @@ func (k Keeper) SetOracles(ctx sdk.Context, nominee string, assetCode string, ad
 	for _, a := range assets {
 		if assetCode == a.AssetCode {
 			a.Oracles = addresses
+			if err := a.ValidateAggregation(); err != nil {
+				return fmt.Errorf("asset %q: %w", assetCode, err)
+			}
 			found = true
 		}
 		updateAssets = append(updateAssets, a)
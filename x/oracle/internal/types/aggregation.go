@@ -0,0 +1,167 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AggregationStrategy defines how oracles raw prices are aggregated into the asset current price.
+type AggregationStrategy string
+
+const (
+	// Median of raw prices (default, used if strategy is empty).
+	AggregationMedian AggregationStrategy = "median"
+	// Mean of raw prices with TrimmedMeanCutPercent of the lowest and the highest prices dropped.
+	AggregationTrimmedMean AggregationStrategy = "trimmed_mean"
+	// Median of raw prices weighted by oracle weight (each asset oracle must have non-zero weight).
+	AggregationWeightedMedian AggregationStrategy = "weighted_median"
+
+	// Percent of raw prices dropped from each side of sorted prices for the trimmed mean.
+	TrimmedMeanCutPercent = 25
+)
+
+// Validate checks strategy is supported.
+func (s AggregationStrategy) Validate() error {
+	switch s {
+	case "", AggregationMedian, AggregationTrimmedMean, AggregationWeightedMedian:
+		return nil
+	default:
+		return fmt.Errorf("unknown aggregation strategy %q", s)
+	}
+}
+
+// ParseAggregationStrategy parses and validates strategy, empty string is parsed to AggregationMedian.
+func ParseAggregationStrategy(str string) (AggregationStrategy, error) {
+	if str == "" {
+		return AggregationMedian, nil
+	}
+
+	strategy := AggregationStrategy(str)
+	if err := strategy.Validate(); err != nil {
+		return "", err
+	}
+
+	return strategy, nil
+}
+
+// ValidateAggregation checks asset aggregation strategy and oracles are consistent.
+func (a Asset) ValidateAggregation() error {
+	if err := a.Aggregation.Validate(); err != nil {
+		return err
+	}
+
+	if a.Aggregation == AggregationWeightedMedian {
+		for _, o := range a.Oracles {
+			if o.Weight == 0 {
+				return fmt.Errorf("oracle %s: zero weight for %s aggregation", o.Address, a.Aggregation)
+			}
+		}
+	}
+
+	return nil
+}
+
+// AggregatePrices aggregates oracles raw prices using the asset strategy.
+// Returns price and its ReceivedAt timestamp (blockTime if price is calculated from several raw prices).
+// Zero price is returned if there are no raw prices to aggregate.
+func (a Asset) AggregatePrices(rawPrices []PostedPrice, blockTime time.Time) (sdk.Int, time.Time) {
+	if len(rawPrices) == 0 {
+		return sdk.ZeroInt(), time.Time{}
+	}
+
+	// sort the prices
+	prices := make([]PostedPrice, len(rawPrices))
+	copy(prices, rawPrices)
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Price.LT(prices[j].Price)
+	})
+
+	switch a.Aggregation {
+	case AggregationTrimmedMean:
+		return trimmedMean(prices, blockTime)
+	case AggregationWeightedMedian:
+		return weightedMedian(prices, a.Oracles, blockTime)
+	default:
+		return median(prices, blockTime)
+	}
+}
+
+// Median of sorted prices.
+func median(prices []PostedPrice, blockTime time.Time) (sdk.Int, time.Time) {
+	l := len(prices)
+
+	// If there's an even number of prices
+	if l%2 == 0 {
+		// Since it's a price and not a balance, division with precision loss is OK.
+		return prices[l/2-1].Price.Add(prices[l/2].Price).QuoRaw(2), blockTime
+	}
+
+	// integer division, so we'll get an integer back, rounded down
+	return prices[l/2].Price, prices[l/2].ReceivedAt
+}
+
+// Trimmed mean of sorted prices.
+func trimmedMean(prices []PostedPrice, blockTime time.Time) (sdk.Int, time.Time) {
+	cut := len(prices) * TrimmedMeanCutPercent / 100
+	prices = prices[cut : len(prices)-cut]
+
+	if len(prices) == 1 {
+		return prices[0].Price, prices[0].ReceivedAt
+	}
+
+	sum := sdk.ZeroInt()
+	for _, p := range prices {
+		sum = sum.Add(p.Price)
+	}
+
+	return sum.QuoRaw(int64(len(prices))), blockTime
+}
+
+// Weighted median of sorted prices, prices posted by unknown oracles are skipped.
+// If the weights cumulative sum is exactly a half of the total weight, mean of two neighbour prices is used
+// (result is equal to median for equal weights).
+func weightedMedian(prices []PostedPrice, oracles Oracles, blockTime time.Time) (sdk.Int, time.Time) {
+	weights := make([]uint64, len(prices))
+	totalWeight := uint64(0)
+	for i, p := range prices {
+		for _, o := range oracles {
+			if o.Address.Equals(p.OracleAddress) {
+				weights[i] = uint64(o.Weight)
+				break
+			}
+		}
+		totalWeight += weights[i]
+	}
+
+	if totalWeight == 0 {
+		return sdk.ZeroInt(), time.Time{}
+	}
+
+	cumWeight := uint64(0)
+	for i, p := range prices {
+		if weights[i] == 0 {
+			continue
+		}
+
+		cumWeight += weights[i]
+		if 2*cumWeight < totalWeight {
+			continue
+		}
+
+		if 2*cumWeight == totalWeight {
+			for j := i + 1; j < len(prices); j++ {
+				if weights[j] != 0 {
+					return p.Price.Add(prices[j].Price).QuoRaw(2), blockTime
+				}
+			}
+		}
+
+		return p.Price, p.ReceivedAt
+	}
+
+	// must never happen
+	return sdk.ZeroInt(), time.Time{}
+}
@@ -0,0 +1,137 @@
+// +build unit
+
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// Create asset with oracles (weights) and raw prices posted by those oracles.
+func newAggregationTestInput(aggregation AggregationStrategy, prices []int64, weights []uint32) (Asset, []PostedPrice) {
+	oracles := make(Oracles, 0, len(prices))
+	rawPrices := make([]PostedPrice, 0, len(prices))
+	for i, price := range prices {
+		oracle := NewOracle(sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()))
+		if i < len(weights) {
+			oracle.Weight = weights[i]
+		}
+		oracles = append(oracles, oracle)
+
+		rawPrices = append(rawPrices, PostedPrice{
+			AssetCode:     "tstusd",
+			OracleAddress: oracle.Address,
+			Price:         sdk.NewInt(price),
+			ReceivedAt:    time.Unix(int64(i+1), 0),
+		})
+	}
+
+	asset := NewAsset("tstusd", oracles, true)
+	asset.Aggregation = aggregation
+
+	return asset, rawPrices
+}
+
+func TestAggregationStrategy_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, AggregationStrategy("").Validate())
+	require.NoError(t, AggregationMedian.Validate())
+	require.NoError(t, AggregationTrimmedMean.Validate())
+	require.NoError(t, AggregationWeightedMedian.Validate())
+	require.Error(t, AggregationStrategy("mean").Validate())
+
+	strategy, err := ParseAggregationStrategy("")
+	require.NoError(t, err)
+	require.Equal(t, AggregationMedian, strategy)
+
+	strategy, err = ParseAggregationStrategy("trimmed_mean")
+	require.NoError(t, err)
+	require.Equal(t, AggregationTrimmedMean, strategy)
+
+	_, err = ParseAggregationStrategy("Median")
+	require.Error(t, err)
+}
+
+func TestAsset_AggregatePrices(t *testing.T) {
+	t.Parallel()
+
+	blockTime := time.Unix(100, 0)
+
+	testCases := []struct {
+		name          string
+		aggregation   AggregationStrategy
+		prices        []int64
+		weights       []uint32
+		expPrice      int64
+		expReceivedAt time.Time
+	}{
+		{"no prices", AggregationMedian, []int64{}, nil, 0, time.Time{}},
+		{"empty strategy: median", "", []int64{30, 10, 20}, nil, 20, time.Unix(3, 0)},
+		{"median: single", AggregationMedian, []int64{10}, nil, 10, time.Unix(1, 0)},
+		{"median: odd", AggregationMedian, []int64{33, 35, 34}, nil, 34, time.Unix(3, 0)},
+		{"median: even", AggregationMedian, []int64{33, 35, 34, 36}, nil, 34, blockTime},
+		{"trimmed mean: single", AggregationTrimmedMean, []int64{10}, nil, 10, time.Unix(1, 0)},
+		{"trimmed mean: nothing trimmed", AggregationTrimmedMean, []int64{10, 20, 60}, nil, 30, blockTime},
+		{"trimmed mean: outliers trimmed", AggregationTrimmedMean, []int64{1000, 10, 12, 1}, nil, 11, blockTime},
+		{"trimmed mean: 5 prices", AggregationTrimmedMean, []int64{1000, 10, 11, 12, 1}, nil, 11, blockTime},
+		{"trimmed mean: 8 prices", AggregationTrimmedMean, []int64{1, 2, 10, 11, 12, 13, 100, 200}, nil, 11, blockTime},
+		{"weighted median: single", AggregationWeightedMedian, []int64{10}, []uint32{5}, 10, time.Unix(1, 0)},
+		{"weighted median: equal weights odd", AggregationWeightedMedian, []int64{33, 35, 34}, []uint32{1, 1, 1}, 34, time.Unix(3, 0)},
+		{"weighted median: equal weights even", AggregationWeightedMedian, []int64{33, 35, 34, 36}, []uint32{2, 2, 2, 2}, 34, blockTime},
+		{"weighted median: heavy oracle", AggregationWeightedMedian, []int64{10, 20, 30}, []uint32{1, 1, 5}, 30, time.Unix(3, 0)},
+		{"weighted median: heavy low oracle", AggregationWeightedMedian, []int64{10, 20, 30}, []uint32{3, 1, 1}, 10, time.Unix(1, 0)},
+		{"weighted median: half weight", AggregationWeightedMedian, []int64{10, 20, 30}, []uint32{2, 1, 1}, 15, blockTime},
+	}
+
+	for _, tc := range testCases {
+		asset, rawPrices := newAggregationTestInput(tc.aggregation, tc.prices, tc.weights)
+
+		price, receivedAt := asset.AggregatePrices(rawPrices, blockTime)
+		require.True(t, price.Equal(sdk.NewInt(tc.expPrice)), "%s: price %s, expected %d", tc.name, price, tc.expPrice)
+		require.True(t, receivedAt.Equal(tc.expReceivedAt), "%s: receivedAt %v, expected %v", tc.name, receivedAt, tc.expReceivedAt)
+	}
+}
+
+func TestAsset_AggregatePricesUnknownOracle(t *testing.T) {
+	t.Parallel()
+
+	asset, rawPrices := newAggregationTestInput(AggregationWeightedMedian, []int64{10, 20, 30}, []uint32{1, 1, 1})
+	inputPrices := append([]PostedPrice{}, rawPrices...)
+
+	// oracle removed from asset: its price is skipped
+	asset.Oracles = asset.Oracles[:2]
+	price, _ := asset.AggregatePrices(rawPrices, time.Unix(100, 0))
+	require.True(t, price.Equal(sdk.NewInt(15)), "price: %s", price)
+
+	// no known oracles
+	asset.Oracles = Oracles{}
+	price, _ = asset.AggregatePrices(rawPrices, time.Unix(100, 0))
+	require.True(t, price.IsZero())
+
+	// input is not modified
+	require.Equal(t, inputPrices, rawPrices)
+}
+
+func TestParseOracles(t *testing.T) {
+	t.Parallel()
+
+	addr1 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr2 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	oracles, err := ParseOracles(addr1.String() + ", " + addr2.String() + ":10")
+	require.NoError(t, err)
+	require.Len(t, oracles, 2)
+	require.Equal(t, Oracle{Address: addr1, Weight: 0}, oracles[0])
+	require.Equal(t, Oracle{Address: addr2, Weight: 10}, oracles[1])
+
+	_, err = ParseOracles(addr1.String() + ":-1")
+	require.Error(t, err)
+
+	_, err = ParseOracles(addr1.String() + ":abc")
+	require.Error(t, err)
+}
@@ -11,21 +11,23 @@ import (
 
 // Asset struct that represents an asset in the oracle
 type Asset struct {
-	AssetCode string  `json:"asset_code" yaml:"asset_code" example:"dfi"`
-	Oracles   Oracles `json:"oracles" yaml:"oracles"` // List of registered RawPrice sources
-	Active    bool    `json:"active" yaml:"active"`   // Not used ATM
+	AssetCode   string              `json:"asset_code" yaml:"asset_code" example:"dfi"`
+	Oracles     Oracles             `json:"oracles" yaml:"oracles"`                                               // List of registered RawPrice sources
+	Active      bool                `json:"active" yaml:"active"`                                                 // Not used ATM
+	Aggregation AggregationStrategy `json:"aggregation" yaml:"aggregation" swaggertype:"string" example:"median"` // RawPrices aggregation strategy (median if empty)
 }
 
-// NewAsset creates a new asset
+// NewAsset creates a new asset with median aggregation strategy
 func NewAsset(
 	assetCode string,
 	oracles Oracles,
 	active bool,
 ) Asset {
 	return Asset{
-		AssetCode: assetCode,
-		Oracles:   oracles,
-		Active:    active,
+		AssetCode:   assetCode,
+		Oracles:     oracles,
+		Active:      active,
+		Aggregation: AggregationMedian,
 	}
 }
 
@@ -39,6 +41,10 @@ func (a Asset) ValidateBasic() error {
 		return sdkErrors.Wrap(ErrInternal, "invalid TokenRecord: missing Oracles")
 	}
 
+	if err := a.ValidateAggregation(); err != nil {
+		return sdkErrors.Wrapf(ErrInternal, "invalid aggregation: %v", err)
+	}
+
 	return nil
 }
 
@@ -47,8 +53,9 @@ func (a Asset) String() string {
 	return fmt.Sprintf(`Asset:
 	Asset Code: %s
 	Oracles: %s
-	Active: %t`,
-		a.AssetCode, a.Oracles, a.Active)
+	Active: %t
+	Aggregation: %s`,
+		a.AssetCode, a.Oracles, a.Active, a.Aggregation)
 }
 
 // Assets array type for oracle
@@ -67,11 +74,12 @@ func (as Assets) String() string {
 // Oracle struct that documents which address an oracle is using
 type Oracle struct {
 	Address sdk.AccAddress `json:"address" yaml:"address"`
+	Weight  uint32         `json:"weight" yaml:"weight"` // Used by weighted median aggregation
 }
 
 // String implements fmt.Stringer
 func (o Oracle) String() string {
-	return fmt.Sprintf(`Address: %s`, o.Address)
+	return fmt.Sprintf(`Address: %s, Weight: %d`, o.Address, o.Weight)
 }
 
 func NewOracle(address sdk.AccAddress) Oracle {
@@ -38,5 +38,23 @@ func Test_NewAsset(t *testing.T) {
 	{
 		a := NewAsset("dn2eth", oracles, true)
 		require.NoError(t, a.ValidateBasic())
+		require.Equal(t, AggregationMedian, a.Aggregation)
+	}
+
+	// check invalid aggregation
+	{
+		a := NewAsset("dn2eth", oracles, true)
+		a.Aggregation = "unknown"
+		require.Error(t, a.ValidateBasic())
+	}
+
+	// check weighted median with zero oracle weight
+	{
+		a := NewAsset("dn2eth", oracles, true)
+		a.Aggregation = AggregationWeightedMedian
+		require.Error(t, a.ValidateBasic())
+
+		a.Oracles = Oracles{Oracle{Address: oracleAddr, Weight: 1}}
+		require.NoError(t, a.ValidateBasic())
 	}
 }
@@ -29,9 +29,17 @@ const (
 
 	// OraclePrefix store prefix for the oracle accounts
 	OraclePrefix = StoreKey + ":oracles"
+
+	// Store prefix for the price info VM resource path to asset code lookup
+	PriceInfoPathPrefix = StoreKey + ":priceinfopath:"
 )
 
 // Get a key to store PostedPrices for specific assetCode and blockHeight
 func GetRawPricesKey(assetCode string, blockHeight int64) []byte {
 	return []byte(fmt.Sprintf("%s%s:%d", RawPriceFeedPrefix, assetCode, blockHeight))
 }
+
+// Get a key to store asset code for price info VM resource path
+func GetPriceInfoPathKey(path []byte) []byte {
+	return append([]byte(PriceInfoPathPrefix), path...)
+}
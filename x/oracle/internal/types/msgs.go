@@ -77,6 +77,7 @@ type MsgAddOracle struct {
 	Oracle  sdk.AccAddress `json:"oracle" yaml:"oracle"`
 	Nominee sdk.AccAddress `json:"nominee" yaml:"nominee"`
 	Denom   string         `json:"denom" yaml:"denom"`
+	Weight  uint32         `json:"weight" yaml:"weight"` // Used by weighted median aggregation
 }
 
 // MsgAddOracle creates a new add oracle message
@@ -84,11 +85,13 @@ func NewMsgAddOracle(
 	nominee sdk.AccAddress,
 	denom string,
 	oracle sdk.AccAddress,
+	weight uint32,
 ) MsgAddOracle {
 	return MsgAddOracle{
 		Oracle:  oracle,
 		Denom:   denom,
 		Nominee: nominee,
+		Weight:  weight,
 	}
 }
 
@@ -102,6 +102,9 @@ func (p Params) Validate() error {
 		if err := assetCodeFilter(asset.AssetCode); err != nil {
 			return fmt.Errorf("invalid asset %q: %w", asset.String(), err)
 		}
+		if err := asset.ValidateAggregation(); err != nil {
+			return fmt.Errorf("invalid asset %q: %w", asset.AssetCode, err)
+		}
 	}
 
 	for i, nominee := range p.Nominees {
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -9,8 +10,20 @@ import (
 
 const (
 	PriceBytesLimit = 8
+
+	// Price info VM synthetic resource identifier (Oracle::PriceInfo<Base, Quote>).
+	PriceInfoModule = "Oracle"
+	PriceInfoName   = "PriceInfo"
+	// Asset code base and quote currencies delimiter.
+	AssetCodeDelimiter = "_"
 )
 
+// Price info VM resource (provided by DS middleware): price and its ReceivedAt timestamp in seconds.
+type PriceInfo struct {
+	Price      uint64
+	ReceivedAt uint64
+}
+
 // implement fmt.Stringer
 func (a PendingPriceAsset) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`AssetCode: %s`, a.AssetCode))
@@ -30,6 +43,7 @@ func ValidateAddress(address string) (sdk.AccAddress, error) {
 	return oracle, nil
 }
 
+// ParseOracles parses comma separated oracles list, each oracle is an address with optional weight ("address:weight").
 func ParseOracles(addresses string) (Oracles, error) {
 	res := make([]Oracle, 0)
 	for _, address := range strings.Split(addresses, ",") {
@@ -37,12 +51,23 @@ func ParseOracles(addresses string) (Oracles, error) {
 		if len(address) == 0 {
 			continue
 		}
+
+		weight := uint64(0)
+		if sepIdx := strings.Index(address, ":"); sepIdx >= 0 {
+			var err error
+			if weight, err = strconv.ParseUint(address[sepIdx+1:], 10, 32); err != nil {
+				return nil, fmt.Errorf("oracle %q weight: %w", address, err)
+			}
+			address = address[:sepIdx]
+		}
+
 		oracleAddress, err := ValidateAddress(address)
 		if err != nil {
 			return nil, err
 		}
 
 		oracle := NewOracle(oracleAddress)
+		oracle.Weight = uint32(weight)
 
 		res = append(res, oracle)
 	}
@@ -9,14 +9,20 @@ import (
 )
 
 const (
-	ModuleName = types.ModuleName
-	StoreKey   = types.StoreKey
+	ModuleName        = types.ModuleName
+	StoreKey          = types.StoreKey
+	DefaultParamspace = types.DefaultParamspace
 )
 
 type (
 	Keeper           = keeper.Keeper
 	MsgDeployModule  = types.MsgDeployModule
 	MsgExecuteScript = types.MsgExecuteScript
+	MsgUpgradeModule = types.MsgUpgradeModule
+	MsgUpdateParams  = types.MsgUpdateParams
+	MsgExecuteBatch  = types.MsgExecuteBatch
+	BatchContract    = types.BatchContract
+	Params           = types.Params
 
 	VMServer                     = vm_grpc.VMServiceServer
 	UnimplementedVMServiceServer = vm_grpc.UnimplementedVMServiceServer
@@ -25,13 +31,40 @@ type (
 	QueryAccessPath = types.QueryAccessPath
 	QueryValueResp  = types.QueryValueResp
 
+	QueryResources     = types.QueryResources
+	QueryResource      = types.QueryResource
+	QueryResourcesResp = types.QueryResourcesResp
+
+	QueryCallScriptResp = types.QueryCallScriptResp
+	QueryModuleCodeResp = types.QueryModuleCodeResp
+	ModuleMetadata      = types.ModuleMetadata
+	ModulesMetadata     = types.ModulesMetadata
+	ValueLayout         = types.ValueLayout
+
 	CurrentTimestamp = middlewares.CurrentTimestamp
 	BlockHeader      = middlewares.BlockHeader
+	ChainInfo        = middlewares.ChainInfo
+	TxInfo           = middlewares.TxInfo
+	Proposer         = middlewares.Proposer
+	ProposerResolver = middlewares.ProposerResolver
 )
 
 var (
 	NewKeeper               = keeper.NewKeeper
+	NewKeyTable             = keeper.NewKeyTable
+	NewParams               = types.NewParams
+	DefaultParams           = types.DefaultParams
+	NewMsgUpdateParams      = types.NewMsgUpdateParams
+	NewMsgExecuteBatch      = types.NewMsgExecuteBatch
+	NewBatchModule          = types.NewBatchModule
+	NewBatchScript          = types.NewBatchScript
 	RegisterVMServiceServer = vm_grpc.RegisterVMServiceServer
+	RegisterStructLayout    = types.RegisterStructLayout
+	RegisterResourceLayout  = types.RegisterResourceLayout
+	DecodeLCS               = types.DecodeLCS
+	NewChainMiddleware      = middlewares.NewChainMiddleware
+	NewTxMiddleware         = middlewares.NewTxMiddleware
+	NewProposerMiddleware   = middlewares.NewProposerMiddleware
 
 	ErrVMCrashed = types.ErrVMCrashed
 )
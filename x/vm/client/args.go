@@ -0,0 +1,226 @@
+package client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/OneOfOne/xxhash"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+
+	"github.com/dfinance/dnode/x/vm/internal/types"
+)
+
+const (
+	FlagArgsFile      = "args-file"
+	FlagArgsFileUsage = "path to typed script arguments JSON file: [{\"type\": \"U64\", \"value\": \"100\"}, {\"type\": \"ByteArray\", \"value\": [1, 2]}]"
+)
+
+// Typed script argument (--args-file / REST request).
+// Only VM supported argument types are accepted: vectors other than vector<u8> (ByteArray) and structs are rejected.
+type ScriptArgJSON struct {
+	Type  string          `json:"type" example:"U64"`         // VM type tag: U8, U64, U128, Bool, Address, ByteArray
+	Value json.RawMessage `json:"value" swaggertype:"object"` // string, number, bool or bytes array (ByteArray)
+}
+
+// Convert string arguments to script arguments using types extracted from script signature.
+func ParseScriptArguments(argTypes []vm_grpc.VMTypeTag, args []string) ([]types.ScriptArg, error) {
+	if err := checkArgumentsCount(argTypes, len(args)); err != nil {
+		return nil, err
+	}
+
+	scriptArgs := make([]types.ScriptArg, len(args))
+	for i, arg := range args {
+		scriptArg, err := parseScriptArgument(argTypes[i], arg)
+		if err != nil {
+			return nil, err
+		}
+		scriptArgs[i] = scriptArg
+	}
+
+	if len(scriptArgs) == 0 {
+		scriptArgs = nil
+	}
+
+	return scriptArgs, nil
+}
+
+// Convert typed JSON arguments to script arguments, arguments types are validated against script signature.
+func ParseScriptArgumentsJSON(argTypes []vm_grpc.VMTypeTag, args []ScriptArgJSON) ([]types.ScriptArg, error) {
+	if err := checkArgumentsCount(argTypes, len(args)); err != nil {
+		return nil, err
+	}
+
+	scriptArgs := make([]types.ScriptArg, len(args))
+	for i, arg := range args {
+		argType, err := types.GetVMTypeByString(arg.Type)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+
+		if argType != argTypes[i] {
+			return nil, fmt.Errorf("argument %d: type mismatch, expected %s, got %s", i, types.VMTypeToStringPanic(argTypes[i]), arg.Type)
+		}
+
+		scriptArg, err := parseScriptArgumentJSON(argType, arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		scriptArgs[i] = scriptArg
+	}
+
+	if len(scriptArgs) == 0 {
+		scriptArgs = nil
+	}
+
+	return scriptArgs, nil
+}
+
+// Parse typed JSON arguments file content.
+func ParseScriptArgumentsFile(argTypes []vm_grpc.VMTypeTag, bz []byte) ([]types.ScriptArg, error) {
+	var args []ScriptArgJSON
+	if err := json.Unmarshal(bz, &args); err != nil {
+		return nil, fmt.Errorf("can't parse arguments JSON: %w", err)
+	}
+
+	return ParseScriptArgumentsJSON(argTypes, args)
+}
+
+// Check arguments count matches script signature.
+func checkArgumentsCount(argTypes []vm_grpc.VMTypeTag, count int) error {
+	if len(argTypes) < count {
+		return fmt.Errorf("arguments amount is not enough to call script, too many arguments, expected %d", len(argTypes))
+	}
+
+	if len(argTypes) > count {
+		return fmt.Errorf("arguments amount is not enough to call script, too few arguments, expected %d", len(argTypes))
+	}
+
+	return nil
+}
+
+// Convert typed JSON argument to script argument.
+func parseScriptArgumentJSON(argType vm_grpc.VMTypeTag, arg ScriptArgJSON) (types.ScriptArg, error) {
+	decoder := json.NewDecoder(bytes.NewReader(arg.Value))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return types.ScriptArg{}, fmt.Errorf("can't parse value: %w", err)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return parseScriptArgument(argType, v)
+
+	case json.Number:
+		return parseScriptArgument(argType, v.String())
+
+	case bool:
+		return parseScriptArgument(argType, strconv.FormatBool(v))
+	}
+
+	switch argType {
+	case vm_grpc.VMTypeTag_ByteArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			return types.ScriptArg{}, fmt.Errorf("ByteArray value must be string or bytes array")
+		}
+
+		bz := make([]byte, 0, len(items))
+		for j, item := range items {
+			b, err := types.EncodeLCS(item, types.NewLayout(types.LayoutU8))
+			if err != nil {
+				return types.ScriptArg{}, fmt.Errorf("ByteArray value item %d: %w", j, err)
+			}
+			bz = append(bz, b...)
+		}
+
+		return types.NewScriptArg(fmt.Sprintf("x\"%s\"", hex.EncodeToString(bz)), argType), nil
+
+	default:
+		return types.ScriptArg{}, fmt.Errorf("value type %T is not supported for %s", value, types.VMTypeToStringPanic(argType))
+	}
+}
+
+// Convert string argument to script argument using type tag.
+func parseScriptArgument(argType vm_grpc.VMTypeTag, arg string) (types.ScriptArg, error) {
+	switch argType {
+	case vm_grpc.VMTypeTag_ByteArray:
+		// trying to parse hex
+		_, err := hex.DecodeString(arg)
+		if err != nil {
+			// if not success, just convert string to hex.
+			return types.NewScriptArg(fmt.Sprintf("x\"%s\"", hex.EncodeToString([]byte(arg))), argType), nil
+		}
+
+		// otherwise just use hex.
+		return types.NewScriptArg(fmt.Sprintf("x\"%s\"", arg), argType), nil
+
+	case vm_grpc.VMTypeTag_U8, vm_grpc.VMTypeTag_U64, vm_grpc.VMTypeTag_U128:
+		if len(arg) > 0 && arg[0] == '#' {
+			// try to convert to xxhash
+			seed := xxhash.NewS64(0)
+
+			if len(arg) < 2 {
+				return types.ScriptArg{}, fmt.Errorf("incorrect format for xxHash argument (prefixed #) %q", arg)
+			}
+
+			_, err := seed.WriteString(strings.ToLower(arg[1:]))
+			if err != nil {
+				return types.ScriptArg{}, fmt.Errorf("can't format to xxHash argument %q (format happens because of '#' prefix)", arg)
+			}
+
+			arg = strconv.FormatUint(seed.Sum64(), 10)
+		}
+
+		n, isOk := sdk.NewIntFromString(arg)
+
+		if !isOk {
+			return types.ScriptArg{}, fmt.Errorf("%s is not a unsigned number (max is unsigned 256), wrong argument type, must be: %s", arg, types.VMTypeToStringPanic(argType))
+		}
+
+		switch argType {
+		case vm_grpc.VMTypeTag_U8:
+			if n.BigInt().BitLen() > 8 {
+				return types.ScriptArg{}, fmt.Errorf("argument %s must be U8, current bit length is %d, overflow", arg, n.BigInt().BitLen())
+			}
+
+		case vm_grpc.VMTypeTag_U64:
+			if n.BigInt().BitLen() > 64 {
+				return types.ScriptArg{}, fmt.Errorf("argument %s must be U64, current bit length is %d, overflow", arg, n.BigInt().BitLen())
+			}
+
+		case vm_grpc.VMTypeTag_U128:
+			if n.BigInt().BitLen() > 128 {
+				return types.ScriptArg{}, fmt.Errorf("argument %s must be U128, current bit length is %d, overflow", arg, n.BigInt().BitLen())
+			}
+		}
+
+		return types.NewScriptArg(arg, argType), nil
+
+	case vm_grpc.VMTypeTag_Address:
+		// validate address
+		if _, err := sdk.AccAddressFromBech32(arg); err != nil {
+			return types.ScriptArg{}, fmt.Errorf("can't parse address argument %s, check address and try again: %s", arg, err.Error())
+		}
+
+		return types.NewScriptArg(arg, argType), nil
+
+	case vm_grpc.VMTypeTag_Bool:
+		if arg != "true" && arg != "false" {
+			return types.ScriptArg{}, fmt.Errorf("%s argument must be bool, means \"true\" or \"false\"", arg)
+		}
+
+		return types.NewScriptArg(arg, argType), nil
+
+	default:
+		// VM accepts only primitive arguments and vector<u8> (ByteArray), other values encoding is unknown.
+		typeStr, _ := types.VMTypeToString(argType)
+		return types.ScriptArg{}, fmt.Errorf("%s (%d) script argument type is not supported by VM", typeStr, argType)
+	}
+}
@@ -0,0 +1,90 @@
+// +build unit
+
+package client
+
+import (
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dfinance/dnode/x/vm/internal/types"
+)
+
+// Test string script arguments parsing.
+func TestParseScriptArguments(t *testing.T) {
+	t.Parallel()
+
+	addr := sdk.AccAddress(make([]byte, sdk.AddrLen))
+	argTypes := []vm_grpc.VMTypeTag{
+		vm_grpc.VMTypeTag_U8,
+		vm_grpc.VMTypeTag_U128,
+		vm_grpc.VMTypeTag_Bool,
+		vm_grpc.VMTypeTag_Address,
+		vm_grpc.VMTypeTag_ByteArray,
+	}
+
+	args, err := ParseScriptArguments(argTypes, []string{"255", "#dfi", "true", addr.String(), "hello"})
+	require.NoError(t, err)
+	require.Equal(t, []types.ScriptArg{
+		types.NewScriptArg("255", vm_grpc.VMTypeTag_U8),
+		types.NewScriptArg(args[1].Value, vm_grpc.VMTypeTag_U128),
+		types.NewScriptArg("true", vm_grpc.VMTypeTag_Bool),
+		types.NewScriptArg(addr.String(), vm_grpc.VMTypeTag_Address),
+		types.NewScriptArg(`x"`+hex.EncodeToString([]byte("hello"))+`"`, vm_grpc.VMTypeTag_ByteArray),
+	}, args)
+	require.NotEqual(t, "#dfi", args[1].Value)
+
+	// count mismatch
+	_, err = ParseScriptArguments(argTypes[:1], []string{"1", "2"})
+	require.Error(t, err)
+
+	// overflow
+	_, err = ParseScriptArguments(argTypes[:1], []string{"256"})
+	require.Error(t, err)
+
+	// struct is not supported by VM
+	_, err = ParseScriptArguments([]vm_grpc.VMTypeTag{vm_grpc.VMTypeTag_Struct}, []string{"0x0a00"})
+	require.Error(t, err)
+}
+
+// Test typed JSON script arguments parsing.
+func TestParseScriptArgumentsFile(t *testing.T) {
+	t.Parallel()
+
+	argTypes := []vm_grpc.VMTypeTag{
+		vm_grpc.VMTypeTag_U64,
+		vm_grpc.VMTypeTag_Bool,
+		vm_grpc.VMTypeTag_ByteArray,
+		vm_grpc.VMTypeTag_ByteArray,
+	}
+
+	args, err := ParseScriptArgumentsFile(argTypes, []byte(`[
+		{"type": "U64", "value": 100},
+		{"type": "Bool", "value": false},
+		{"type": "ByteArray", "value": [1, 2, 255]},
+		{"type": "ByteArray", "value": "0a0b"}
+	]`))
+	require.NoError(t, err)
+	require.Equal(t, []types.ScriptArg{
+		types.NewScriptArg("100", vm_grpc.VMTypeTag_U64),
+		types.NewScriptArg("false", vm_grpc.VMTypeTag_Bool),
+		types.NewScriptArg(`x"0102ff"`, vm_grpc.VMTypeTag_ByteArray),
+		types.NewScriptArg(`x"0a0b"`, vm_grpc.VMTypeTag_ByteArray),
+	}, args)
+
+	// type mismatch with signature
+	_, err = ParseScriptArgumentsFile(argTypes[:1], []byte(`[{"type": "U8", "value": 1}]`))
+	require.Error(t, err)
+
+	// struct / vector values are not supported by VM
+	structTypes := []vm_grpc.VMTypeTag{vm_grpc.VMTypeTag_Struct}
+	_, err = ParseScriptArgumentsFile(structTypes, []byte(`[{"type": "Struct", "value": {"value": "1"}}]`))
+	require.Error(t, err)
+	_, err = ParseScriptArgumentsFile(structTypes, []byte(`[{"type": "Struct", "value": [1, 2]}]`))
+	require.Error(t, err)
+	_, err = ParseScriptArgumentsFile(argTypes[:1], []byte(`[{"type": "U64", "value": [1, 2]}]`))
+	require.Error(t, err)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdkClient "github.com/cosmos/cosmos-sdk/client/flags"
@@ -21,6 +23,14 @@ import (
 	"github.com/dfinance/dnode/x/vm/internal/types"
 )
 
+const (
+	FlagViewGas = "gas"
+	FlagPage    = "page"
+	FlagLimit   = "limit"
+	FlagDecode  = "decode"
+	FlagLayout  = "layout"
+)
+
 // Returns get commands for this module.
 func GetQueryCmd(cdc *amino.Codec) *cobra.Command {
 	queryCmd := &cobra.Command{
@@ -37,10 +47,31 @@ func GetQueryCmd(cdc *amino.Codec) *cobra.Command {
 		cmd.Flags().String(vmClient.FlagOutput, "", "--to-file ./compiled.mv")
 	}
 
+	compilePackageCmd := sdkClient.GetCommands(CompilePackage(types.ModuleName, cdc))[0]
+	compilePackageCmd.Flags().String(vmClient.FlagCompilerAddr, vmClient.DefaultCompilerAddr, vmClient.FlagCompilerUsage)
+	compilePackageCmd.Flags().String(vmClient.FlagOutputDir, "", "output directory (default is [dir]/"+vmClient.DefaultOutputDir+")")
+
+	callScriptCmd := sdkClient.GetCommands(CallScript(types.ModuleName, cdc))[0]
+	callScriptCmd.Flags().String(vmClient.FlagCompilerAddr, vmClient.DefaultCompilerAddr, vmClient.FlagCompilerUsage)
+	callScriptCmd.Flags().String(vmClient.FlagArgsFile, "", vmClient.FlagArgsFileUsage)
+	callScriptCmd.Flags().Uint64(FlagViewGas, types.DefaultViewGasLimit, "gas limit for read-only script execution")
+
 	commands := sdkClient.GetCommands(
 		GetData(types.ModuleName, cdc),
+		GetModules(types.ModuleName, cdc),
+		GetModule(types.ModuleName, cdc),
+		GetModuleCode(types.ModuleName, cdc),
+		GetParams(types.ModuleName, cdc),
 	)
+	resourcesCmd := sdkClient.GetCommands(GetResources(types.ModuleName, cdc))[0]
+	resourcesCmd.Flags().Int(FlagPage, 1, "page number")
+	resourcesCmd.Flags().Int(FlagLimit, types.DefaultResourcesLimit, "resources per page")
+	resourcesCmd.Flags().Bool(FlagDecode, false, "decode values using detected known resources layouts")
+	resourcesCmd.Flags().String(FlagLayout, "", "decode all values using layout, "+vmClient.LayoutUsage()+" (or path to layout JSON file)")
+
+	commands = append(commands, callScriptCmd, resourcesCmd)
 	commands = append(commands, compileCommands...)
+	commands = append(commands, compilePackageCmd)
 
 	queryCmd.AddCommand(commands...)
 
@@ -84,13 +115,15 @@ func saveOutput(bytecode []byte, cdc *codec.Codec) error {
 	return nil
 }
 
-// Get data from data source by access path.
+// Get data from data source by access path, optionally decoding it using layout.
 func GetData(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:     "get-data [address] [path]",
-		Short:   "get-data from data source storage by address and path, address could be bech32 or hex",
-		Example: "get-data wallet1jk4ld0uu6wdrj9t8u3gghm9jt583hxx7xp7he8 0019b01c2cf3c2160a43e4dcad70e3e5d18151cc38de7a1d1067c6031bfa0ae4d9",
-		Args:    cobra.ExactArgs(2),
+		Use:   "get-data [address] [path] [layout]",
+		Short: "get-data from data source storage by address and path, address could be bech32 or hex",
+		Long: "get-data from data source storage by address and path, address could be bech32 or hex.\n" +
+			"Optional layout decodes LCS value to JSON, " + vmClient.LayoutUsage() + " (or path to layout JSON file).",
+		Example: "get-data wallet1jk4ld0uu6wdrj9t8u3gghm9jt583hxx7xp7he8 0019b01c2cf3c2160a43e4dcad70e3e5d18151cc38de7a1d1067c6031bfa0ae4d9 BalanceResource",
+		Args:    cobra.RangeArgs(2, 3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
@@ -112,6 +145,24 @@ func GetData(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			var layout *types.ValueLayout
+			if len(args) > 2 {
+				rawLayout := args[2]
+				if _, err := os.Stat(rawLayout); err == nil {
+					bz, err := ioutil.ReadFile(rawLayout)
+					if err != nil {
+						return fmt.Errorf("%s argument %q: %w", "layout", rawLayout, err)
+					}
+					rawLayout = string(bz)
+				}
+
+				parsedLayout, err := vmClient.ParseValueLayout(rawLayout)
+				if err != nil {
+					return fmt.Errorf("%s argument %q: %w", "layout", args[2], err)
+				}
+				layout = &parsedLayout
+			}
+
 			bz, err := cdc.MarshalJSON(types.QueryAccessPath{
 				Address: address,
 				Path:    path,
@@ -129,6 +180,264 @@ func GetData(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			}
 
 			out := types.QueryValueResp{Value: hex.EncodeToString(res)}
+			if layout != nil && len(res) > 0 {
+				if out.Decoded, err = vmClient.DecodeValue(res, *layout); err != nil {
+					return err
+				}
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// Get resources stored under address, optionally decoding them.
+func GetResources(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resources [address]",
+		Short: "get resources (access paths and values) stored under address, address could be bech32 or hex",
+		Long: "get resources (access paths and values) stored under address, address could be bech32 or hex.\n" +
+			"Values could be decoded with detected known resources layouts (--decode) or with provided layout (--layout).",
+		Example: "resources wallet1jk4ld0uu6wdrj9t8u3gghm9jt583hxx7xp7he8 --page 1 --limit 10 --decode",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			address, err := parseVMAddress(args[0])
+			if err != nil {
+				return fmt.Errorf("%s argument %q: %w", "address", args[0], err)
+			}
+
+			var layout *types.ValueLayout
+			layoutName := viper.GetString(FlagLayout)
+			if layoutName != "" {
+				rawLayout := layoutName
+				if _, err := os.Stat(rawLayout); err == nil {
+					bz, err := ioutil.ReadFile(rawLayout)
+					if err != nil {
+						return fmt.Errorf("%s flag %q: %w", FlagLayout, rawLayout, err)
+					}
+					rawLayout = string(bz)
+				}
+
+				parsedLayout, err := vmClient.ParseValueLayout(rawLayout)
+				if err != nil {
+					return fmt.Errorf("%s flag %q: %w", FlagLayout, layoutName, err)
+				}
+				layout = &parsedLayout
+			}
+
+			bz, err := cdc.MarshalJSON(types.QueryResources{
+				Address: address,
+				Page:    viper.GetInt(FlagPage),
+				Limit:   viper.GetInt(FlagLimit),
+			})
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/resources", queryRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResourcesResp
+			cdc.MustUnmarshalJSON(res, &out)
+
+			if layout != nil || viper.GetBool(FlagDecode) {
+				vmClient.DecodeResources(out.Resources, layoutName, layout)
+			}
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// Parse VM address: libra hex or bech32.
+func parseVMAddress(rawAddress string) ([]byte, error) {
+	if address, err := hex.DecodeString(rawAddress); err == nil {
+		return address, nil
+	}
+
+	address, err := sdk.AccAddressFromBech32(rawAddress)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse address, it could be libra hex or bech32")
+	}
+
+	return common_vm.Bech32ToLibra(address), nil
+}
+
+// Get modules deployed by address.
+func GetModules(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "modules [address]",
+		Short:   "get list of modules deployed by address",
+		Example: "modules wallet1jk4ld0uu6wdrj9t8u3gghm9jt583hxx7xp7he8",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			address, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return fmt.Errorf("%s argument %q: %w", "address", args[0], err)
+			}
+
+			bz, err := cdc.MarshalJSON(types.QueryModules{Address: address})
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/modules", queryRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.ModulesMetadata
+			cdc.MustUnmarshalJSON(res, &out)
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// Get deployed module metadata.
+func GetModule(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "module [address] [name]",
+		Short:   "get deployed module metadata (deployer, height, code hash)",
+		Example: "module wallet1jk4ld0uu6wdrj9t8u3gghm9jt583hxx7xp7he8 MyModule",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			address, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return fmt.Errorf("%s argument %q: %w", "address", args[0], err)
+			}
+
+			bz, err := cdc.MarshalJSON(types.QueryModule{Address: address, Name: args[1]})
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/module", queryRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.ModuleMetadata
+			cdc.MustUnmarshalJSON(res, &out)
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// Get deployed module bytecode.
+func GetModuleCode(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "module-code [address] [name]",
+		Short:   "get deployed module bytecode",
+		Example: "module-code wallet1jk4ld0uu6wdrj9t8u3gghm9jt583hxx7xp7he8 MyModule",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			address, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return fmt.Errorf("%s argument %q: %w", "address", args[0], err)
+			}
+
+			bz, err := cdc.MarshalJSON(types.QueryModule{Address: address, Name: args[1]})
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/module_code", queryRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryModuleCodeResp
+			cdc.MustUnmarshalJSON(res, &out)
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// Get VM params.
+func GetParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "params",
+		Short:   "get VM params: gas unit price, max gas per script / module, max bytecode size",
+		Example: "params",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/params", queryRoute), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.Params
+			cdc.MustUnmarshalJSON(res, &out)
+
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// Execute script in read-only mode (write set is not applied) at the current or provided (--height) block.
+func CallScript(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "call-script [address] [compiledMoveFile] [arg1,arg2,arg3,...]",
+		Short:   "execute Move script in read-only mode and print its events, write set and gas used",
+		Example: "call-script wallet1jk4ld0uu6wdrj9t8u3gghm9jt583hxx7xp7he8 ./script.move.json 100 true --height 10 --gas 500000",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			compilerAddr := viper.GetString(vmClient.FlagCompilerAddr)
+
+			address, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return fmt.Errorf("%s argument %q: %w", "address", args[0], err)
+			}
+
+			mvFile, err := GetMVFromFile(args[1])
+			if err != nil {
+				return fmt.Errorf("%s argument %q: %w", "mvFile", args[1], err)
+			}
+
+			code, err := hex.DecodeString(mvFile.Code)
+			if err != nil {
+				return err
+			}
+
+			// parsing arguments
+			scriptArgs, err := parseScriptArgs(compilerAddr, code, args[2:])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.QueryCallScript{
+				Address: address,
+				Script:  code,
+				Args:    scriptArgs,
+				Gas:     viper.GetUint64(FlagViewGas),
+			})
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/call_script", queryRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryCallScriptResp
+			cdc.MustUnmarshalJSON(res, &out)
 
 			return cliCtx.PrintOutput(out)
 		},
@@ -224,3 +533,73 @@ func CompileModule(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// Compile Move package directory (modules and scripts) in dependency order.
+func CompilePackage(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "compile-package [dir] [account]",
+		Short:   "compile modules and scripts from directory in dependency order, writes MoveFiles and manifest to output directory",
+		Example: "compile-package ./contracts wallet196udj7s83uaw2u4safcrvgyqc0sc3flxuherp6 --output-dir ./contracts/build",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			compilerAddr := viper.GetString(vmClient.FlagCompilerAddr)
+
+			addr, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return fmt.Errorf("error during parsing address %s: %v", args[1], err)
+			}
+
+			outputDir := viper.GetString(vmClient.FlagOutputDir)
+			if outputDir == "" {
+				outputDir = filepath.Join(args[0], vmClient.DefaultOutputDir)
+			}
+
+			// dependencies are fetched from chain state
+			fetcher := func(address sdk.AccAddress, name string) ([]byte, error) {
+				bz, err := cdc.MarshalJSON(types.QueryModule{Address: address, Name: name})
+				if err != nil {
+					return nil, err
+				}
+
+				res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/module_code", queryRoute), bz)
+				if err != nil {
+					return nil, err
+				}
+
+				var out types.QueryModuleCodeResp
+				if err := cdc.UnmarshalJSON(res, &out); err != nil {
+					return nil, err
+				}
+
+				return hex.DecodeString(out.Code)
+			}
+
+			manifest, err := vmClient.CompilePackage(compilerAddr, addr, args[0], fetcher)
+			if err != nil {
+				return err
+			}
+
+			if err := vmClient.WritePackage(&manifest, outputDir); err != nil {
+				return fmt.Errorf("error during compiled package output: %v", err)
+			}
+
+			for _, output := range manifest.Outputs {
+				fmt.Printf("%s %s: %s\n", output.Type, output.Name, output.Output)
+			}
+			fmt.Printf("Manifest saved to file %s\n", filepath.Join(outputDir, vmClient.PackageManifestName))
+
+			if len(manifest.Pending) > 0 {
+				for _, pending := range manifest.Pending {
+					fmt.Printf("%s %s: not compiled, modules are not deployed: %s\n", pending.Type, pending.Name, strings.Join(pending.Missing, ", "))
+				}
+				fmt.Println("Deploy compiled modules and compile package again.")
+
+				return nil
+			}
+			fmt.Println("Compilation successful done.")
+
+			return nil
+		},
+	}
+}
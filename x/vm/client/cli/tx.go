@@ -8,23 +8,25 @@ import (
 	"io/ioutil"
 	"os"
 	"strconv"
-	"strings"
 
-	"github.com/OneOfOne/xxhash"
 	cliBldrCtx "github.com/cosmos/cosmos-sdk/client/context"
 	sdkClient "github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	txBldrCtx "github.com/cosmos/cosmos-sdk/x/auth/types"
-	"github.com/dfinance/dvm-proto/go/vm_grpc"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	codec "github.com/tendermint/go-amino"
 
+	msMsg "github.com/dfinance/dnode/x/multisig/msgs"
 	vmClient "github.com/dfinance/dnode/x/vm/client"
 	"github.com/dfinance/dnode/x/vm/internal/types"
 )
 
+const (
+	FlagModulePolicy = "policy"
+)
+
 // GetTxCmd returns the transaction commands for this module.
 func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	txCmd := &cobra.Command{
@@ -37,12 +39,26 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	)
 	for _, cmd := range compileCommands {
 		cmd.Flags().String(vmClient.FlagCompilerAddr, vmClient.DefaultCompilerAddr, vmClient.FlagCompilerUsage)
+		cmd.Flags().String(vmClient.FlagArgsFile, "", vmClient.FlagArgsFileUsage)
 		txCmd.AddCommand(cmd)
 	}
 
-	commands := sdkClient.PostCommands(DeployContract(cdc))
+	batchCommands := sdkClient.PostCommands(
+		ExecuteBatch(cdc),
+	)
+	for _, cmd := range batchCommands {
+		cmd.Flags().String(vmClient.FlagCompilerAddr, vmClient.DefaultCompilerAddr, vmClient.FlagCompilerUsage)
+	}
+	compileCommands = append(compileCommands, batchCommands...)
+
+	commands := sdkClient.PostCommands(DeployContract(cdc), PostMsUpgradeModule(cdc))
+	for _, cmd := range commands {
+		cmd.Flags().String(FlagModulePolicy, "", fmt.Sprintf("module upgrade policy: %s, %s, %s", types.ModulePolicyImmutable, types.ModulePolicyOwner, types.ModulePolicyMultisig))
+	}
 	commands = append(commands, compileCommands...)
 
+	commands = append(commands, sdkClient.PostCommands(PostMsUpdateParams(cdc))...)
+
 	txCmd.AddCommand(commands...)
 
 	return txCmd
@@ -70,13 +86,43 @@ func GetMVFromFile(filePath string) (vmClient.MoveFile, error) {
 	return move, nil
 }
 
+// Parse script arguments from command args or typed arguments file (--args-file) using script signature.
+func parseScriptArgs(compilerAddr string, code []byte, args []string) ([]types.ScriptArg, error) {
+	argsFile := viper.GetString(vmClient.FlagArgsFile)
+	if argsFile != "" && len(args) > 0 {
+		return nil, fmt.Errorf("arguments could be provided via command args or via --%s, not both", vmClient.FlagArgsFile)
+	}
+
+	extractedArgs, err := vmClient.ExtractArguments(compilerAddr, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if argsFile == "" {
+		return vmClient.ParseScriptArguments(extractedArgs, args)
+	}
+
+	bz, err := ioutil.ReadFile(argsFile)
+	if err != nil {
+		return nil, fmt.Errorf("--%s %q: %w", vmClient.FlagArgsFile, argsFile, err)
+	}
+
+	scriptArgs, err := vmClient.ParseScriptArgumentsFile(extractedArgs, bz)
+	if err != nil {
+		return nil, fmt.Errorf("--%s %q: %w", vmClient.FlagArgsFile, argsFile, err)
+	}
+
+	return scriptArgs, nil
+}
+
 // Execute script contract.
 func ExecuteScript(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:     "execute-script [compiledMoveFile] [arg1,arg2,arg3,...] --from [account] --fees [dfiFee] --gas [gas]",
-		Short:   "execute Move script",
-		Example: "execute-script ./script.move.json wallet1jk4ld0uu6wdrj9t8u3gghm9jt583hxx7xp7he8 100 true \"my string\" \"68656c6c6f2c20776f726c6421\" #\"DFI_ETH\" --from my_account --fees 1dfi --gas 500000",
-		Args:    cobra.MinimumNArgs(1),
+		Use:   "execute-script [compiledMoveFile] [arg1,arg2,arg3,...] --from [account] --fees [dfiFee] --gas [gas|auto]",
+		Short: "execute Move script, use --gas auto (and --gas-adjustment) to estimate gas by tx simulation",
+		Example: "execute-script ./script.move.json wallet1jk4ld0uu6wdrj9t8u3gghm9jt583hxx7xp7he8 100 true \"my string\" \"68656c6c6f2c20776f726c6421\" #\"DFI_ETH\" --from my_account --fees 1dfi --gas 500000\n" +
+			"execute-script ./script.move.json --args-file ./args.json --from my_account --fees 1dfi --gas 500000",
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			compilerAddr := viper.GetString(vmClient.FlagCompilerAddr)
 
@@ -100,103 +146,115 @@ func ExecuteScript(cdc *codec.Codec) *cobra.Command {
 			}
 
 			// parsing arguments
-			parsedArgs := args[1:]
-			scriptArgs := make([]types.ScriptArg, len(parsedArgs))
-			extractedArgs, err := vmClient.ExtractArguments(compilerAddr, code)
+			scriptArgs, err := parseScriptArgs(compilerAddr, code, args[1:])
 			if err != nil {
 				return err
 			}
 
-			if len(extractedArgs) < len(parsedArgs) {
-				return fmt.Errorf("arguments amount is not enough to call script, too many arguments, expected %d", len(extractedArgs))
-			}
-
-			if len(extractedArgs) > len(parsedArgs) {
-				return fmt.Errorf("arguments amount is not enough to call script, too few arguments, expected %d", len(extractedArgs))
+			msg := types.NewMsgExecuteScript(cliCtx.GetFromAddress(), code, scriptArgs)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
 			}
 
-			for i, arg := range parsedArgs {
-				switch extractedArgs[i] {
-				case vm_grpc.VMTypeTag_ByteArray:
-					// trying to parse hex
-					_, err := hex.DecodeString(arg)
-					if err != nil {
-						// if not success, just convert string to hex.
-						scriptArgs[i] = types.NewScriptArg(fmt.Sprintf("x\"%s\"", hex.EncodeToString([]byte(arg))), extractedArgs[i])
-					} else {
-						// otherwise just use hex.
-						scriptArgs[i] = types.NewScriptArg(fmt.Sprintf("x\"%s\"", arg), extractedArgs[i])
-					}
-
-				case vm_grpc.VMTypeTag_Struct:
-					return fmt.Errorf("currently doesnt's support struct type as argument")
-
-				case vm_grpc.VMTypeTag_U8, vm_grpc.VMTypeTag_U64, vm_grpc.VMTypeTag_U128:
-					if arg[0] == '#' {
-						// try to convert to xxhash
-						seed := xxhash.NewS64(0)
+			cliCtx.WithOutput(os.Stdout)
 
-						if len(arg) < 2 {
-							return fmt.Errorf("incorrect format for xxHash argument (prefixed #) %q", arg)
-						}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
 
-						_, err := seed.WriteString(strings.ToLower(arg[1:]))
-						if err != nil {
-							return fmt.Errorf("can't format to xxHash argument %q (format happens because of '#' prefix)", arg)
-						}
+// Batch file item: compiled module or script file path and typed script arguments.
+type batchFileItem struct {
+	Module string                   `json:"module,omitempty"`
+	Script string                   `json:"script,omitempty"`
+	Args   []vmClient.ScriptArgJSON `json:"args,omitempty"`
+}
 
-						arg = strconv.FormatUint(seed.Sum64(), 10)
-					}
+// Read batch file and convert it to batch contracts (script arguments are validated using script signature).
+func getBatchFromFile(compilerAddr, filePath string) ([]types.BatchContract, error) {
+	bz, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
 
-					n, isOk := sdk.NewIntFromString(arg)
+	var items []batchFileItem
+	if err := json.Unmarshal(bz, &items); err != nil {
+		return nil, fmt.Errorf("can't parse batch JSON: %w", err)
+	}
 
-					if !isOk {
-						return fmt.Errorf("%s is not a unsigned number (max is unsigned 256), wrong argument type, must be: %s", arg, types.VMTypeToStringPanic(extractedArgs[i]))
-					}
+	contracts := make([]types.BatchContract, 0, len(items))
+	for i, item := range items {
+		if (item.Module == "") == (item.Script == "") {
+			return nil, fmt.Errorf("item %d: module or script file path should be provided", i)
+		}
+
+		mvFilePath := item.Module
+		if item.Script != "" {
+			mvFilePath = item.Script
+		}
+
+		mvFile, err := GetMVFromFile(mvFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %q: %w", i, mvFilePath, err)
+		}
+
+		code, err := hex.DecodeString(mvFile.Code)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %q: %w", i, mvFilePath, err)
+		}
+
+		if item.Module != "" {
+			if len(item.Args) > 0 {
+				return nil, fmt.Errorf("item %d: module can't have arguments", i)
+			}
 
-					switch extractedArgs[i] {
-					case vm_grpc.VMTypeTag_U8:
-						if n.BigInt().BitLen() > 8 {
-							return fmt.Errorf("argument %s must be U8, current bit length is %d, overflow", arg, n.BigInt().BitLen())
-						}
+			contracts = append(contracts, types.NewBatchModule(code))
+			continue
+		}
 
-					case vm_grpc.VMTypeTag_U64:
-						if n.BigInt().BitLen() > 64 {
-							return fmt.Errorf("argument %s must be U64, current bit length is %d, overflow", arg, n.BigInt().BitLen())
-						}
+		extractedArgs, err := vmClient.ExtractArguments(compilerAddr, code)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
 
-					case vm_grpc.VMTypeTag_U128:
-						if n.BigInt().BitLen() > 128 {
-							return fmt.Errorf("argument %s must be U128, current bit length is %d, overflow", arg, n.BigInt().BitLen())
-						}
-					}
+		scriptArgs, err := vmClient.ParseScriptArgumentsJSON(extractedArgs, item.Args)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
 
-					scriptArgs[i] = types.NewScriptArg(arg, extractedArgs[i])
+		contracts = append(contracts, types.NewBatchScript(code, scriptArgs))
+	}
 
-				case vm_grpc.VMTypeTag_Address:
-					// validate address
-					if _, err := sdk.AccAddressFromBech32(arg); err != nil {
-						return fmt.Errorf("can't parse address argument %s, check address and try again: %s", arg, err.Error())
-					}
+	return contracts, nil
+}
 
-					scriptArgs[i] = types.NewScriptArg(arg, extractedArgs[i])
+// Execute batch of modules / scripts cli TX command.
+func ExecuteBatch(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "execute-batch [batchFile] --from [account] --fees [dfiFee] --gas [gas|auto]",
+		Short: "deploy Move modules and execute Move scripts in one transaction (in order, atomically)",
+		Long: "Batch file contains JSON list of compiled modules / scripts files with typed script arguments:\n" +
+			"[{\"module\": \"./my_module.move.json\"}, {\"script\": \"./init.move.json\", \"args\": [{\"type\": \"U64\", \"value\": \"100\"}]}]",
+		Example: "execute-batch ./batch.json --from my_account --fees 1dfi --gas auto --gas-adjustment 1.5",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			compilerAddr := viper.GetString(vmClient.FlagCompilerAddr)
 
-				case vm_grpc.VMTypeTag_Bool:
-					if arg != "true" && arg != "false" {
-						return fmt.Errorf("%s argument must be bool, means \"true\" or \"false\"", arg)
-					}
-					scriptArgs[i] = types.NewScriptArg(arg, extractedArgs[i])
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := txBldrCtx.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := cliBldrCtx.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+			accGetter := txBldrCtx.NewAccountRetriever(cliCtx)
 
-				default:
-					scriptArgs[i] = types.NewScriptArg(arg, extractedArgs[i])
-				}
+			if err := accGetter.EnsureExists(cliCtx.FromAddress); err != nil {
+				return fmt.Errorf("provide correct parameter for --from flag: %v", err)
 			}
 
-			if len(scriptArgs) == 0 {
-				scriptArgs = nil
+			contracts, err := getBatchFromFile(compilerAddr, args[0])
+			if err != nil {
+				return fmt.Errorf("%s argument %q: %w", "batchFile", args[0], err)
 			}
 
-			msg := types.NewMsgExecuteScript(cliCtx.GetFromAddress(), code, scriptArgs)
+			msg := types.NewMsgExecuteBatch(cliCtx.GetFromAddress(), contracts...)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -211,9 +269,9 @@ func ExecuteScript(cdc *codec.Codec) *cobra.Command {
 // Deploy contract cli TX command.
 func DeployContract(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:     "deploy-module [compiledMoveFile] --from [account] --fees [dfiFee] --gas [gas]",
-		Short:   "deploy Move module",
-		Example: "deploy-module ./my_module.move.json --from my_account --fees 1dfi --gas 500000",
+		Use:     "deploy-module [compiledMoveFile] --from [account] --fees [dfiFee] --gas [gas|auto] --policy [policy]",
+		Short:   "deploy Move module, use --gas auto (and --gas-adjustment) to estimate gas by tx simulation",
+		Example: "deploy-module ./my_module.move.json --from my_account --fees 1dfi --gas auto --gas-adjustment 1.5",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
@@ -235,7 +293,95 @@ func DeployContract(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			msg := types.NewMsgDeployModule(cliCtx.GetFromAddress(), code)
+			msg := types.NewMsgDeployModule(cliCtx.GetFromAddress(), code).WithPolicy(viper.GetString(FlagModulePolicy))
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			cliCtx.WithOutput(os.Stdout)
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// Upgrade module (including privileged standard library modules) via multisignature call.
+func PostMsUpgradeModule(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "ms-upgrade-module [compiledMoveFile] [address] [uniqueID] --policy [policy]",
+		Short:   "deploy / upgrade module at address via multisignature, address could be bech32 or hex (zero address for standard library)",
+		Example: "ms-upgrade-module ./my_module.move.json 0000000000000000000000000000000000000000 upgrade_1 --from my_account --fees 1dfi",
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := txBldrCtx.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := cliBldrCtx.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+			accGetter := txBldrCtx.NewAccountRetriever(cliCtx)
+
+			if err := accGetter.EnsureExists(cliCtx.FromAddress); err != nil {
+				return fmt.Errorf("provide correct parameter for --from flag: %v", err)
+			}
+
+			mvFile, err := GetMVFromFile(args[0])
+			if err != nil {
+				return fmt.Errorf("%s argument %q: %w", "mvFile", args[0], err)
+			}
+
+			code, err := hex.DecodeString(mvFile.Code)
+			if err != nil {
+				return err
+			}
+
+			address, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				address, err = hex.DecodeString(args[1])
+				if err != nil || len(address) != sdk.AddrLen {
+					return fmt.Errorf("%s argument %q: should be bech32 or %d bytes hex", "address", args[1], sdk.AddrLen)
+				}
+			}
+
+			msUpgrade := types.NewMsgUpgradeModule(address, code, viper.GetString(FlagModulePolicy))
+			msg := msMsg.NewMsgSubmitCall(msUpgrade, args[2], cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			cliCtx.WithOutput(os.Stdout)
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// Update VM params via multisignature.
+func PostMsUpdateParams(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "ms-update-params [gasUnitPrice] [maxGasPerScript] [maxGasPerModule] [maxBytecodeSize] [uniqueID]",
+		Short:   "update VM params via multisignature",
+		Example: "ms-update-params 1 10000000 10000000 65536 vm_params_1 --from my_account --fees 1dfi",
+		Args:    cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := txBldrCtx.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := cliBldrCtx.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+			accGetter := txBldrCtx.NewAccountRetriever(cliCtx)
+
+			if err := accGetter.EnsureExists(cliCtx.FromAddress); err != nil {
+				return fmt.Errorf("provide correct parameter for --from flag: %v", err)
+			}
+
+			argNames := []string{"gasUnitPrice", "maxGasPerScript", "maxGasPerModule", "maxBytecodeSize"}
+			values := make([]uint64, len(argNames))
+			for i, argName := range argNames {
+				value, err := strconv.ParseUint(args[i], 10, 64)
+				if err != nil {
+					return fmt.Errorf("%s argument %q: %w", argName, args[i], err)
+				}
+				values[i] = value
+			}
+
+			msUpdate := types.NewMsgUpdateParams(types.NewParams(values[0], values[1], values[2], values[3]))
+			msg := msMsg.NewMsgSubmitCall(msUpdate, args[4], cliCtx.GetFromAddress())
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
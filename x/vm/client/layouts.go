@@ -0,0 +1,121 @@
+package client
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dfinance/dnode/x/common_vm"
+	"github.com/dfinance/dnode/x/vm/internal/types"
+)
+
+const (
+	FlagLayoutUsage = "value layout: known resource name (%s), registered struct tag (0x{address}::{module}::{name}) or layout JSON"
+)
+
+// Get value layout usage description with known resources names.
+func LayoutUsage() string {
+	return fmt.Sprintf(FlagLayoutUsage, strings.Join(types.GetResourceLayoutNames(), ", "))
+}
+
+// Parse value layout: known resource name, registered struct tag or layout JSON.
+func ParseValueLayout(arg string) (types.ValueLayout, error) {
+	arg = strings.TrimSpace(arg)
+
+	if layout, ok := types.GetResourceLayout(arg); ok {
+		return layout, nil
+	}
+
+	if strings.HasPrefix(arg, "0x") {
+		return parseStructTagLayout(arg)
+	}
+
+	var layout types.ValueLayout
+	if err := json.Unmarshal([]byte(arg), &layout); err != nil {
+		return types.ValueLayout{}, fmt.Errorf("unknown resource %q / invalid layout JSON: %w", arg, err)
+	}
+
+	if err := layout.Validate(); err != nil {
+		return types.ValueLayout{}, fmt.Errorf("invalid layout: %w", err)
+	}
+
+	return layout, nil
+}
+
+// Decode LCS encoded value to JSON using layout.
+func DecodeValue(value []byte, layout types.ValueLayout) (json.RawMessage, error) {
+	decoded, err := types.DecodeLCS(value, layout)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode value using layout %s: %w", layout, err)
+	}
+
+	return json.Marshal(decoded)
+}
+
+// Detect registered resource layout decoding value, only a single matching layout is accepted.
+func DetectValueLayout(value []byte) (string, types.ValueLayout, bool) {
+	var name string
+	var layout types.ValueLayout
+	matches := 0
+
+	for _, resName := range types.GetResourceLayoutNames() {
+		resLayout, _ := types.GetResourceLayout(resName)
+		if _, err := types.DecodeLCS(value, resLayout); err == nil {
+			name, layout = resName, resLayout
+			matches++
+		}
+	}
+
+	return name, layout, matches == 1
+}
+
+// Decode resources values using layout or detected known resources layouts (if layout is nil).
+// Values that can't be decoded are kept as is.
+func DecodeResources(resources []types.QueryResource, layoutName string, layout *types.ValueLayout) {
+	for i := range resources {
+		value, err := hex.DecodeString(resources[i].Value)
+		if err != nil || len(value) == 0 {
+			continue
+		}
+
+		name, resLayout := layoutName, layout
+		if resLayout == nil {
+			detectedName, detectedLayout, ok := DetectValueLayout(value)
+			if !ok {
+				continue
+			}
+			name, resLayout = detectedName, &detectedLayout
+		}
+
+		if decoded, err := DecodeValue(value, *resLayout); err == nil {
+			resources[i].Layout, resources[i].Decoded = name, decoded
+		}
+	}
+}
+
+// Get registered layout by struct tag string: 0x{address}::{module}::{name}.
+func parseStructTagLayout(structTag string) (types.ValueLayout, error) {
+	parts := strings.Split(structTag, "::")
+	if len(parts) != 3 {
+		return types.ValueLayout{}, fmt.Errorf("struct tag %q: invalid format, expected 0x{address}::{module}::{name}", structTag)
+	}
+
+	// short addresses (0x0) are left padded with zeros.
+	rawAddress := strings.TrimPrefix(parts[0], "0x")
+	if len(rawAddress) > common_vm.VMAddressLength*2 {
+		return types.ValueLayout{}, fmt.Errorf("struct tag %q: address is too long", structTag)
+	}
+
+	address, err := hex.DecodeString(strings.Repeat("0", common_vm.VMAddressLength*2-len(rawAddress)) + rawAddress)
+	if err != nil {
+		return types.ValueLayout{}, fmt.Errorf("struct tag %q: address: %w", structTag, err)
+	}
+
+	layout, ok := types.GetStructLayout(address, parts[1], parts[2])
+	if !ok {
+		return types.ValueLayout{}, fmt.Errorf("struct tag %q: layout not registered", structTag)
+	}
+
+	return layout, nil
+}
@@ -0,0 +1,56 @@
+// +build unit
+
+package client
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dfinance/dnode/x/vm/internal/types"
+	"github.com/dfinance/dnode/x/vmauth"
+)
+
+// Test resources values decoding with detected and provided layouts.
+func TestDecodeResources(t *testing.T) {
+	t.Parallel()
+
+	balance := vmauth.BalanceToBytes(vmauth.BalanceResource{Value: big.NewInt(100)})
+
+	name, _, ok := DetectValueLayout(balance)
+	require.True(t, ok)
+	require.Equal(t, "BalanceResource", name)
+
+	_, _, ok = DetectValueLayout([]byte{0x1})
+	require.False(t, ok)
+
+	// detected layouts
+	{
+		resources := []types.QueryResource{
+			{Path: "01", Value: hex.EncodeToString(balance)},
+			{Path: "02", Value: "01"},
+		}
+		DecodeResources(resources, "", nil)
+
+		require.Equal(t, "BalanceResource", resources[0].Layout)
+		require.JSONEq(t, `{"value":"100"}`, string(resources[0].Decoded))
+		require.Empty(t, resources[1].Layout)
+		require.Empty(t, resources[1].Decoded)
+	}
+
+	// provided layout
+	{
+		layout := types.NewLayout(types.LayoutU8)
+		resources := []types.QueryResource{
+			{Path: "01", Value: hex.EncodeToString(balance)},
+			{Path: "02", Value: "01"},
+		}
+		DecodeResources(resources, "u8", &layout)
+
+		require.Empty(t, resources[0].Decoded)
+		require.Equal(t, "u8", resources[1].Layout)
+		require.JSONEq(t, `1`, string(resources[1].Decoded))
+	}
+}
@@ -0,0 +1,445 @@
+package client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+const (
+	FlagOutputDir       = "output-dir"
+	DefaultOutputDir    = "build"
+	PackageManifestName = "manifest.json"
+	MoveSourceExt       = ".move"
+	MoveFileExt         = ".move.json"
+
+	PackageTypeModule = "module"
+	PackageTypeScript = "script"
+)
+
+var (
+	packageCommentsRe = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	packageModuleRe   = regexp.MustCompile(`\bmodule\s+([A-Za-z_][A-Za-z0-9_]*)\s*\{`)
+	packageScriptRe   = regexp.MustCompile(`\bscript\s*\{|\bfun\s+main\s*[<(]`)
+	packageImportRe   = regexp.MustCompile(`\buse\s+0x([0-9a-fA-F]+)::([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// Move package source file (module or script).
+type PackageSource struct {
+	// File path.
+	Path string
+	// Module name or script file name (without extension).
+	Name string
+	// Contract type.
+	Type vm_grpc.ContractType
+	// Source code.
+	Text string
+	// Imported modules.
+	Imports []PackageImport
+}
+
+// Imported module (use 0x{address}::{module}).
+type PackageImport struct {
+	// Libra address.
+	Address []byte
+	// Module name.
+	Module string
+}
+
+func (i PackageImport) String() string {
+	return fmt.Sprintf("0x%s::%s", hex.EncodeToString(i.Address), i.Module)
+}
+
+// Fetch deployed module bytecode from chain state.
+type ModuleCodeFetcher func(address sdk.AccAddress, name string) ([]byte, error)
+
+// Package compilation manifest.
+type PackageManifest struct {
+	// Package account address.
+	Address string `json:"address"`
+	// Compiled files in dependency (deploy) order.
+	Outputs []PackageOutput `json:"outputs"`
+	// Files not compiled as they import package modules which are not deployed yet (in dependency order).
+	Pending []PackagePending `json:"pending,omitempty"`
+	// Deployed modules package depends on (fetched from chain).
+	Dependencies []PackageDependency `json:"dependencies"`
+}
+
+// Compiled package file (MoveFile with source metadata).
+type PackageOutput struct {
+	MoveFile
+	// Module name or script file name.
+	Name string `json:"name"`
+	// Contract type: module / script.
+	Type string `json:"type"`
+	// Source file path.
+	Source string `json:"source"`
+	// Output MoveFile path.
+	Output string `json:"output,omitempty"`
+}
+
+// Package file not compiled: compiler resolves imports using chain state, so imported package modules should be deployed first.
+type PackagePending struct {
+	// Module name or script file name.
+	Name string `json:"name"`
+	// Contract type: module / script.
+	Type string `json:"type"`
+	// Source file path.
+	Source string `json:"source"`
+	// Imported package modules which are not deployed.
+	Missing []string `json:"missing"`
+}
+
+// Deployed module package depends on (MoveFile with module ID).
+type PackageDependency struct {
+	MoveFile
+	// Module deployer address.
+	Address string `json:"address"`
+	// Module name.
+	Module string `json:"module"`
+}
+
+// Parse Move source file: detect contract type, module name and imported modules.
+func ParsePackageSource(path, text string) (PackageSource, error) {
+	source := PackageSource{
+		Path: path,
+		Text: text,
+	}
+
+	code := packageCommentsRe.ReplaceAllString(text, "")
+
+	modules := packageModuleRe.FindAllStringSubmatch(code, -1)
+	isScript := packageScriptRe.MatchString(code)
+	switch {
+	case len(modules) > 1:
+		return PackageSource{}, fmt.Errorf("%s: only one module per file is supported, found %d", path, len(modules))
+	case len(modules) == 1 && isScript:
+		return PackageSource{}, fmt.Errorf("%s: file contains both module and script", path)
+	case len(modules) == 1:
+		source.Name = modules[0][1]
+		source.Type = vm_grpc.ContractType_Module
+	case isScript:
+		source.Name = strings.TrimSuffix(filepath.Base(path), MoveSourceExt)
+		source.Type = vm_grpc.ContractType_Script
+	default:
+		return PackageSource{}, fmt.Errorf("%s: neither module nor script found", path)
+	}
+
+	imported := make(map[string]bool)
+	for _, match := range packageImportRe.FindAllStringSubmatch(code, -1) {
+		address, err := parseMoveAddress(match[1])
+		if err != nil {
+			return PackageSource{}, fmt.Errorf("%s: import %s::%s: %w", path, match[1], match[2], err)
+		}
+
+		imp := PackageImport{Address: address, Module: match[2]}
+		if !imported[imp.String()] {
+			imported[imp.String()] = true
+			source.Imports = append(source.Imports, imp)
+		}
+	}
+
+	return source, nil
+}
+
+// Read and parse all Move source files (*.move) in directory (recursively, in lexical order).
+func ReadPackage(dir string) ([]PackageSource, error) {
+	var sources []PackageSource
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != MoveSourceExt {
+			return nil
+		}
+
+		text, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		source, err := ParsePackageSource(path, string(text))
+		if err != nil {
+			return err
+		}
+		sources = append(sources, source)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no %s files found in %s", MoveSourceExt, dir)
+	}
+
+	return sources, nil
+}
+
+// Sort package sources in dependency order: modules are ordered by imports, scripts follow modules.
+// Returns sorted sources and external imports (modules not defined by package).
+func SortPackage(sources []PackageSource, address []byte) ([]PackageSource, []PackageImport, error) {
+	modules := make(map[string]int)
+	for i, source := range sources {
+		if source.Type != vm_grpc.ContractType_Module {
+			continue
+		}
+		if j, ok := modules[source.Name]; ok {
+			return nil, nil, fmt.Errorf("module %s is defined twice: %s, %s", source.Name, sources[j].Path, source.Path)
+		}
+		modules[source.Name] = i
+	}
+
+	// package module index by import, -1 for external imports
+	localIdx := func(imp PackageImport) int {
+		if !bytes.Equal(imp.Address, address) {
+			return -1
+		}
+		if idx, ok := modules[imp.Module]; ok {
+			return idx
+		}
+
+		return -1
+	}
+
+	const (
+		notVisited = iota
+		inProgress
+		visited
+	)
+	states := make([]int, len(sources))
+	sorted := make([]PackageSource, 0, len(sources))
+
+	var visit func(idx int, chain []string) error
+	visit = func(idx int, chain []string) error {
+		source := sources[idx]
+		chain = append(chain, source.Name)
+
+		switch states[idx] {
+		case visited:
+			return nil
+		case inProgress:
+			return fmt.Errorf("import cycle: %s", strings.Join(chain, " -> "))
+		}
+
+		states[idx] = inProgress
+		for _, imp := range source.Imports {
+			if depIdx := localIdx(imp); depIdx >= 0 {
+				if err := visit(depIdx, chain); err != nil {
+					return err
+				}
+			}
+		}
+		states[idx] = visited
+		sorted = append(sorted, source)
+
+		return nil
+	}
+
+	for i, source := range sources {
+		if source.Type == vm_grpc.ContractType_Module {
+			if err := visit(i, nil); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	for i, source := range sources {
+		if source.Type == vm_grpc.ContractType_Script {
+			if err := visit(i, nil); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	var external []PackageImport
+	externalSet := make(map[string]bool)
+	for _, source := range sorted {
+		for _, imp := range source.Imports {
+			if localIdx(imp) < 0 && !externalSet[imp.String()] {
+				externalSet[imp.String()] = true
+				external = append(external, imp)
+			}
+		}
+	}
+	sort.Slice(external, func(i, j int) bool {
+		return external[i].String() < external[j].String()
+	})
+
+	return sorted, external, nil
+}
+
+// Compile Move package directory: sources are compiled in dependency order, external dependencies deployed
+// by accounts are fetched from chain state (standard library modules provided by genesis are skipped).
+// Compiler accepts a single source file and resolves imports using chain state (via data source server),
+// so dependencies bytecode is not passed to compiler: fetching ensures dependencies are deployed and
+// records them to the manifest.
+// Files importing package modules which are not deployed yet can't be compiled, they are reported as pending:
+// compiled modules should be deployed and package compiled again.
+func CompilePackage(compilerAddr string, address sdk.AccAddress, dir string, fetcher ModuleCodeFetcher) (PackageManifest, error) {
+	libraAddress := common_vm.Bech32ToLibra(address)
+
+	sources, err := ReadPackage(dir)
+	if err != nil {
+		return PackageManifest{}, fmt.Errorf("reading package: %w", err)
+	}
+
+	sources, imports, err := SortPackage(sources, libraAddress)
+	if err != nil {
+		return PackageManifest{}, fmt.Errorf("sorting package: %w", err)
+	}
+
+	manifest := PackageManifest{
+		Address:      address.String(),
+		Outputs:      make([]PackageOutput, 0, len(sources)),
+		Dependencies: make([]PackageDependency, 0, len(imports)),
+	}
+
+	for _, imp := range imports {
+		depAddress, ok := libraToAccAddress(imp.Address)
+		if !ok {
+			continue
+		}
+
+		code, err := fetcher(depAddress, imp.Module)
+		if err != nil {
+			return PackageManifest{}, fmt.Errorf("fetching dependency %s: %w", imp, err)
+		}
+
+		manifest.Dependencies = append(manifest.Dependencies, PackageDependency{
+			MoveFile: MoveFile{Code: hex.EncodeToString(code)},
+			Address:  depAddress.String(),
+			Module:   imp.Module,
+		})
+	}
+
+	// package modules deployed state
+	deployed := make(map[string]bool)
+	for _, source := range sources {
+		if source.Type != vm_grpc.ContractType_Module {
+			continue
+		}
+
+		code, err := fetcher(address, source.Name)
+		if err != nil {
+			continue
+		}
+
+		deployed[source.Name] = true
+		manifest.Dependencies = append(manifest.Dependencies, PackageDependency{
+			MoveFile: MoveFile{Code: hex.EncodeToString(code)},
+			Address:  address.String(),
+			Module:   source.Name,
+		})
+	}
+
+	for _, source := range sources {
+		contractType := PackageTypeModule
+		if source.Type == vm_grpc.ContractType_Script {
+			contractType = PackageTypeScript
+		}
+
+		var missing []string
+		for _, imp := range source.Imports {
+			if bytes.Equal(imp.Address, libraAddress) && !deployed[imp.Module] {
+				missing = append(missing, imp.Module)
+			}
+		}
+
+		if len(missing) > 0 {
+			manifest.Pending = append(manifest.Pending, PackagePending{
+				Name:    source.Name,
+				Type:    contractType,
+				Source:  source.Path,
+				Missing: missing,
+			})
+			continue
+		}
+
+		bytecode, err := Compile(compilerAddr, &vm_grpc.MvIrSourceFile{
+			Text:    source.Text,
+			Address: libraAddress,
+			Type:    source.Type,
+		})
+		if err != nil {
+			return PackageManifest{}, fmt.Errorf("%s: %w", source.Path, err)
+		}
+
+		manifest.Outputs = append(manifest.Outputs, PackageOutput{
+			MoveFile: MoveFile{Code: hex.EncodeToString(bytecode)},
+			Name:     source.Name,
+			Type:     contractType,
+			Source:   source.Path,
+		})
+	}
+
+	return manifest, nil
+}
+
+// Write compiled package outputs to directory as MoveFiles ({name}.move.json) with package manifest.
+func WritePackage(manifest *PackageManifest, outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	for i := range manifest.Outputs {
+		output := &manifest.Outputs[i]
+		output.Output = filepath.Join(outputDir, output.Name+MoveFileExt)
+
+		if err := writeJSONFile(output.Output, output.MoveFile); err != nil {
+			return err
+		}
+	}
+
+	return writeJSONFile(filepath.Join(outputDir, PackageManifestName), manifest)
+}
+
+// Write value as indented JSON file.
+func writeJSONFile(path string, value interface{}) error {
+	bz, err := json.MarshalIndent(value, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, bz, 0644)
+}
+
+// Parse Move address literal (hex without 0x prefix): address is left padded to Libra address length.
+func parseMoveAddress(rawAddress string) ([]byte, error) {
+	if len(rawAddress)%2 != 0 {
+		rawAddress = "0" + rawAddress
+	}
+
+	bz, err := hex.DecodeString(rawAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bz) > common_vm.VMAddressLength {
+		return nil, fmt.Errorf("address length %d exceeds %d", len(bz), common_vm.VMAddressLength)
+	}
+
+	return append(make([]byte, common_vm.VMAddressLength-len(bz)), bz...), nil
+}
+
+// Convert Libra address to account address (if address was converted from bech32 and is not zero).
+func libraToAccAddress(address []byte) (sdk.AccAddress, bool) {
+	if bytes.Equal(address, common_vm.ZeroAddress) {
+		return nil, false
+	}
+
+	return common_vm.LibraToBech32(address)
+}
@@ -0,0 +1,279 @@
+// +build unit
+
+package client
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dfinance/dnode/helpers/tests"
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+const (
+	testPackageModuleA = `
+module A {
+    use 0x0::Account;
+    // use 0x0::Commented;
+    public fun a() {}
+}`
+	testPackageModuleB = `
+module B {
+    use 0x%s::A;
+    use 0x%s::Deployed;
+    public fun b() { A::a(); }
+}`
+	testPackageScript = `
+script {
+    use 0x%s::B;
+    fun main() { B::b(); }
+}`
+)
+
+// Build test package sources.
+func getTestPackageSources(address sdk.AccAddress, dependency sdk.AccAddress) map[string]string {
+	hexAddress := hex.EncodeToString(common_vm.Bech32ToLibra(address))
+
+	return map[string]string{
+		"a.move":      testPackageModuleA,
+		"b.move":      fmt.Sprintf(testPackageModuleB, hexAddress, hex.EncodeToString(common_vm.Bech32ToLibra(dependency))),
+		"0_init.move": fmt.Sprintf(testPackageScript, hexAddress),
+	}
+}
+
+// Test Move source files parsing.
+func TestParsePackageSource(t *testing.T) {
+	t.Parallel()
+
+	// module
+	{
+		source, err := ParsePackageSource("a.move", testPackageModuleA)
+		require.NoError(t, err)
+		require.Equal(t, "A", source.Name)
+		require.Equal(t, vm_grpc.ContractType_Module, source.Type)
+		require.Len(t, source.Imports, 1)
+		require.Equal(t, common_vm.ZeroAddress, source.Imports[0].Address)
+		require.Equal(t, "Account", source.Imports[0].Module)
+	}
+
+	// script
+	{
+		source, err := ParsePackageSource("dir/init.move", "fun main() {}")
+		require.NoError(t, err)
+		require.Equal(t, "init", source.Name)
+		require.Equal(t, vm_grpc.ContractType_Script, source.Type)
+		require.Empty(t, source.Imports)
+	}
+
+	// invalid sources
+	{
+		_, err := ParsePackageSource("a.move", "module A {} module B {}")
+		require.Error(t, err)
+
+		_, err = ParsePackageSource("a.move", "module A {} script { fun main() {} }")
+		require.Error(t, err)
+
+		_, err = ParsePackageSource("a.move", "// module A {}")
+		require.Error(t, err)
+
+		_, err = ParsePackageSource("a.move", "module A { use 0x"+hex.EncodeToString(make([]byte, 25))+"::B; }")
+		require.Error(t, err)
+	}
+}
+
+// Test package sources dependency ordering.
+func TestSortPackage(t *testing.T) {
+	t.Parallel()
+
+	address := sdk.AccAddress(make([]byte, sdk.AddrLen))
+	address[0] = 1
+	dependency := sdk.AccAddress(make([]byte, sdk.AddrLen))
+	dependency[0] = 2
+
+	var sources []PackageSource
+	for _, name := range []string{"0_init.move", "a.move", "b.move"} {
+		source, err := ParsePackageSource(name, getTestPackageSources(address, dependency)[name])
+		require.NoError(t, err)
+		sources = append(sources, source)
+	}
+
+	// reversed input order
+	for _, input := range [][]PackageSource{sources, {sources[2], sources[1], sources[0]}} {
+		sorted, external, err := SortPackage(input, common_vm.Bech32ToLibra(address))
+		require.NoError(t, err)
+
+		require.Len(t, sorted, 3)
+		require.Equal(t, "A", sorted[0].Name)
+		require.Equal(t, "B", sorted[1].Name)
+		require.Equal(t, "0_init", sorted[2].Name)
+
+		require.Len(t, external, 2)
+		require.Equal(t, common_vm.ZeroAddress, external[0].Address)
+		require.Equal(t, "Account", external[0].Module)
+		require.Equal(t, common_vm.Bech32ToLibra(dependency), external[1].Address)
+		require.Equal(t, "Deployed", external[1].Module)
+	}
+
+	// other address imports are external
+	{
+		_, external, err := SortPackage(sources, common_vm.Bech32ToLibra(dependency))
+		require.NoError(t, err)
+		require.Len(t, external, 4)
+	}
+
+	// import cycle
+	{
+		a, err := ParsePackageSource("a.move", "module A { use 0x1::B; }")
+		require.NoError(t, err)
+		b, err := ParsePackageSource("b.move", "module B { use 0x1::A; }")
+		require.NoError(t, err)
+
+		_, _, err = SortPackage([]PackageSource{a, b}, a.Imports[0].Address)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "A -> B -> A")
+	}
+
+	// duplicated module
+	{
+		a, err := ParsePackageSource("a.move", "module A {}")
+		require.NoError(t, err)
+
+		_, _, err = SortPackage([]PackageSource{a, a}, common_vm.ZeroAddress)
+		require.Error(t, err)
+	}
+}
+
+// Test package compilation and outputs writing.
+func TestCompilePackage(t *testing.T) {
+	t.Parallel()
+
+	address := sdk.AccAddress(make([]byte, sdk.AddrLen))
+	address[0] = 1
+	dependency := sdk.AccAddress(make([]byte, sdk.AddrLen))
+	dependency[0] = 2
+	dependencyCode := []byte{0xAA}
+
+	dir, err := ioutil.TempDir("", "compile-package")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// setup compiler mock
+	vmServer := tests.NewVMMockServer()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	vmServer.Start(listener)
+	defer vmServer.Stop()
+
+	for name, text := range getTestPackageSources(address, dependency) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0644))
+		vmServer.SetCompiled(text, []byte(name))
+	}
+
+	// package modules deployed on chain
+	deployedCodes := make(map[string][]byte)
+	fetcher := func(fetchAddress sdk.AccAddress, name string) ([]byte, error) {
+		if fetchAddress.Equals(dependency) && name == "Deployed" {
+			return dependencyCode, nil
+		}
+		if code, ok := deployedCodes[name]; ok && fetchAddress.Equals(address) {
+			return code, nil
+		}
+
+		return nil, fmt.Errorf("module not found")
+	}
+
+	// package modules are not deployed: only A is compiled, B and script import not deployed modules
+	{
+		manifest, err := CompilePackage(listener.Addr().String(), address, dir, fetcher)
+		require.NoError(t, err)
+
+		require.Len(t, manifest.Dependencies, 1)
+		require.Equal(t, dependency.String(), manifest.Dependencies[0].Address)
+		require.Equal(t, "Deployed", manifest.Dependencies[0].Module)
+		require.Equal(t, hex.EncodeToString(dependencyCode), manifest.Dependencies[0].Code)
+
+		require.Len(t, manifest.Outputs, 1)
+		require.Equal(t, "A", manifest.Outputs[0].Name)
+
+		require.Len(t, manifest.Pending, 2)
+		require.Equal(t, PackagePending{Name: "B", Type: PackageTypeModule, Source: filepath.Join(dir, "b.move"), Missing: []string{"A"}}, manifest.Pending[0])
+		require.Equal(t, PackagePending{Name: "0_init", Type: PackageTypeScript, Source: filepath.Join(dir, "0_init.move"), Missing: []string{"B"}}, manifest.Pending[1])
+	}
+
+	// A is deployed: B is compiled
+	{
+		deployedCodes["A"] = []byte("a.move")
+
+		manifest, err := CompilePackage(listener.Addr().String(), address, dir, fetcher)
+		require.NoError(t, err)
+
+		require.Len(t, manifest.Outputs, 2)
+		require.Equal(t, "B", manifest.Outputs[1].Name)
+		require.Len(t, manifest.Pending, 1)
+		require.Equal(t, "0_init", manifest.Pending[0].Name)
+
+		require.Len(t, manifest.Dependencies, 2)
+		require.Equal(t, address.String(), manifest.Dependencies[1].Address)
+		require.Equal(t, "A", manifest.Dependencies[1].Module)
+	}
+
+	// all package modules are deployed
+	deployedCodes["B"] = []byte("b.move")
+	manifest, err := CompilePackage(listener.Addr().String(), address, dir, fetcher)
+	require.NoError(t, err)
+
+	require.Equal(t, address.String(), manifest.Address)
+	require.Len(t, manifest.Dependencies, 3)
+	require.Empty(t, manifest.Pending)
+
+	require.Len(t, manifest.Outputs, 3)
+	for i, expected := range []struct{ name, typ, source string }{
+		{"A", PackageTypeModule, "a.move"},
+		{"B", PackageTypeModule, "b.move"},
+		{"0_init", PackageTypeScript, "0_init.move"},
+	} {
+		require.Equal(t, expected.name, manifest.Outputs[i].Name)
+		require.Equal(t, expected.typ, manifest.Outputs[i].Type)
+		require.Equal(t, filepath.Join(dir, expected.source), manifest.Outputs[i].Source)
+		require.Equal(t, hex.EncodeToString([]byte(expected.source)), manifest.Outputs[i].Code)
+	}
+
+	// write outputs
+	outputDir := filepath.Join(dir, DefaultOutputDir)
+	require.NoError(t, WritePackage(&manifest, outputDir))
+
+	bz, err := ioutil.ReadFile(filepath.Join(outputDir, PackageManifestName))
+	require.NoError(t, err)
+	var writtenManifest PackageManifest
+	require.NoError(t, json.Unmarshal(bz, &writtenManifest))
+	require.Equal(t, manifest, writtenManifest)
+
+	for _, output := range manifest.Outputs {
+		require.Equal(t, filepath.Join(outputDir, output.Name+MoveFileExt), output.Output)
+
+		bz, err := ioutil.ReadFile(output.Output)
+		require.NoError(t, err)
+		var moveFile MoveFile
+		require.NoError(t, json.Unmarshal(bz, &moveFile))
+		require.Equal(t, output.MoveFile, moveFile)
+	}
+
+	// missing dependency
+	{
+		_, err := CompilePackage(listener.Addr().String(), address, dir, func(sdk.AccAddress, string) ([]byte, error) {
+			return nil, fmt.Errorf("module not found")
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Deployed")
+	}
+}
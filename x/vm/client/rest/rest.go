@@ -4,10 +4,12 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	"github.com/dfinance/dvm-proto/go/vm_grpc"
 	"github.com/gorilla/mux"
 	"github.com/spf13/viper"
@@ -20,6 +22,11 @@ import (
 const (
 	accountAddrName = "accountAddr"
 	vmPathName      = "vmPath"
+	moduleName      = "moduleName"
+	layoutParamName = "layout"
+	pageParamName   = "page"
+	limitParamName  = "limit"
+	decodeParamName = "decode"
 )
 
 type compileReq struct {
@@ -27,11 +34,45 @@ type compileReq struct {
 	Account string `json:"address" example:"wallet13jyjuz3kkdvqw8u4qfkwd94emdl3vx394kn07h"` // Code address
 }
 
+type callScriptReq struct {
+	Address   string                   `json:"address" example:"wallet13jyjuz3kkdvqw8u4qfkwd94emdl3vx394kn07h"` // Script sender address
+	Code      string                   `json:"code" format:"HEX string"`                                        // Compiled script code
+	Args      []string                 `json:"args"`                                                            // Script arguments
+	TypedArgs []vmClient.ScriptArgJSON `json:"typed_args"`                                                      // Typed script arguments (alternative to args)
+	Gas       uint64                   `json:"gas" example:"500000"`                                            // Gas limit (optional)
+}
+
+type executeScriptReq struct {
+	BaseReq   rest.BaseReq             `json:"base_req" yaml:"base_req"`
+	Code      string                   `json:"code" format:"HEX string"` // Compiled script code
+	Args      []string                 `json:"args"`                     // Script arguments
+	TypedArgs []vmClient.ScriptArgJSON `json:"typed_args"`               // Typed script arguments (alternative to args)
+}
+
+type deployModuleReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Code    string       `json:"code" format:"HEX string"`             // Compiled module code
+	Policy  string       `json:"policy" example:"owner" yaml:"policy"` // Module upgrade policy (optional)
+}
+
+type simulateReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Msgs    []sdk.Msg    `json:"msgs"` // VM messages (deploy module / execute script) to estimate gas for
+}
+
 // Registering routes for REST API.
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
-	r.HandleFunc(fmt.Sprintf("/%s/compile-script", types.ModuleName), compileScript(cliCtx)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/compile-module", types.ModuleName), compileModule(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/compile-script", types.ModuleName), compileScript(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/compile-module", types.ModuleName), compileModule(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/data/{%s}/{%s}", types.ModuleName, accountAddrName, vmPathName), getData(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/call-script", types.ModuleName), callScript(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/simulate", types.ModuleName), simulate(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/execute-script", types.ModuleName), executeScript(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/deploy-module", types.ModuleName), deployModule(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/resources/{%s}", types.ModuleName, accountAddrName), getResources(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/modules/{%s}", types.ModuleName, accountAddrName), getModules(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/modules/{%s}/{%s}", types.ModuleName, accountAddrName, moduleName), getModule(cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/modules/{%s}/{%s}/code", types.ModuleName, accountAddrName, moduleName), getModuleCode(cliCtx)).Methods("GET")
 }
 
 // GetCompiledScript godoc
@@ -41,11 +82,11 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 // @ID vmGetCompiledScript
 // @Accept  json
 // @Produce json
-// @Param getRequest body compileReq true "Code with metadata"
+// @Param request body compileReq true "Code with metadata"
 // @Success 200 {object} VmRespCompile
 // @Failure 400 {object} rest.ErrorResponse "Returned if the request doesn't have valid query params"
 // @Failure 500 {object} rest.ErrorResponse "Returned on server error"
-// @Router /vm/compile-script [get]
+// @Router /vm/compile-script [post]
 func compileScript(cliCtx context.CLIContext) http.HandlerFunc {
 	return commonCompileHandler(cliCtx, vm_grpc.ContractType_Script)
 }
@@ -57,11 +98,11 @@ func compileScript(cliCtx context.CLIContext) http.HandlerFunc {
 // @ID vmGetCompiledModule
 // @Accept  json
 // @Produce json
-// @Param getRequest body compileReq true "Code with metadata"
+// @Param request body compileReq true "Code with metadata"
 // @Success 200 {object} VmRespCompile
 // @Failure 400 {object} rest.ErrorResponse "Returned if the request doesn't have valid query params"
 // @Failure 500 {object} rest.ErrorResponse "Returned on server error"
-// @Router /vm/compile-module [get]
+// @Router /vm/compile-module [post]
 func compileModule(cliCtx context.CLIContext) http.HandlerFunc {
 	return commonCompileHandler(cliCtx, vm_grpc.ContractType_Module)
 }
@@ -70,14 +111,19 @@ func commonCompileHandler(cliCtx context.CLIContext, compileType vm_grpc.Contrac
 	return func(w http.ResponseWriter, r *http.Request) {
 		req := compileReq{}
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		address, err := sdk.AccAddressFromBech32(req.Account)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("can't parse address %q: %v", req.Account, err))
 			return
 		}
 
 		compilerAddr := viper.GetString(vmClient.FlagCompilerAddr)
 		sourceFile := &vm_grpc.MvIrSourceFile{
 			Text:    req.Code,
-			Address: []byte(req.Account),
+			Address: common_vm.Bech32ToLibra(address),
 			Type:    compileType,
 		}
 
@@ -97,12 +143,13 @@ func commonCompileHandler(cliCtx context.CLIContext, compileType vm_grpc.Contrac
 // GetCompiledModule godoc
 // @Tags vm
 // @Summary Get data from data source
-// @Description Get data from data source by accountAddr and path
+// @Description Get data from data source by accountAddr and path, optionally decoded using layout
 // @ID vmGetData
 // @Accept  json
 // @Produce json
 // @Param accountAddr path string true "account address (Libra HEX  Bech32)"
 // @Param vmPath path string true "VM path (HEX string)"
+// @Param layout query string false "Value layout to decode value: known resource name, registered struct tag or layout JSON"
 // @Success 200 {object} VmData
 // @Failure 422 {object} rest.ErrorResponse "Returned if the request doesn't have valid path params"
 // @Failure 500 {object} rest.ErrorResponse "Returned on server error"
@@ -129,6 +176,20 @@ func getData(cliCtx context.CLIContext) http.HandlerFunc {
 			address = common_vm.Bech32ToLibra(address)
 		}
 
+		var layout *types.ValueLayout
+		if rawLayout := r.URL.Query().Get(layoutParamName); rawLayout != "" {
+			parsedLayout, err := vmClient.ParseValueLayout(rawLayout)
+			if err != nil {
+				rest.WriteErrorResponse(
+					w,
+					http.StatusUnprocessableEntity,
+					fmt.Sprintf("can't parse layout %q: %v", rawLayout, err),
+				)
+				return
+			}
+			layout = &parsedLayout
+		}
+
 		path, err := hex.DecodeString(rawPath)
 		if err != nil {
 			rest.WriteErrorResponse(
@@ -170,7 +231,464 @@ func getData(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 		resp := types.QueryValueResp{Value: hex.EncodeToString(res)}
+		if layout != nil && len(res) > 0 {
+			if resp.Decoded, err = vmClient.DecodeValue(res, *layout); err != nil {
+				rest.WriteErrorResponse(
+					w,
+					http.StatusUnprocessableEntity,
+					err.Error(),
+				)
+				return
+			}
+		}
 
 		rest.PostProcessResponse(w, cliCtx, resp)
 	}
 }
+
+// GetResources godoc
+// @Tags vm
+// @Summary Get resources stored under address
+// @Description Get resources (access paths and values) stored under address, values are optionally decoded
+// @ID vmGetResources
+// @Accept  json
+// @Produce json
+// @Param accountAddr path string true "account address (Libra HEX  Bech32)"
+// @Param page query int false "page number (default: 1)"
+// @Param limit query int false "items per page (default: 100)"
+// @Param decode query bool false "decode values using detected known resources layouts"
+// @Param layout query string false "Value layout to decode all values: known resource name, registered struct tag or layout JSON"
+// @Success 200 {object} VmRespResources
+// @Failure 400 {object} rest.ErrorResponse "Returned if the request doesn't have valid query params"
+// @Failure 422 {object} rest.ErrorResponse "Returned if the request doesn't have valid path params"
+// @Failure 500 {object} rest.ErrorResponse "Returned on server error"
+// @Router /vm/resources/{accountAddr} [get]
+func getResources(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		rawAddress := mux.Vars(r)[accountAddrName]
+		address, err := hex.DecodeString(rawAddress)
+		if err != nil {
+			accAddress, err := sdk.AccAddressFromBech32(rawAddress)
+			if err != nil {
+				rest.WriteErrorResponse(
+					w,
+					http.StatusUnprocessableEntity,
+					fmt.Sprintf("can't parse address %q (should be libra hex or bech32): %v", rawAddress, err),
+				)
+				return
+			}
+
+			address = common_vm.Bech32ToLibra(accAddress)
+		}
+
+		query := types.QueryResources{Address: address}
+		for paramName, value := range map[string]*int{pageParamName: &query.Page, limitParamName: &query.Limit} {
+			if rawValue := r.URL.Query().Get(paramName); rawValue != "" {
+				if *value, err = strconv.Atoi(rawValue); err != nil {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("can't parse %s %q: %v", paramName, rawValue, err))
+					return
+				}
+			}
+		}
+
+		decode := false
+		if rawDecode := r.URL.Query().Get(decodeParamName); rawDecode != "" {
+			if decode, err = strconv.ParseBool(rawDecode); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("can't parse %s %q: %v", decodeParamName, rawDecode, err))
+				return
+			}
+		}
+
+		var layout *types.ValueLayout
+		rawLayout := r.URL.Query().Get(layoutParamName)
+		if rawLayout != "" {
+			parsedLayout, err := vmClient.ParseValueLayout(rawLayout)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusUnprocessableEntity, fmt.Sprintf("can't parse layout %q: %v", rawLayout, err))
+				return
+			}
+			layout = &parsedLayout
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(query)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("can't marshal query: %v", err))
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/resources", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("processing query: %v", err))
+			return
+		}
+
+		var resp types.QueryResourcesResp
+		if err := cliCtx.Codec.UnmarshalJSON(res, &resp); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("can't unmarshal response: %v", err))
+			return
+		}
+
+		if layout != nil || decode {
+			vmClient.DecodeResources(resp.Resources, rawLayout, layout)
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, resp)
+	}
+}
+
+// CallScript godoc
+// @Tags vm
+// @Summary Execute script in read-only mode
+// @Description Execute script without applying write set at the current or provided block height, return events, write set and gas used
+// @ID vmCallScript
+// @Accept  json
+// @Produce json
+// @Param request body callScriptReq true "Compiled script with arguments"
+// @Param height query int false "block height to execute script at"
+// @Success 200 {object} VmRespCallScript
+// @Failure 400 {object} rest.ErrorResponse "Returned if the request doesn't have valid query params"
+// @Failure 500 {object} rest.ErrorResponse "Returned on server error"
+// @Router /vm/call-script [post]
+func callScript(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		req := callScriptReq{}
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		address, err := sdk.AccAddressFromBech32(req.Address)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("can't parse address %q: %v", req.Address, err))
+			return
+		}
+
+		code, err := hex.DecodeString(req.Code)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("can't parse code: %v", err))
+			return
+		}
+
+		scriptArgs, ok := parseScriptArgs(w, code, req.Args, req.TypedArgs)
+		if !ok {
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.QueryCallScript{
+			Address: address,
+			Script:  code,
+			Args:    scriptArgs,
+			Gas:     req.Gas,
+		})
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("can't marshal query: %v", err))
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/call_script", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("processing query: %v", err))
+			return
+		}
+
+		var resp types.QueryCallScriptResp
+		if err := cliCtx.Codec.UnmarshalJSON(res, &resp); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("can't unmarshal response: %v", err))
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, resp)
+	}
+}
+
+// ExecuteScript godoc
+// @Tags vm
+// @Summary Build execute script transaction
+// @Description Parse script arguments using script signature and return unsigned StdTx with execute script message
+// @ID vmExecuteScript
+// @Accept  json
+// @Produce json
+// @Param request body executeScriptReq true "Script code with arguments and base request"
+// @Success 200 {object} VmRespStdTx
+// @Failure 400 {object} rest.ErrorResponse "Returned if the request doesn't have valid query params"
+// @Failure 500 {object} rest.ErrorResponse "Returned on server error"
+// @Router /vm/execute-script [post]
+func executeScript(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := executeScriptReq{}
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		signer, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("can't parse base_req.from address %q: %v", baseReq.From, err))
+			return
+		}
+
+		code, err := hex.DecodeString(req.Code)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("can't parse code: %v", err))
+			return
+		}
+
+		scriptArgs, ok := parseScriptArgs(w, code, req.Args, req.TypedArgs)
+		if !ok {
+			return
+		}
+
+		msg := types.NewMsgExecuteScript(signer, code, scriptArgs)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+// DeployModule godoc
+// @Tags vm
+// @Summary Build deploy module transaction
+// @Description Return unsigned StdTx with deploy module message
+// @ID vmDeployModule
+// @Accept  json
+// @Produce json
+// @Param request body deployModuleReq true "Module code with base request"
+// @Success 200 {object} VmRespStdTx
+// @Failure 400 {object} rest.ErrorResponse "Returned if the request doesn't have valid query params"
+// @Failure 500 {object} rest.ErrorResponse "Returned on server error"
+// @Router /vm/deploy-module [post]
+func deployModule(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := deployModuleReq{}
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		signer, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("can't parse base_req.from address %q: %v", baseReq.From, err))
+			return
+		}
+
+		code, err := hex.DecodeString(req.Code)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("can't parse code: %v", err))
+			return
+		}
+
+		msg := types.NewMsgDeployModule(signer, code).WithPolicy(req.Policy)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+// Parse script arguments (string or typed ones) using script signature extracted by compiler.
+// Writes error response and returns false on failure.
+func parseScriptArgs(w http.ResponseWriter, code []byte, args []string, typedArgs []vmClient.ScriptArgJSON) ([]types.ScriptArg, bool) {
+	if len(args) > 0 && len(typedArgs) > 0 {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, "arguments could be provided via args or via typed_args, not both")
+		return nil, false
+	}
+
+	compilerAddr := viper.GetString(vmClient.FlagCompilerAddr)
+	extractedArgs, err := vmClient.ExtractArguments(compilerAddr, code)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("can't extract script arguments: %v", err))
+		return nil, false
+	}
+
+	var scriptArgs []types.ScriptArg
+	if len(typedArgs) > 0 {
+		scriptArgs, err = vmClient.ParseScriptArgumentsJSON(extractedArgs, typedArgs)
+	} else {
+		scriptArgs, err = vmClient.ParseScriptArguments(extractedArgs, args)
+	}
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	return scriptArgs, true
+}
+
+// Simulate godoc
+// @Tags vm
+// @Summary Estimate gas for VM transaction
+// @Description Simulate VM messages execution (VM is called, state is not changed) and return estimated gas (adjusted with base_req.gas_adjustment)
+// @ID vmSimulate
+// @Accept  json
+// @Produce json
+// @Param request body simulateReq true "VM messages with base request"
+// @Success 200 {object} rest.GasEstimateResponse
+// @Failure 400 {object} rest.ErrorResponse "Returned if the request doesn't have valid query params"
+// @Failure 500 {object} rest.ErrorResponse "Returned on server error"
+// @Router /vm/simulate [post]
+func simulate(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := simulateReq{}
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+		baseReq.Simulate = true
+
+		if len(req.Msgs) == 0 {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "empty msgs list")
+			return
+		}
+
+		for i, msg := range req.Msgs {
+			if msg.Route() != types.RouterKey {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("msg %d: not a VM msg: %s", i, msg.Route()))
+				return
+			}
+
+			if err := msg.ValidateBasic(); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("msg %d: %v", i, err))
+				return
+			}
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, req.Msgs)
+	}
+}
+
+// GetModules godoc
+// @Tags vm
+// @Summary Get deployed modules
+// @Description Get list of modules deployed by address
+// @ID vmGetModules
+// @Accept  json
+// @Produce json
+// @Param accountAddr path string true "deployer address (Bech32)"
+// @Success 200 {object} VmRespModules
+// @Failure 400 {object} rest.ErrorResponse "Returned if the request doesn't have valid query params"
+// @Failure 500 {object} rest.ErrorResponse "Returned on server error"
+// @Router /vm/modules/{accountAddr} [get]
+func getModules(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		rawAddress := mux.Vars(r)[accountAddrName]
+		address, err := sdk.AccAddressFromBech32(rawAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("can't parse address %q: %v", rawAddress, err))
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.QueryModules{Address: address})
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("can't marshal query: %v", err))
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/modules", types.ModuleName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("processing query: %v", err))
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// GetModule godoc
+// @Tags vm
+// @Summary Get deployed module metadata
+// @Description Get deployed module metadata (deployer, height, code hash) by address and module name
+// @ID vmGetModule
+// @Accept  json
+// @Produce json
+// @Param accountAddr path string true "deployer address (Bech32)"
+// @Param moduleName path string true "module name"
+// @Success 200 {object} VmRespModule
+// @Failure 400 {object} rest.ErrorResponse "Returned if the request doesn't have valid query params"
+// @Failure 500 {object} rest.ErrorResponse "Returned on server error"
+// @Router /vm/modules/{accountAddr}/{moduleName} [get]
+func getModule(cliCtx context.CLIContext) http.HandlerFunc {
+	return commonModuleHandler(cliCtx, "module")
+}
+
+// GetModuleCode godoc
+// @Tags vm
+// @Summary Get deployed module bytecode
+// @Description Get deployed module bytecode by address and module name
+// @ID vmGetModuleCode
+// @Accept  json
+// @Produce json
+// @Param accountAddr path string true "deployer address (Bech32)"
+// @Param moduleName path string true "module name"
+// @Success 200 {object} VmRespModuleCode
+// @Failure 400 {object} rest.ErrorResponse "Returned if the request doesn't have valid query params"
+// @Failure 500 {object} rest.ErrorResponse "Returned on server error"
+// @Router /vm/modules/{accountAddr}/{moduleName}/code [get]
+func getModuleCode(cliCtx context.CLIContext) http.HandlerFunc {
+	return commonModuleHandler(cliCtx, "module_code")
+}
+
+func commonModuleHandler(cliCtx context.CLIContext, queryName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		vars := mux.Vars(r)
+		rawAddress := vars[accountAddrName]
+		address, err := sdk.AccAddressFromBech32(rawAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("can't parse address %q: %v", rawAddress, err))
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.QueryModule{Address: address, Name: vars[moduleName]})
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("can't marshal query: %v", err))
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.ModuleName, queryName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("processing query: %v", err))
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
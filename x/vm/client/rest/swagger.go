@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
 	vmClient "github.com/dfinance/dnode/x/vm/client"
 	"github.com/dfinance/dnode/x/vm/internal/types"
 )
@@ -16,4 +18,34 @@ type (
 		Height int64                `json:"height"`
 		Result types.QueryValueResp `json:"result" format:"HEX string"`
 	}
+
+	VmRespResources struct {
+		Height int64                    `json:"height"`
+		Result types.QueryResourcesResp `json:"result"`
+	}
+
+	VmRespModules struct {
+		Height int64                 `json:"height"`
+		Result types.ModulesMetadata `json:"result"`
+	}
+
+	VmRespModule struct {
+		Height int64                `json:"height"`
+		Result types.ModuleMetadata `json:"result"`
+	}
+
+	VmRespModuleCode struct {
+		Height int64                     `json:"height"`
+		Result types.QueryModuleCodeResp `json:"result"`
+	}
+
+	VmRespCallScript struct {
+		Height int64                     `json:"height"`
+		Result types.QueryCallScriptResp `json:"result"`
+	}
+
+	VmRespStdTx struct {
+		Type  string     `json:"type" example:"cosmos-sdk/StdTx"`
+		Value auth.StdTx `json:"value"`
+	}
 )
@@ -8,10 +8,6 @@ import (
 // New message handler for PoA module.
 func NewHandler(keeper Keeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
-		// settings actual context for ds.
-		// TODO: move it to base app and set before transaction execution maybe? or find way to have actual context always
-		keeper.SetDSContext(ctx)
-
 		switch msg := msg.(type) {
 		case MsgDeployModule:
 			return handleMsgDeploy(ctx, keeper, msg)
@@ -19,6 +15,9 @@ func NewHandler(keeper Keeper) sdk.Handler {
 		case MsgExecuteScript:
 			return handleMsgScript(ctx, keeper, msg)
 
+		case MsgExecuteBatch:
+			return handleMsgBatch(ctx, keeper, msg)
+
 		default:
 			return nil, sdkErrors.Wrapf(sdkErrors.ErrUnknownRequest, "unrecognized vm msg type: %v", msg.Type())
 		}
@@ -40,3 +39,11 @@ func handleMsgDeploy(ctx sdk.Context, keeper Keeper, msg MsgDeployModule) (*sdk.
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+func handleMsgBatch(ctx sdk.Context, keeper Keeper, msg MsgExecuteBatch) (*sdk.Result, error) {
+	if err := keeper.ExecuteBatch(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -28,11 +28,13 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/test/bufconn"
 
+	"github.com/dfinance/dvm-proto/go/ds_grpc"
 	"github.com/dfinance/dvm-proto/go/vm_grpc"
 
 	vmConfig "github.com/dfinance/dnode/cmd/config"
 	"github.com/dfinance/dnode/helpers"
 	"github.com/dfinance/dnode/helpers/tests"
+	"github.com/dfinance/dnode/x/common_vm"
 	"github.com/dfinance/dnode/x/currencies_register"
 	"github.com/dfinance/dnode/x/oracle"
 	"github.com/dfinance/dnode/x/vm/internal/types"
@@ -59,24 +61,28 @@ const (
 			{
 				"path": "011c53cd211c8dd6f27b977dbcf497d6650944f764d15cebf75dcc17f8e2bfa5f4",
           		"denom": "dfi",
+          		"balancePath": "0136364df51f98e43cbe92dfcfdd807c7fc626aa9690793ebf7d301cfe0de72f11",
           		"decimals": 18,
           		"totalSupply": "100000000000000000000000000"
         	},
         	{
           		"path": "01b7c72e9510f8bd1bfb20b45f5de59d9289798b6413722cb341aa7c0db02b52bb",
           		"denom": "eth",
+          		"balancePath": "01f7c5cca156342bec3ac2bfb6fc439683da605ab7bc3f58881427861c8fc44577",
           		"decimals": 18,
           		"totalSupply": "100000000000000000000000000"
         	},
         	{
           		"path": "018640c82fe545f74fe72e54cc655c43b3eb465d8ce9f902a61b4d3a0ab99aab33",
           		"denom": "btc",
+          		"balancePath": "01d09b573d3bea2a3853ad447728fa476925894836352c46711fb7604e962b2903",
           		"decimals": 8,
           		"totalSupply": "100000000000000"
 			},
         	{
           		"path": "016f04631b2df14f2199ad915ae7f620c58c12ac8f6728356c543dbfb719e283cc",
           		"denom": "usdt",
+          		"balancePath": "01affc8719224641c76bb20b65e4a26c002a39bbc82b753410976002ad7931b270",
           		"decimals": 6,
           		"totalSupply": "10000000000000"
         	}
@@ -283,23 +289,29 @@ func setupTestInput(launchMock bool) testInput {
 		}
 	}
 
+	input.pk = params.NewKeeper(input.cdc, input.keyParams, input.tkeyParams)
+
 	input.vk = Keeper{
-		cdc:      input.cdc,
-		storeKey: input.keyVM,
-		client:   vm_grpc.NewVMServiceClient(clientConn),
-		listener: listener,
-		config:   config,
+		cdc:        input.cdc,
+		storeKey:   input.keyVM,
+		paramStore: input.pk.Subspace(types.DefaultParamspace).WithKeyTable(NewKeyTable()),
+		client:     vm_grpc.NewVMServiceClient(clientConn),
+		listener:   listener,
+		config:     config,
+		health:     &vmHealth{},
+
+		writeSetHandlers: &[]common_vm.VMWriteSetHandler{},
 	}
 
 	//cdc *amino.Codec, storeKey sdk.StoreKey, vmStorage common_vm.VMStorage
 	input.cr = currencies_register.NewKeeper(input.cdc, input.keyCRegister, input.vk)
 
-	input.pk = params.NewKeeper(input.cdc, input.keyParams, input.tkeyParams)
 	input.ak = vmauth.NewVMAccountKeeper(
 		input.cdc,
 		input.keyAccount,
 		input.pk.Subspace(auth.DefaultParamspace),
 		input.vk,
+		input.cr,
 		auth.ProtoBaseAccount,
 	)
 
@@ -307,6 +319,7 @@ func setupTestInput(launchMock bool) testInput {
 
 	input.vk.dsServer = NewDSServer(&input.vk)
 	input.ctx = sdk.NewContext(mstore, abci.Header{ChainID: "dn-testnet-vm-keeper-test"}, false, log.NewNopLogger())
+	input.vk.SetParams(input.ctx, types.DefaultParams())
 
 	err = input.cr.InitGenesis(input.ctx, []byte(CoinsInfo))
 	if err != nil {
@@ -344,12 +357,17 @@ func (server VMServer) ExecuteContracts(ctx context.Context, req *vm_grpc.VMExec
 
 	for i, contract := range req.Contracts {
 		if contract.ContractType == vm_grpc.ContractType_Module {
-			// process module
+			// process module: bytecode is stored under contract address
+			path := randomPath()
+			if addr, err := hex.DecodeString(strings.TrimPrefix(contract.Address, "0x")); err == nil {
+				path.Address = addr
+			}
+
 			values := make([]*vm_grpc.VMValue, 1)
 			values[0] = &vm_grpc.VMValue{
 				Type:  vm_grpc.VmWriteOp_Value,
-				Value: randomValue(512),
-				Path:  randomPath(),
+				Value: contract.Code,
+				Path:  path,
 			}
 
 			resps.Executions[i] = &vm_grpc.VMExecuteResponse{
@@ -412,6 +430,31 @@ func LaunchVMMock() (*VMServer, *grpc.Server, *bufconn.Listener) {
 	return &vmServer, server, vmListener
 }
 
+// Launch in-process VM mock server connected to keeper DS server and switch keeper VM client to it.
+func LaunchVMMockServer(input *testInput) *tests.VMMockServer {
+	if input.vk.rawDSServer == nil {
+		input.vk.rawDSServer = StartServer(input.dsListener, input.vk.dsServer)
+	}
+
+	vmListener := bufconn.Listen(bufferSize)
+	vmMock := tests.NewVMMockServer()
+	vmMock.Start(vmListener)
+
+	dsConn, err := grpc.DialContext(context.TODO(), "", grpc.WithContextDialer(GetBufDialer(input.dsListener)), grpc.WithInsecure())
+	if err != nil {
+		panic(err)
+	}
+	vmMock.SetDataSourceClient(ds_grpc.NewDSServiceClient(dsConn))
+
+	vmConn, err := grpc.DialContext(context.TODO(), "", grpc.WithContextDialer(GetBufDialer(vmListener)), grpc.WithInsecure())
+	if err != nil {
+		panic(err)
+	}
+	input.vk.client = vm_grpc.NewVMServiceClient(vmConn)
+
+	return vmMock
+}
+
 func GetBufDialer(listener *bufconn.Listener) func(context.Context, string) (net.Conn, error) {
 	return func(ctx context.Context, url string) (net.Conn, error) {
 		return listener.Dial()
@@ -474,7 +517,7 @@ func createCompilerOptions(registry, dsServerUrl, tag string) docker.CreateConta
 }
 
 // stop docker
-func stopDocker(t *testing.T, client *docker.Client, container *docker.Container) {
+func stopDocker(t testing.TB, client *docker.Client, container *docker.Container) {
 	if err := client.RemoveContainer(docker.RemoveContainerOptions{
 		ID:    container.ID,
 		Force: true,
@@ -484,7 +527,7 @@ func stopDocker(t *testing.T, client *docker.Client, container *docker.Container
 }
 
 // Launch docker container with dvm.
-func launchDocker(dsServerPort int, t *testing.T) (*docker.Client, *docker.Container, *docker.Container) {
+func launchDocker(dsServerPort int, t testing.TB) (*docker.Client, *docker.Container, *docker.Container) {
 	hostUrl, _, err := tests.HostMachineDockerUrl()
 	require.NoError(t, err)
 	dsServerUrl := fmt.Sprintf("%s:%d", hostUrl, dsServerPort)
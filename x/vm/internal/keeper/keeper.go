@@ -7,6 +7,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/tendermint/go-amino"
 	"github.com/tendermint/tendermint/libs/log"
 	"google.golang.org/grpc"
@@ -21,36 +22,55 @@ import (
 
 // VM keeper.
 type Keeper struct {
-	cdc      *amino.Codec // Amino codec.
-	storeKey sdk.StoreKey // Store key.
+	cdc        *amino.Codec    // Amino codec.
+	storeKey   sdk.StoreKey    // Store key.
+	paramStore params.Subspace // VM params store.
 
 	client    vm_grpc.VMServiceClient // VM service client.
 	listener  net.Listener            // VM data server listener.
 	rawClient *grpc.ClientConn        // GRPC connection to VM.
 
 	config *config.VMConfig // VM config.
+	health *vmHealth        // VM health state.
 
 	dsServer    *DSServer    // Data-source server.
 	rawDSServer *grpc.Server // GRPC raw server.
+
+	writeSetHandlers *[]common_vm.VMWriteSetHandler // Execution write set handlers registered by other keepers.
 }
 
 // Check that VMStorage is compatible with keeper (later we can do it by events probably).
 var _ common_vm.VMStorage = Keeper{}
 
+// Check that keeper could be used to check VM availability by ante handler.
+var _ common_vm.VMHealthChecker = Keeper{}
+
+// Check that keeper could be used by other keepers to register DS middlewares.
+var _ common_vm.DSMiddlewareRegistry = Keeper{}
+
+// Check that keeper could be used by other keepers to register execution write set handlers.
+var _ common_vm.VMWriteSetHandlerRegistry = Keeper{}
+
 // Initialize VM keeper (include grpc client to VM and grpc server for data store).
-func NewKeeper(storeKey sdk.StoreKey, cdc *amino.Codec, conn *grpc.ClientConn, listener net.Listener, config *config.VMConfig) (keeper Keeper) {
+func NewKeeper(storeKey sdk.StoreKey, cdc *amino.Codec, conn *grpc.ClientConn, listener net.Listener, config *config.VMConfig, paramStore params.Subspace) (keeper Keeper) {
 	keeper = Keeper{
-		cdc:       cdc,
-		storeKey:  storeKey,
-		rawClient: conn,
-		client:    vm_grpc.NewVMServiceClient(conn),
-		listener:  listener,
-		config:    config,
+		cdc:        cdc,
+		storeKey:   storeKey,
+		paramStore: paramStore.WithKeyTable(NewKeyTable()),
+		rawClient:  conn,
+		client:     vm_grpc.NewVMServiceClient(conn),
+		listener:   listener,
+		config:     config,
+		health:     &vmHealth{},
+
+		writeSetHandlers: &[]common_vm.VMWriteSetHandler{},
 	}
 
 	keeper.dsServer = NewDSServer(&keeper)
 	keeper.dsServer.RegisterDataMiddleware(middlewares.NewBlockMiddleware())
 	keeper.dsServer.RegisterDataMiddleware(middlewares.NewTimeMiddleware())
+	keeper.dsServer.RegisterDataMiddleware(middlewares.NewChainMiddleware())
+	keeper.dsServer.RegisterDataMiddleware(middlewares.NewTxMiddleware())
 
 	return
 }
@@ -62,16 +82,19 @@ func (Keeper) Logger(ctx sdk.Context) log.Logger {
 
 // Execute script.
 func (keeper Keeper) ExecuteScript(ctx sdk.Context, msg types.MsgExecuteScript) error {
-	req, sdkErr := NewExecuteRequest(ctx, msg)
+	ctx = common_vm.WithTxSender(ctx, msg.Signer)
+
+	params := keeper.GetParams(ctx)
+	req, sdkErr := NewExecuteRequest(ctx, msg, params)
 	if sdkErr != nil {
 		return sdkErr
 	}
 
 	resp, err := keeper.sendExecuteReq(ctx, req)
 	if err != nil {
-		keeper.Logger(ctx).Error(fmt.Sprintf("grpc error: %s", err.Error()))
-		panic(sdkErrors.Wrap(types.ErrVMCrashed, err.Error()))
+		return keeper.handleVMFailure(ctx, err)
 	}
+	keeper.observeGasUsed(msg.Type(), resp)
 
 	if len(resp.Executions) != 1 {
 		// error because execution amount during such transaction could be only one.
@@ -79,23 +102,107 @@ func (keeper Keeper) ExecuteScript(ctx sdk.Context, msg types.MsgExecuteScript)
 	}
 
 	exec := resp.Executions[0]
-	keeper.processExecution(ctx, exec)
+	keeper.processExecution(ctx, exec, params)
 
 	return nil
 }
 
-// Deploy module.
+// Execute batch of modules / scripts.
+// Contracts are sent to VM one per request and executed one by one within the batch context, so a contract sees
+// write sets of the previous ones (e.g. script uses module deployed earlier in the same batch): VM doesn't apply
+// write sets between contracts of a single request, and gas limit depends on gas consumed by the previous contracts.
+// Batch is aborted (no changes are applied) if any contract execution fails.
+// Modules upgrade policies are checked.
+func (keeper Keeper) ExecuteBatch(ctx sdk.Context, msg types.MsgExecuteBatch) error {
+	ctx = common_vm.WithTxSender(ctx, msg.Signer)
+
+	for i, contract := range msg.Contracts {
+		if contract.Type != vm_grpc.ContractType_Module {
+			continue
+		}
+
+		if err := keeper.checkModuleUpgrade(ctx, msg.Signer, contract.Code, false); err != nil {
+			return sdkErrors.Wrapf(err, "contract %d", i)
+		}
+	}
+
+	// validate all contracts before sending any of them to VM.
+	params := keeper.GetParams(ctx)
+	for i, batchContract := range msg.Contracts {
+		if _, sdkErr := NewBatchContract(ctx, msg.Signer, batchContract, params); sdkErr != nil {
+			return sdkErrors.Wrapf(sdkErr, "contract %d", i)
+		}
+	}
+
+	batchCtx, writeCache := ctx.CacheContext()
+	for i, batchContract := range msg.Contracts {
+		// gas limit is calculated for every contract as previous ones consume gas.
+		contract, sdkErr := NewBatchContract(batchCtx, msg.Signer, batchContract, params)
+		if sdkErr != nil {
+			return sdkErrors.Wrapf(sdkErr, "contract %d", i)
+		}
+
+		resp, err := keeper.sendExecuteReq(batchCtx, &vm_grpc.VMExecuteRequest{Contracts: []*vm_grpc.VMContract{contract}})
+		if err != nil {
+			return keeper.handleVMFailure(ctx, err)
+		}
+		keeper.observeGasUsed(msg.Type(), resp)
+
+		if len(resp.Executions) != 1 {
+			// error because execution amount should match request contracts amount.
+			return sdkErrors.Wrapf(types.ErrWrongExecutionResponse, "contract %d: %v", i, *resp)
+		}
+
+		exec := resp.Executions[0]
+		if !keeper.processExecution(batchCtx, exec, params) {
+			return sdkErrors.Wrapf(types.ErrBatchAborted, "contract %d: status %s, major code %d, sub status %d: %s",
+				i, exec.Status, exec.StatusStruct.GetMajorStatus(), exec.StatusStruct.GetSubStatus(), exec.StatusStruct.GetMessage())
+		}
+
+		if batchContract.Type == vm_grpc.ContractType_Module {
+			keeper.registerModule(batchCtx, types.NewMsgDeployModule(msg.Signer, batchContract.Code), exec.WriteSet)
+		}
+	}
+
+	writeCache()
+	ctx.EventManager().EmitEvents(batchCtx.EventManager().Events())
+
+	return nil
+}
+
+// Deploy module, module upgrade policy is checked.
 func (keeper Keeper) DeployContract(ctx sdk.Context, msg types.MsgDeployModule) error {
-	req, sdkErr := NewDeployRequest(ctx, msg)
+	if err := keeper.checkModuleUpgrade(ctx, msg.Signer, msg.Module, false); err != nil {
+		return err
+	}
+
+	return keeper.deployModule(ctx, msg)
+}
+
+// Deploy / upgrade module via multisig call, "multisig" module upgrade policy is allowed.
+func (keeper Keeper) UpgradeModule(ctx sdk.Context, msg types.MsgUpgradeModule) error {
+	if err := keeper.checkModuleUpgrade(ctx, msg.Address, msg.Module, true); err != nil {
+		return err
+	}
+
+	return keeper.deployModule(ctx, types.NewMsgDeployModule(msg.Address, msg.Module).WithPolicy(msg.Policy))
+}
+
+// Send deploy request to VM, process execution and register module.
+func (keeper Keeper) deployModule(ctx sdk.Context, msg types.MsgDeployModule) error {
+	ctx = common_vm.WithTxSender(ctx, msg.Signer)
+
+	params := keeper.GetParams(ctx)
+	req, sdkErr := NewDeployRequest(ctx, msg, params)
 	if sdkErr != nil {
 		return sdkErr
 	}
 
 	resp, err := keeper.sendExecuteReq(ctx, req)
 	if err != nil {
-		keeper.Logger(ctx).Error(fmt.Sprintf("grpc error: %s", err.Error()))
-		panic(sdkErrors.Wrap(types.ErrVMCrashed, err.Error()))
+		return keeper.handleVMFailure(ctx, err)
 	}
+	keeper.observeGasUsed(msg.Type(), resp)
 
 	if len(resp.Executions) != 1 {
 		// error because execution amount during such transaction could be only one.
@@ -103,7 +210,9 @@ func (keeper Keeper) DeployContract(ctx sdk.Context, msg types.MsgDeployModule)
 	}
 
 	exec := resp.Executions[0]
-	keeper.processExecution(ctx, exec)
+	if keeper.processExecution(ctx, exec, params) {
+		keeper.registerModule(ctx, msg, exec.WriteSet)
+	}
 
 	return nil
 }
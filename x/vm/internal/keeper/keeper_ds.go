@@ -10,16 +10,21 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/dfinance/dvm-proto/go/vm_grpc"
 
+	"github.com/dfinance/dnode/cmd/config"
+	"github.com/dfinance/dnode/helpers/metrics"
+	"github.com/dfinance/dnode/x/common_vm"
 	"github.com/dfinance/dnode/x/vm/internal/types"
 )
 
 // Retry "execution" request.
 // Contains information about request to VM and retry settings.
 type RetryExecReq struct {
-	Raw            *vm_grpc.VMExecuteRequest // Request to retry.
-	Attempt        int                       // Current attempt.
-	CurrentTimeout int                       // Current timeout.
-	MaxAttempts    int                       // Max attempts.
+	Raw             *vm_grpc.VMExecuteRequest // Request to retry.
+	Attempt         int                       // Current attempt.
+	CurrentTimeout  int                       // Current timeout.
+	MaxAttempts     int                       // Max attempts.
+	Deadline        time.Time                 // Retry deadline (zero - no deadline).
+	ExecWaitTimeout int                       // Timeout in ms to wait for the running VM execution (zero - wait infinitely).
 }
 
 // Start Data source (DS) server.
@@ -33,13 +38,34 @@ func (keeper *Keeper) StartDSServer(ctx sdk.Context) {
 	}
 }
 
-// Set DS (data-source) server context.
+// Set DS (data-source) server default context (used for requests out of VM executions).
+// VM executions use their own execution-scoped contexts.
 func (keeper Keeper) SetDSContext(ctx sdk.Context) {
 	keeper.dsServer.SetContext(ctx.WithGasMeter(types.NewDumbGasMeter()))
 }
 
+// Register DS (data-source) data middleware.
+// Should be called on app initialization (before DS server is started), middlewares are processed in registration order.
+func (keeper Keeper) RegisterDSDataMiddleware(md common_vm.DSDataMiddleware) {
+	keeper.dsServer.RegisterDataMiddleware(md)
+}
+
+// Reset DS (data-source) server read cache, should be called on commit.
+func (keeper Keeper) ResetDSCache(nextHeight int64) {
+	keeper.dsServer.cache.reset(nextHeight)
+}
+
+// Invalidate DS (data-source) server read cache on storage write.
+func (keeper Keeper) invalidateDSCache(key []byte) {
+	if keeper.dsServer != nil {
+		keeper.dsServer.cache.invalidate(key)
+	}
+}
+
 // Stop DS server and close connection to VM.
 func (keeper Keeper) CloseConnections() {
+	keeper.stopHealthProber()
+
 	if keeper.rawDSServer != nil {
 		keeper.rawDSServer.Stop()
 	}
@@ -50,19 +76,34 @@ func (keeper Keeper) CloseConnections() {
 }
 
 // Send request with retry mechanism and wait for connection and execution or return error.
+// Execution context is registered within DS server (executions are serialized).
 func (keeper Keeper) retryExecReq(ctx sdk.Context, req RetryExecReq) (retResp *vm_grpc.VMExecuteResponses, retErr error) {
+	execWaitTimeout := time.Duration(req.ExecWaitTimeout) * time.Millisecond
+	release, err := keeper.dsServer.RegisterExecution(ctx.WithGasMeter(types.NewDumbGasMeter()), execWaitTimeout)
+	if err != nil {
+		retErr = err
+		return
+	}
+	defer release()
+
+	vmMetrics := metrics.Get()
+
 	doneCh := make(chan bool)
 	go func() {
 		defer close(doneCh)
 
 		for {
-			curTimeout := time.Duration(req.CurrentTimeout)*time.Millisecond
-			connCtx, _ := context.WithTimeout(context.Background(), curTimeout)
+			curTimeout := time.Duration(req.CurrentTimeout) * time.Millisecond
+			connCtx, connCancel := context.WithTimeout(context.Background(), curTimeout)
 
 			connStartedAt := time.Now()
 			resp, err := keeper.client.ExecuteContracts(connCtx, req.Raw)
 			connDuration := time.Now().Sub(connStartedAt)
+			connCancel()
 			if err != nil {
+				vmMetrics.VMRequestAttempts.With(metrics.LabelResult, metrics.ResultFailure).Add(1)
+				vmMetrics.VMRequestDuration.With(metrics.LabelResult, metrics.ResultFailure).Observe(connDuration.Seconds())
+
 				if req.Attempt == 0 {
 					// write to Sentry (if enabled)
 					keeper.Logger(ctx).Error(fmt.Sprintf("Can't get answer from VM in %v, will try to reconnect in %s attempts: %v", req.CurrentTimeout, GetMaxAttemptsStr(req.MaxAttempts), err))
@@ -73,6 +114,16 @@ func (keeper Keeper) retryExecReq(ctx sdk.Context, req RetryExecReq) (retResp *v
 					// return error because of max attempts.
 					logErr := fmt.Errorf("max %d attemps reached, can't get answer from VM: %v", req.Attempt, err)
 					keeper.Logger(ctx).Error(logErr.Error())
+					vmMetrics.VMRequestFailures.Add(1)
+					retErr = logErr
+					return
+				}
+
+				if !req.Deadline.IsZero() && time.Now().After(req.Deadline) {
+					// return error because of retry deadline.
+					logErr := fmt.Errorf("retry deadline reached after %d attemps, can't get answer from VM: %v", req.Attempt, err)
+					keeper.Logger(ctx).Error(logErr.Error())
+					vmMetrics.VMRequestFailures.Add(1)
 					retErr = logErr
 					return
 				}
@@ -88,7 +139,11 @@ func (keeper Keeper) retryExecReq(ctx sdk.Context, req RetryExecReq) (retResp *v
 
 				continue
 			}
+			vmMetrics.VMRequestAttempts.With(metrics.LabelResult, metrics.ResultSuccess).Add(1)
+			vmMetrics.VMRequestDuration.With(metrics.LabelResult, metrics.ResultSuccess).Observe(connDuration.Seconds())
+
 			keeper.Logger(ctx).Info(fmt.Sprintf("Successfully connected to VM with %v timeout in %d attempts", req.CurrentTimeout, req.Attempt))
+			keeper.health.set(nil)
 			retResp = resp
 
 			return
@@ -108,10 +163,27 @@ func (keeper Keeper) sendExecuteReq(ctx sdk.Context, req *vm_grpc.VMExecuteReque
 	}
 
 	if keeper.config.MaxAttempts < 0 {
-		// just send, in case of error - return error and panic.
+		// just send, in case of error - return error and handle it by failure policy.
 		retryReq.MaxAttempts = 1
 	}
 
+	// DeliverTx retries are capped by attempts count (not wall clock), node halts once attempts are exhausted.
+	if !ctx.IsCheckTx() && keeper.config.DeliverMaxAttempts > 0 {
+		if retryReq.MaxAttempts == 0 || retryReq.MaxAttempts > keeper.config.DeliverMaxAttempts {
+			retryReq.MaxAttempts = keeper.config.DeliverMaxAttempts
+		}
+	}
+
+	if ctx.IsCheckTx() {
+		// CheckTx / simulation shouldn't wait for a stuck DeliverTx execution infinitely.
+		retryReq.ExecWaitTimeout = types.ExecWaitTimeout
+	}
+
+	// wall clock based deadline is not deterministic, so it is applied to CheckTx / simulation only.
+	if ctx.IsCheckTx() && keeper.config.FailurePolicy == config.VMFailurePolicyRetryDeadline {
+		retryReq.Deadline = time.Now().Add(time.Duration(keeper.config.RetryDeadline) * time.Millisecond)
+	}
+
 	return keeper.retryExecReq(ctx, retryReq)
 }
 
@@ -18,6 +18,12 @@ func (keeper Keeper) InitGenesis(ctx sdk.Context, data json.RawMessage) {
 
 	types.ModuleCdc.MustUnmarshalJSON(data, &state)
 
+	params := types.DefaultParams()
+	if state.Parameters != nil {
+		params = *state.Parameters
+	}
+	keeper.SetParams(ctx, params)
+
 	for _, genWriteOp := range state.WriteSet {
 		bzAddr, err := hex.DecodeString(genWriteOp.Address)
 		if err != nil {
@@ -42,18 +48,47 @@ func (keeper Keeper) InitGenesis(ctx sdk.Context, data json.RawMessage) {
 		keeper.setValue(ctx, accessPath, bzValue)
 	}
 
-	// "data" variable can't be used directly as it might contain extra JSON fields
+	for _, module := range state.Modules {
+		keeper.setModule(ctx, module)
+	}
+
+	// mark genesis as initialized (genesis state itself is not stored, VM storage is exported instead)
 	store := ctx.KVStore(keeper.storeKey)
-	store.Set(types.KeyGenesis, types.ModuleCdc.MustMarshalJSON(state))
+	store.Set(types.KeyGenesis, []byte{1})
 }
 
+// Export genesis state: whole VM storage as write set, deployed modules registry and params.
 func (keeper Keeper) ExportGenesis(ctx sdk.Context) types.GenesisState {
-	store := ctx.KVStore(keeper.storeKey)
-	state := types.GenesisState{}
+	params := keeper.GetParams(ctx)
+
+	return types.GenesisState{
+		WriteSet:   keeper.exportWriteSet(ctx),
+		Modules:    keeper.GetAllModules(ctx),
+		Parameters: &params,
+	}
+}
 
-	if store.Has(types.KeyGenesis) {
-		types.ModuleCdc.MustUnmarshalJSON(store.Get(types.KeyGenesis), &state)
+// Prepare VM state for export at zero height: reset modules deploy height.
+func (keeper Keeper) PrepForZeroHeightGenesis(ctx sdk.Context) {
+	for _, module := range keeper.GetAllModules(ctx) {
+		module.Height = 0
+		keeper.setModule(ctx, module)
 	}
+}
+
+// Export all VM storage values as genesis write set.
+func (keeper Keeper) exportWriteSet(ctx sdk.Context) []types.GenesisWriteOp {
+	writeSet := make([]types.GenesisWriteOp, 0)
+
+	keeper.IterateValues(ctx, func(accessPath *vm_grpc.VMAccessPath, value []byte) bool {
+		writeSet = append(writeSet, types.GenesisWriteOp{
+			Address: hex.EncodeToString(accessPath.Address),
+			Path:    hex.EncodeToString(accessPath.Path),
+			Value:   hex.EncodeToString(value),
+		})
+
+		return false
+	})
 
-	return state
+	return writeSet
 }
@@ -0,0 +1,139 @@
+// VM availability: failure policy and health prober.
+package keeper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+
+	"github.com/dfinance/dnode/cmd/config"
+	"github.com/dfinance/dnode/helpers/metrics"
+	"github.com/dfinance/dnode/x/vm/internal/types"
+)
+
+// VM health state, updated by health prober and VM requests results.
+type vmHealth struct {
+	mu        sync.RWMutex
+	down      bool      // VM is marked down.
+	lastErr   error     // last health check / request error.
+	checkedAt time.Time // last update time.
+	stopCh    chan struct{}
+}
+
+// Update health state, returns true if state has changed.
+func (h *vmHealth) set(err error) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	changed := h.down != (err != nil)
+	h.down, h.lastErr, h.checkedAt = err != nil, err, time.Now()
+
+	if h.down {
+		metrics.Get().VMUp.Set(0)
+	} else {
+		metrics.Get().VMUp.Set(1)
+	}
+
+	return changed
+}
+
+// Check VM is not marked down.
+func (h *vmHealth) isUp() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return !h.down
+}
+
+// Check VM is available (not marked down by health prober / failed VM request).
+func (keeper Keeper) IsVMAvailable() bool {
+	return keeper.health.isUp()
+}
+
+// Check msg route is handled by VM module.
+func (keeper Keeper) IsVMRoute(msgRoute string) bool {
+	return msgRoute == types.RouterKey
+}
+
+// Start VM health prober (non-blocking), disabled if health check interval is zero.
+func (keeper Keeper) StartHealthProber(logger log.Logger) {
+	keeper.health.mu.Lock()
+	defer keeper.health.mu.Unlock()
+
+	if keeper.config.HealthCheckInterval <= 0 || keeper.health.stopCh != nil {
+		return
+	}
+
+	logger = logger.With("module", fmt.Sprintf("x/%s", types.ModuleName))
+	stopCh := make(chan struct{})
+	keeper.health.stopCh = stopCh
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(keeper.config.HealthCheckInterval) * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				keeper.probeVM(logger)
+			}
+		}
+	}()
+}
+
+// Stop VM health prober.
+func (keeper Keeper) stopHealthProber() {
+	keeper.health.mu.Lock()
+	defer keeper.health.mu.Unlock()
+
+	if keeper.health.stopCh != nil {
+		close(keeper.health.stopCh)
+		keeper.health.stopCh = nil
+	}
+}
+
+// Check VM is reachable: empty execution request is sent, health state is updated.
+func (keeper Keeper) probeVM(logger log.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(keeper.config.HealthCheckTimeout)*time.Millisecond)
+	defer cancel()
+
+	_, err := keeper.client.ExecuteContracts(ctx, &vm_grpc.VMExecuteRequest{})
+	if keeper.health.set(err) {
+		if err != nil {
+			logger.Error(fmt.Sprintf("VM is down, VM transactions are rejected: %v", err))
+		} else {
+			logger.Info("VM is up")
+		}
+	}
+
+	return err
+}
+
+// Handle VM request failure according to VM failure policy: halt the node (panic) or fail tx.
+// Policy is applied to CheckTx / simulation only, DeliverTx always halts the node:
+// DeliverTx result can't depend on local VM availability, as that would lead to app hash divergence.
+// VM is marked down until health prober / next request succeeds.
+func (keeper Keeper) handleVMFailure(ctx sdk.Context, err error) error {
+	keeper.Logger(ctx).Error(fmt.Sprintf("grpc error: %s", err.Error()))
+	keeper.health.set(err)
+
+	if !ctx.IsCheckTx() {
+		panic(sdkErrors.Wrap(types.ErrVMCrashed, err.Error()))
+	}
+
+	switch keeper.config.FailurePolicy {
+	case config.VMFailurePolicyFailTx, config.VMFailurePolicyRetryDeadline:
+		return sdkErrors.Wrap(types.ErrVMUnavailable, err.Error())
+	default:
+		panic(sdkErrors.Wrap(types.ErrVMCrashed, err.Error()))
+	}
+}
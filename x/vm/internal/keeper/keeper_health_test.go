@@ -0,0 +1,148 @@
+// +build unit
+
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	vmConfig "github.com/dfinance/dnode/cmd/config"
+	"github.com/dfinance/dnode/helpers/tests"
+	"github.com/dfinance/dnode/x/vm/internal/types"
+)
+
+// VM failure policies with mocked VM.
+func TestKeeper_VMFailurePolicy(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	vmMock := LaunchVMMockServer(&input)
+	defer vmMock.Stop()
+
+	defConfig := *input.vk.config
+	defer func() { *input.vk.config = defConfig }()
+
+	msg := types.NewMsgExecuteScript(sdk.AccAddress(randomValue(20)), randomValue(32), nil)
+	checkTxCtx := input.ctx.WithIsCheckTx(true)
+
+	// halt: panic
+	{
+		input.vk.config.FailurePolicy = vmConfig.VMFailurePolicyHalt
+		input.vk.config.MaxAttempts = -1
+		vmMock.PushError(fmt.Errorf("vm is down"))
+
+		require.Panics(t, func() {
+			input.vk.ExecuteScript(input.ctx, msg)
+		})
+		require.False(t, input.vk.IsVMAvailable())
+
+		// VM is marked up after successful request
+		require.NoError(t, input.vk.ExecuteScript(input.ctx, msg))
+		require.True(t, input.vk.IsVMAvailable())
+	}
+
+	// fail tx: error is returned for CheckTx
+	{
+		input.vk.config.FailurePolicy = vmConfig.VMFailurePolicyFailTx
+		input.vk.config.MaxAttempts = -1
+		vmMock.PushError(fmt.Errorf("vm is down"))
+
+		err := input.vk.ExecuteScript(checkTxCtx, msg)
+		tests.CheckExpectedErr(t, types.ErrVMUnavailable, err)
+		require.False(t, input.vk.IsVMAvailable())
+
+		require.NoError(t, input.vk.ExecuteScript(checkTxCtx, msg))
+		require.True(t, input.vk.IsVMAvailable())
+	}
+
+	// fail tx: DeliverTx halts
+	{
+		input.vk.config.FailurePolicy = vmConfig.VMFailurePolicyFailTx
+		input.vk.config.MaxAttempts = -1
+		vmMock.PushError(fmt.Errorf("vm is down"))
+
+		require.Panics(t, func() {
+			input.vk.ExecuteScript(input.ctx, msg)
+		})
+		require.False(t, input.vk.IsVMAvailable())
+
+		require.NoError(t, input.vk.ExecuteScript(input.ctx, msg))
+		require.True(t, input.vk.IsVMAvailable())
+	}
+
+	// retry with deadline: infinite attempts are limited by deadline for CheckTx
+	{
+		input.vk.config.FailurePolicy = vmConfig.VMFailurePolicyRetryDeadline
+		input.vk.config.MaxAttempts = 0
+		input.vk.config.RetryDeadline = 300
+		for i := 0; i < 100; i++ {
+			vmMock.PushError(fmt.Errorf("vm is down"))
+		}
+
+		err := input.vk.ExecuteScript(checkTxCtx, msg)
+		tests.CheckExpectedErr(t, types.ErrVMUnavailable, err)
+		require.False(t, input.vk.IsVMAvailable())
+
+		vmMock.Reset()
+		require.NoError(t, input.vk.ExecuteScript(checkTxCtx, msg))
+		require.True(t, input.vk.IsVMAvailable())
+	}
+
+	// retry with deadline: DeliverTx retries until VM is up (no deadline)
+	{
+		input.vk.config.FailurePolicy = vmConfig.VMFailurePolicyRetryDeadline
+		input.vk.config.MaxAttempts = 0
+		input.vk.config.RetryDeadline = 1
+		for i := 0; i < 3; i++ {
+			vmMock.PushError(fmt.Errorf("vm is down"))
+		}
+
+		require.NoError(t, input.vk.ExecuteScript(input.ctx, msg))
+		require.True(t, input.vk.IsVMAvailable())
+	}
+
+	// DeliverTx infinite attempts are capped: halts once attempts are exhausted
+	{
+		input.vk.config.FailurePolicy = vmConfig.VMFailurePolicyRetryDeadline
+		input.vk.config.MaxAttempts = 0
+		input.vk.config.DeliverMaxAttempts = 3
+		for i := 0; i < 3; i++ {
+			vmMock.PushError(fmt.Errorf("vm is down"))
+		}
+
+		require.Panics(t, func() {
+			input.vk.ExecuteScript(input.ctx, msg)
+		})
+		require.False(t, input.vk.IsVMAvailable())
+
+		require.NoError(t, input.vk.ExecuteScript(input.ctx, msg))
+		require.True(t, input.vk.IsVMAvailable())
+	}
+}
+
+// VM health probe with mocked VM.
+func TestKeeper_VMHealthProbe(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	vmMock := LaunchVMMockServer(&input)
+	defer vmMock.Stop()
+
+	input.vk.config.HealthCheckTimeout = vmConfig.DefaultHealthCheckTimeout
+
+	require.NoError(t, input.vk.probeVM(log.NewNopLogger()))
+	require.True(t, input.vk.IsVMAvailable())
+
+	vmMock.PushError(fmt.Errorf("vm is down"))
+	require.Error(t, input.vk.probeVM(log.NewNopLogger()))
+	require.False(t, input.vk.IsVMAvailable())
+
+	require.NoError(t, input.vk.probeVM(log.NewNopLogger()))
+	require.True(t, input.vk.IsVMAvailable())
+
+	require.True(t, input.vk.IsVMRoute(types.NewMsgExecuteScript(sdk.AccAddress(randomValue(20)), randomValue(32), nil).Route()))
+}
@@ -350,7 +350,7 @@ func TestKeeper_DeployModule(t *testing.T) {
 
 	require.Equal(t, events[1].Type, types.EventTypeMoveEvent, "script after execution doesn't contain event with amount")
 
-	require.Len(t, events[1].Attributes, 4)
+	require.Len(t, events[1].Attributes, 5)
 	require.EqualValues(t, events[1].Attributes[1].Key, types.AttrKeySequenceNumber)
 	require.EqualValues(t, events[1].Attributes[1].Value, "0")
 	require.EqualValues(t, events[1].Attributes[2].Key, types.AttrKeyType)
@@ -361,6 +361,8 @@ func TestKeeper_DeployModule(t *testing.T) {
 	binary.LittleEndian.PutUint64(uintBz, uint64(110))
 
 	require.EqualValues(t, events[1].Attributes[3].Value, "0x"+hex.EncodeToString(uintBz))
+	require.EqualValues(t, events[1].Attributes[4].Key, types.AttrKeyDecodedData)
+	require.EqualValues(t, events[1].Attributes[4].Value, "110")
 }
 
 // Test oracle price return.
@@ -450,7 +452,7 @@ func TestKeeper_ScriptOracle(t *testing.T) {
 	events := input.ctx.EventManager().Events()
 	require.Contains(t, events, types.NewEventKeep())
 
-	require.Len(t, events[1].Attributes, 4)
+	require.Len(t, events[1].Attributes, 5)
 	require.EqualValues(t, events[1].Attributes[1].Key, types.AttrKeySequenceNumber)
 	require.EqualValues(t, events[1].Attributes[1].Value, "0")
 	require.EqualValues(t, events[1].Attributes[2].Key, types.AttrKeyType)
@@ -461,6 +463,8 @@ func TestKeeper_ScriptOracle(t *testing.T) {
 
 	binary.LittleEndian.PutUint64(bz, 100)
 	require.EqualValues(t, events[1].Attributes[3].Value, "0x"+hex.EncodeToString(bz))
+	require.EqualValues(t, events[1].Attributes[4].Key, types.AttrKeyDecodedData)
+	require.EqualValues(t, events[1].Attributes[4].Value, "100")
 
 	checkNoErrors(events, t)
 }
@@ -540,3 +544,75 @@ func TestKeeper_ErrorScript(t *testing.T) {
 	require.True(t, getAcc.GetCoins().IsEqual(coins))
 	require.Len(t, events, 2)
 }
+
+// Benchmark send script execution (stdlib modules and accounts are read via DS server) with and without DS read cache.
+func BenchmarkKeeper_ExecuteScriptDSCache(b *testing.B) {
+	config := sdk.GetConfig()
+	dnodeConfig.InitBechPrefixes(config)
+
+	benchmarks := []struct {
+		name      string
+		cacheSize int
+	}{
+		{name: "NoCache", cacheSize: 0},
+		{name: "Cache", cacheSize: dnodeConfig.DefaultDSCacheSize},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			input := setupTestInput(false)
+			input.vk.dsServer.cache = newDSCache(bm.cacheSize)
+
+			// launch docker
+			client, compiler, vm := launchDocker(input.dsPort, b)
+			defer input.vk.CloseConnections()
+			defer stopDocker(b, client, compiler)
+			defer stopDocker(b, client, vm)
+
+			addr1 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+			acc1 := input.ak.NewAccountWithAddress(input.ctx, addr1)
+			acc1.SetCoins(sdk.NewCoins(
+				sdk.NewCoin("dfi", sdk.NewInt(1000000000)),
+				sdk.NewCoin("eth", sdk.NewInt(1000000000)),
+				sdk.NewCoin("btc", sdk.NewInt(1000000000)),
+				sdk.NewCoin("usdt", sdk.NewInt(1000000000)),
+			))
+			input.ak.SetAccount(input.ctx, acc1)
+
+			addr2 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+			input.ak.SetAccount(input.ctx, input.ak.NewAccountWithAddress(input.ctx, addr2))
+
+			input.vk.InitGenesis(input.ctx, getGenesis(b))
+			input.vk.SetDSContext(input.ctx)
+			input.vk.StartDSServer(input.ctx)
+			time.Sleep(2 * time.Second)
+
+			require.NoError(b, waitStarted(client, compiler.ID, 5*time.Second))
+			require.NoError(b, waitStarted(client, vm.ID, 5*time.Second))
+			require.NoError(b, waitReachable(*vmCompiler, 5*time.Second))
+			require.NoError(b, waitReachable(*vmAddress, 5*time.Second))
+
+			bytecode, err := compilerClient.Compile(*vmCompiler, &vm_grpc.MvIrSourceFile{
+				Text:    sendScript,
+				Address: common_vm.Bech32ToLibra(addr1),
+				Type:    vm_grpc.ContractType_Script,
+			})
+			require.NoError(b, err)
+
+			args := []types.ScriptArg{{Value: addr2.String(), Type: vm_grpc.VMTypeTag_Address}}
+			for i := 0; i < 4; i++ {
+				args = append(args, types.ScriptArg{Value: "1", Type: vm_grpc.VMTypeTag_U128})
+			}
+			msgScript := types.NewMsgExecuteScript(addr1, bytecode, args)
+
+			// genesis and accounts are "committed", block is being executed
+			ctx := input.ctx.WithBlockHeight(input.ctx.BlockHeight() + 1)
+			input.vk.ResetDSCache(ctx.BlockHeight())
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				require.NoError(b, input.vk.ExecuteScript(ctx, msgScript))
+			}
+		})
+	}
+}
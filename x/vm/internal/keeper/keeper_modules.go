@@ -0,0 +1,156 @@
+// Deployed modules registry.
+package keeper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+
+	"github.com/dfinance/dnode/x/common_vm"
+	"github.com/dfinance/dnode/x/vm/internal/types"
+)
+
+// Get module metadata by deployer address and module name.
+func (keeper Keeper) GetModule(ctx sdk.Context, address sdk.AccAddress, name string) (types.ModuleMetadata, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+
+	bz := store.Get(types.GetModuleKey(address, name))
+	if bz == nil {
+		return types.ModuleMetadata{}, false
+	}
+
+	var module types.ModuleMetadata
+	keeper.cdc.MustUnmarshalBinaryBare(bz, &module)
+
+	return module, true
+}
+
+// Get module bytecode by deployer address and module name.
+func (keeper Keeper) GetModuleCode(ctx sdk.Context, address sdk.AccAddress, name string) ([]byte, error) {
+	module, found := keeper.GetModule(ctx, address, name)
+	if !found {
+		return nil, sdkErrors.Wrapf(types.ErrModuleNotFound, "%s: %s", address, name)
+	}
+
+	path, err := hex.DecodeString(module.Path)
+	if err != nil || len(path) == 0 {
+		return nil, sdkErrors.Wrapf(types.ErrModuleNotFound, "%s: %s: bytecode path not found", address, name)
+	}
+
+	code := keeper.GetValue(ctx, &vm_grpc.VMAccessPath{
+		Address: common_vm.Bech32ToLibra(address),
+		Path:    path,
+	})
+	if code == nil {
+		return nil, sdkErrors.Wrapf(types.ErrModuleNotFound, "%s: %s: bytecode not found", address, name)
+	}
+
+	return code, nil
+}
+
+// Get modules metadata deployed by address.
+func (keeper Keeper) GetModules(ctx sdk.Context, address sdk.AccAddress) types.ModulesMetadata {
+	return keeper.iterateModules(ctx, types.GetModulesAddressPrefix(address))
+}
+
+// Get all deployed modules metadata.
+func (keeper Keeper) GetAllModules(ctx sdk.Context) types.ModulesMetadata {
+	return keeper.iterateModules(ctx, append(types.KeyModulesPrefix, common_vm.KeyDelimiter...))
+}
+
+// Set module metadata.
+func (keeper Keeper) setModule(ctx sdk.Context, module types.ModuleMetadata) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(types.GetModuleKey(module.Deployer, module.Name), keeper.cdc.MustMarshalBinaryBare(module))
+}
+
+// Register successfully deployed module, bytecode path is taken from execution write set.
+func (keeper Keeper) registerModule(ctx sdk.Context, msg types.MsgDeployModule, writeSet []*vm_grpc.VMValue) {
+	name, err := types.GetModuleName(msg.Module)
+	if err != nil {
+		keeper.Logger(ctx).Error(fmt.Sprintf("module from %s is deployed, but not registered: %v", msg.Signer, err))
+		return
+	}
+
+	// policy: msg policy, current policy on upgrade or default one.
+	policy := msg.Policy
+	if policy == "" {
+		if prevModule, found := keeper.GetModule(ctx, msg.Signer, name); found && prevModule.Policy != "" {
+			policy = prevModule.Policy
+		} else if msg.Signer.Equals(types.PrivilegedModulesAddress) {
+			policy = types.ModulePolicyMultisig
+		} else {
+			policy = types.DefaultModulePolicy
+		}
+	}
+
+	codeHash := sha256.Sum256(msg.Module)
+	module := types.ModuleMetadata{
+		Deployer: msg.Signer,
+		Name:     name,
+		Height:   ctx.BlockHeight(),
+		CodeHash: hex.EncodeToString(codeHash[:]),
+		Policy:   policy,
+	}
+
+	for _, value := range writeSet {
+		if value.Type == vm_grpc.VmWriteOp_Value && bytes.Equal(value.Value, msg.Module) {
+			module.Path = hex.EncodeToString(value.Path.Path)
+			break
+		}
+	}
+
+	keeper.setModule(ctx, module)
+}
+
+// Check module could be deployed / upgraded at address according to its upgrade policy.
+func (keeper Keeper) checkModuleUpgrade(ctx sdk.Context, address sdk.AccAddress, code []byte, viaMultisig bool) error {
+	if !viaMultisig && address.Equals(types.PrivilegedModulesAddress) {
+		return sdkErrors.Wrap(types.ErrModuleUpgradeForbidden, "privileged modules could be deployed only via multisig call")
+	}
+
+	// module without readable name can't be checked against its upgrade policy, so it is rejected.
+	name, err := types.GetModuleName(code)
+	if err != nil {
+		return sdkErrors.Wrapf(types.ErrModuleUpgradeForbidden, "reading module name: %v", err)
+	}
+
+	module, found := keeper.GetModule(ctx, address, name)
+	if !found {
+		return nil
+	}
+
+	switch module.Policy {
+	case types.ModulePolicyImmutable:
+		return sdkErrors.Wrapf(types.ErrModuleUpgradeForbidden, "module %q is immutable", name)
+
+	case types.ModulePolicyMultisig:
+		if !viaMultisig {
+			return sdkErrors.Wrapf(types.ErrModuleUpgradeForbidden, "module %q could be upgraded only via multisig call", name)
+		}
+	}
+
+	return nil
+}
+
+// Iterate over modules registry by key prefix.
+func (keeper Keeper) iterateModules(ctx sdk.Context, prefix []byte) types.ModulesMetadata {
+	var modules types.ModulesMetadata
+
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var module types.ModuleMetadata
+		keeper.cdc.MustUnmarshalBinaryBare(iterator.Value(), &module)
+		modules = append(modules, module)
+	}
+
+	return modules
+}
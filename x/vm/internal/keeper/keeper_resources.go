@@ -0,0 +1,48 @@
+// Resources stored in VM storage under address.
+package keeper
+
+import (
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+
+	"github.com/dfinance/dnode/x/common_vm"
+	"github.com/dfinance/dnode/x/vm/internal/types"
+)
+
+// Iterate over values stored under VM address (ordered by path), iteration stops if handler returns true.
+func (keeper Keeper) IterateResources(ctx sdk.Context, address []byte, handler func(path, value []byte) (stop bool)) {
+	prefix := common_vm.MakePathKey(&vm_grpc.VMAccessPath{Address: address})
+
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if handler(iterator.Key()[len(prefix):], iterator.Value()) {
+			break
+		}
+	}
+}
+
+// Get resources stored under VM address by page (starting from 1) and limit, iteration stops once the page is filled.
+func (keeper Keeper) GetResources(ctx sdk.Context, address []byte, page, limit int) []types.QueryResource {
+	resources := make([]types.QueryResource, 0)
+	start, end := (page-1)*limit, page*limit
+
+	idx := 0
+	keeper.IterateResources(ctx, address, func(path, value []byte) bool {
+		if idx >= start {
+			resources = append(resources, types.QueryResource{
+				Path:  hex.EncodeToString(path),
+				Value: hex.EncodeToString(value),
+			})
+		}
+		idx++
+
+		return idx >= end
+	})
+
+	return resources
+}
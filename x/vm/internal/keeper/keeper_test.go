@@ -3,14 +3,22 @@
 package keeper
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
 	"testing"
 
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/dfinance/dvm-proto/go/vm_grpc"
 
+	"github.com/dfinance/dnode/helpers/tests"
+	"github.com/dfinance/dnode/x/common_vm"
 	"github.com/dfinance/dnode/x/vm/internal/types"
 )
 
@@ -41,6 +49,129 @@ func TestKeeper_DeployContractMock(t *testing.T) {
 	require.EqualValues(t, types.StatusKeep, events[0].Attributes[0].Value)
 }
 
+// Deployed module is registered with mocked VM.
+func TestKeeper_DeployContractRegistryMock(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	acc := sdk.AccAddress(randomValue(20))
+
+	codeBytes, err := hex.DecodeString(moveCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	require.Empty(t, input.vk.GetModules(input.ctx, acc))
+
+	msg := types.NewMsgDeployModule(acc, codeBytes)
+	require.NoError(t, input.vk.DeployContract(input.ctx, msg))
+
+	// metadata
+	module, found := input.vk.GetModule(input.ctx, acc, "EarmarkedLibraCoin")
+	require.True(t, found)
+	require.Equal(t, acc, module.Deployer)
+	require.Equal(t, input.ctx.BlockHeight(), module.Height)
+	require.NotEmpty(t, module.CodeHash)
+	require.NotEmpty(t, module.Path)
+
+	// list
+	modules := input.vk.GetModules(input.ctx, acc)
+	require.Len(t, modules, 1)
+	require.Equal(t, module, modules[0])
+	require.Empty(t, input.vk.GetModules(input.ctx, sdk.AccAddress(randomValue(20))))
+
+	// bytecode
+	code, err := input.vk.GetModuleCode(input.ctx, acc, module.Name)
+	require.NoError(t, err)
+	require.Equal(t, codeBytes, code)
+
+	_, err = input.vk.GetModuleCode(input.ctx, acc, "NotExisting")
+	require.True(t, types.ErrModuleNotFound.Is(err))
+
+	// genesis export
+	state := input.vk.ExportGenesis(input.ctx)
+	require.Len(t, state.Modules, 1)
+	require.Equal(t, module, state.Modules[0])
+}
+
+// Module upgrade policies with mocked VM.
+func TestKeeper_ModuleUpgradePolicyMock(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	codeBytes, err := hex.DecodeString(moveCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moduleName := "EarmarkedLibraCoin"
+
+	// owner-upgradable (default policy)
+	{
+		acc := sdk.AccAddress(randomValue(20))
+
+		require.NoError(t, input.vk.DeployContract(input.ctx, types.NewMsgDeployModule(acc, codeBytes)))
+		module, _ := input.vk.GetModule(input.ctx, acc, moduleName)
+		require.Equal(t, types.DefaultModulePolicy, module.Policy)
+
+		require.NoError(t, input.vk.DeployContract(input.ctx, types.NewMsgDeployModule(acc, codeBytes)))
+		module, _ = input.vk.GetModule(input.ctx, acc, moduleName)
+		require.Equal(t, types.DefaultModulePolicy, module.Policy)
+	}
+
+	// immutable
+	{
+		acc := sdk.AccAddress(randomValue(20))
+
+		msg := types.NewMsgDeployModule(acc, codeBytes).WithPolicy(types.ModulePolicyImmutable)
+		require.NoError(t, input.vk.DeployContract(input.ctx, msg))
+
+		err := input.vk.DeployContract(input.ctx, types.NewMsgDeployModule(acc, codeBytes))
+		require.True(t, types.ErrModuleUpgradeForbidden.Is(err))
+
+		err = input.vk.UpgradeModule(input.ctx, types.NewMsgUpgradeModule(acc, codeBytes, ""))
+		require.True(t, types.ErrModuleUpgradeForbidden.Is(err))
+	}
+
+	// multisig only
+	{
+		acc := sdk.AccAddress(randomValue(20))
+
+		msg := types.NewMsgDeployModule(acc, codeBytes).WithPolicy(types.ModulePolicyMultisig)
+		require.NoError(t, input.vk.DeployContract(input.ctx, msg))
+
+		err := input.vk.DeployContract(input.ctx, types.NewMsgDeployModule(acc, codeBytes))
+		require.True(t, types.ErrModuleUpgradeForbidden.Is(err))
+
+		require.NoError(t, input.vk.UpgradeModule(input.ctx, types.NewMsgUpgradeModule(acc, codeBytes, "")))
+		module, _ := input.vk.GetModule(input.ctx, acc, moduleName)
+		require.Equal(t, types.ModulePolicyMultisig, module.Policy)
+	}
+
+	// privileged address
+	{
+		acc := types.PrivilegedModulesAddress
+
+		err := input.vk.DeployContract(input.ctx, types.NewMsgDeployModule(acc, codeBytes))
+		require.True(t, types.ErrModuleUpgradeForbidden.Is(err))
+
+		require.NoError(t, input.vk.UpgradeModule(input.ctx, types.NewMsgUpgradeModule(acc, codeBytes, "")))
+		module, found := input.vk.GetModule(input.ctx, acc, moduleName)
+		require.True(t, found)
+		require.Equal(t, types.ModulePolicyMultisig, module.Policy)
+	}
+
+	// module name can't be read: upgrade policy can't be checked
+	{
+		acc := sdk.AccAddress(randomValue(20))
+
+		err := input.vk.DeployContract(input.ctx, types.NewMsgDeployModule(acc, randomValue(32)))
+		require.True(t, types.ErrModuleUpgradeForbidden.Is(err))
+
+		err = input.vk.UpgradeModule(input.ctx, types.NewMsgUpgradeModule(acc, randomValue(32), ""))
+		require.True(t, types.ErrModuleUpgradeForbidden.Is(err))
+	}
+}
+
 // Deploy script execute with mocked VM.
 func TestKeeper_ExecuteScriptMock(t *testing.T) {
 	input := setupTestInput(true)
@@ -68,15 +199,50 @@ func TestKeeper_ExecuteScriptMock(t *testing.T) {
 	require.EqualValues(t, types.StatusKeep, events[0].Attributes[0].Value)
 }
 
+// Read-only script execution with mocked VM: write set must not be applied.
+func TestKeeper_ExecuteScriptViewMock(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	acc := sdk.AccAddress(randomValue(20))
+
+	codeBytes, err := hex.DecodeString(moveCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := types.NewMsgExecuteScript(acc, codeBytes, nil)
+
+	resp, err := input.vk.ExecuteScriptView(input.ctx, msg, 0)
+	require.NoError(t, err)
+
+	require.Equal(t, types.StatusKeep, resp.Status)
+	require.EqualValues(t, 10000, resp.GasUsed)
+	require.Len(t, resp.Events, 1)
+	require.EqualValues(t, types.EventTypeMoveEvent, resp.Events[0].Type)
+	require.Len(t, resp.WriteSet, 2)
+
+	for _, op := range resp.WriteSet {
+		address, err := hex.DecodeString(op.Address)
+		require.NoError(t, err)
+		path, err := hex.DecodeString(op.Path)
+		require.NoError(t, err)
+
+		require.False(t, input.vk.HasValue(input.ctx, &vm_grpc.VMAccessPath{Address: address, Path: path}))
+	}
+
+	require.Empty(t, input.ctx.EventManager().Events())
+}
+
 // Check genesis Import / Export functionality
 func TestKeeper_ExportGenesis(t *testing.T) {
 	input := setupTestInput(true)
 	defer closeInput(input)
 
-	// check export with no initial genesis
-	{
-		outputState := input.vk.ExportGenesis(input.ctx)
-		require.Empty(t, outputState.WriteSet)
+	// check export with no initial genesis: only values written on test input setup (currencies) are exported
+	setupWriteSet := input.vk.ExportGenesis(input.ctx).WriteSet
+	for _, writeOp := range setupWriteSet {
+		require.Equal(t, hex.EncodeToString(common_vm.ZeroAddress), writeOp.Address)
 	}
 
 	// initial state
@@ -95,16 +261,380 @@ func TestKeeper_ExportGenesis(t *testing.T) {
 		},
 	}
 
-	// add non-init WriteSets
-	input.vk.SetValue(input.ctx, &vm_grpc.VMAccessPath{
+	// add non-init WriteSets: non-standard address length and VM address with delimiter in path
+	nonInitPath1 := &vm_grpc.VMAccessPath{
 		Address: []byte("616464726573735f33"), // address_3
 		Path:    []byte("706174685f33"),       // path_3
-	}, []byte("76616c75655f33")) // value_3
+	}
+	input.vk.SetValue(input.ctx, nonInitPath1, []byte("76616c75655f33")) // value_3
+
+	nonInitPath2 := &vm_grpc.VMAccessPath{
+		Address: common_vm.Bech32ToLibra(sdk.AccAddress(randomValue(20))),
+		Path:    append([]byte{0x1}, common_vm.KeyDelimiter...),
+	}
+	input.vk.SetValue(input.ctx, nonInitPath2, []byte{0x2})
+
+	nonInitWriteSet := []types.GenesisWriteOp{
+		{
+			Address: hex.EncodeToString(nonInitPath1.Address),
+			Path:    hex.EncodeToString(nonInitPath1.Path),
+			Value:   hex.EncodeToString([]byte("76616c75655f33")),
+		},
+		{
+			Address: hex.EncodeToString(nonInitPath2.Address),
+			Path:    hex.EncodeToString(nonInitPath2.Path),
+			Value:   "02",
+		},
+	}
+
+	// check export with initial genesis: whole VM storage is exported
+	{
+		input.vk.InitGenesis(input.ctx, input.cdc.MustMarshalJSON(inputState))
+		outputState := input.vk.ExportGenesis(input.ctx)
+		require.NotNil(t, outputState.Parameters)
+		require.Equal(t, types.DefaultParams(), *outputState.Parameters)
+		expectedWriteSet := append(append(setupWriteSet, inputState.WriteSet...), nonInitWriteSet...)
+		require.ElementsMatch(t, expectedWriteSet, outputState.WriteSet)
 
-	// check export with initial genesis
+		// only genesis initialized marker is stored
+		require.Equal(t, []byte{1}, input.ctx.KVStore(input.vk.storeKey).Get(types.KeyGenesis))
+	}
+
+	// check params import / export
 	{
+		params := types.NewParams(2, 1000, 2000, 4096)
+		inputState.Parameters = &params
+
 		input.vk.InitGenesis(input.ctx, input.cdc.MustMarshalJSON(inputState))
+		require.Equal(t, params, input.vk.GetParams(input.ctx))
+
 		outputState := input.vk.ExportGenesis(input.ctx)
-		require.Equal(t, inputState, outputState)
+		require.Equal(t, inputState.Parameters, outputState.Parameters)
+	}
+
+	// check exported state import (deleted value is not exported)
+	{
+		input.vk.DelValue(input.ctx, nonInitPath1)
+		exportedState := input.vk.ExportGenesis(input.ctx)
+		require.Len(t, exportedState.WriteSet, len(setupWriteSet)+len(inputState.WriteSet)+1)
+
+		input2 := setupTestInput(true)
+		defer closeInput(input2)
+
+		input2.vk.InitGenesis(input2.ctx, input2.cdc.MustMarshalJSON(exportedState))
+		require.Equal(t, []byte{0x2}, input2.vk.GetValue(input2.ctx, nonInitPath2))
+		require.False(t, input2.vk.HasValue(input2.ctx, nonInitPath1))
+		require.Equal(t, exportedState, input2.vk.ExportGenesis(input2.ctx))
+	}
+}
+
+// Reset modules deploy height for zero height export.
+func TestKeeper_PrepForZeroHeightGenesis(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	module := types.ModuleMetadata{
+		Deployer: sdk.AccAddress(randomValue(20)),
+		Name:     "Module",
+		Height:   10,
+		Policy:   types.DefaultModulePolicy,
+	}
+	input.vk.setModule(input.ctx, module)
+
+	input.vk.PrepForZeroHeightGenesis(input.ctx)
+
+	module.Height = 0
+	state := input.vk.ExportGenesis(input.ctx)
+	require.Len(t, state.Modules, 1)
+	require.Equal(t, module, state.Modules[0])
+}
+
+// Iterate and paginate resources stored under address.
+func TestKeeper_GetResources(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	address := common_vm.Bech32ToLibra(sdk.AccAddress(randomValue(20)))
+	otherAddress := common_vm.Bech32ToLibra(sdk.AccAddress(randomValue(20)))
+
+	for i := byte(0); i < 5; i++ {
+		input.vk.SetValue(input.ctx, &vm_grpc.VMAccessPath{Address: address, Path: []byte{0x1, i}}, []byte{i})
+	}
+	input.vk.SetValue(input.ctx, &vm_grpc.VMAccessPath{Address: otherAddress, Path: []byte{0x1}}, []byte{0xFF})
+
+	// iterate all
+	{
+		var paths [][]byte
+		input.vk.IterateResources(input.ctx, address, func(path, value []byte) bool {
+			require.Equal(t, []byte{path[1]}, value)
+			paths = append(paths, append([]byte{}, path...))
+			return false
+		})
+		require.Len(t, paths, 5)
+		require.Equal(t, []byte{0x1, 0x0}, paths[0])
+		require.Equal(t, []byte{0x1, 0x4}, paths[4])
+	}
+
+	// stop iteration
+	{
+		count := 0
+		input.vk.IterateResources(input.ctx, address, func(_, _ []byte) bool {
+			count++
+			return count == 2
+		})
+		require.Equal(t, 2, count)
+	}
+
+	// pagination
+	{
+		resources := input.vk.GetResources(input.ctx, address, 1, 2)
+		require.Len(t, resources, 2)
+		require.Equal(t, "0100", resources[0].Path)
+		require.Equal(t, "00", resources[0].Value)
+
+		resources = input.vk.GetResources(input.ctx, address, 3, 2)
+		require.Len(t, resources, 1)
+		require.Equal(t, "0104", resources[0].Path)
+
+		resources = input.vk.GetResources(input.ctx, address, 4, 2)
+		require.Empty(t, resources)
+
+		resources = input.vk.GetResources(input.ctx, common_vm.Bech32ToLibra(sdk.AccAddress(randomValue(20))), 1, 2)
+		require.Empty(t, resources)
+	}
+}
+
+// Update params via multisig message.
+func TestKeeper_UpdateParams(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	require.Equal(t, types.DefaultParams(), input.vk.GetParams(input.ctx))
+
+	params := types.NewParams(3, 500000, 700000, 32*1024)
+	require.NoError(t, input.vk.UpdateParams(input.ctx, types.NewMsgUpdateParams(params)))
+	require.Equal(t, params, input.vk.GetParams(input.ctx))
+
+	err := input.vk.UpdateParams(input.ctx, types.NewMsgUpdateParams(types.Params{}))
+	require.True(t, types.ErrWrongParams.Is(err))
+	require.Equal(t, params, input.vk.GetParams(input.ctx))
+
+	// params are not set (chain started before VM params were added)
+	{
+		db := dbm.NewMemDB()
+		mstore := store.NewCommitMultiStore(db)
+		mstore.MountStoreWithDB(input.keyParams, sdk.StoreTypeIAVL, db)
+		mstore.MountStoreWithDB(input.tkeyParams, sdk.StoreTypeTransient, db)
+		require.NoError(t, mstore.LoadLatestVersion())
+
+		ctx := sdk.NewContext(mstore, abci.Header{ChainID: "dn-testnet-vm-keeper-test"}, false, log.NewNopLogger())
+		require.Equal(t, types.DefaultParams(), input.vk.GetParams(ctx))
+	}
+}
+
+// Execute script with in-process VM mock server reading data via DS server.
+func TestKeeper_VMMockServer(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	vmMock := LaunchVMMockServer(&input)
+	defer vmMock.Stop()
+
+	acc := sdk.AccAddress(randomValue(20))
+	srcPath := &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(acc), Path: []byte{0, 1}}
+	dstPath := &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(acc), Path: []byte{0, 2}}
+	input.vk.SetValue(input.ctx, srcPath, []byte{0x2A})
+
+	// handler copies value from source path to destination path (read via DS server)
+	vmMock.SetExecHandler(func(contract *vm_grpc.VMContract, ds tests.VMMockDataSource) (*vm_grpc.VMExecuteResponse, error) {
+		value, err := ds.GetRaw(srcPath.Address, srcPath.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		writeSet := []*vm_grpc.VMValue{{Type: vm_grpc.VmWriteOp_Value, Path: dstPath, Value: value}}
+		events := []*vm_grpc.VMEvent{{Key: []byte("copy"), Type: &vm_grpc.VMType{Tag: vm_grpc.VMTypeTag_U8}, EventData: value}}
+
+		return tests.NewVMMockKeepResponse(100, writeSet, events), nil
+	})
+
+	msg := types.NewMsgExecuteScript(acc, randomValue(32), nil)
+	input.vk.SetDSContext(input.ctx)
+	require.NoError(t, input.vk.ExecuteScript(input.ctx, msg))
+	require.Equal(t, []byte{0x2A}, input.vk.GetValue(input.ctx, dstPath))
+	require.Len(t, vmMock.Requests(), 1)
+
+	events := input.ctx.EventManager().Events()
+	require.Len(t, events, 2)
+	require.Equal(t, types.NewEventKeep(), events[0])
+	require.EqualValues(t, types.AttrKeyDecodedData, events[1].Attributes[4].Key)
+	require.EqualValues(t, "42", events[1].Attributes[4].Value)
+
+	// canned discard response
+	vmMock.PushResponse(tests.NewVMMockDiscardResponse(4016, 0, "discarded"))
+	ctx := input.ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, input.vk.ExecuteScript(ctx, msg))
+	require.Equal(t, types.StatusDiscard, string(ctx.EventManager().Events()[0].Attributes[0].Value))
+}
+
+// Batch of module and script is executed contract by contract with mocked VM, failed contract aborts the whole batch.
+func TestKeeper_ExecuteBatchMock(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	vmMock := LaunchVMMockServer(&input)
+	defer vmMock.Stop()
+
+	acc := sdk.AccAddress(randomValue(20))
+	codeBytes, err := hex.DecodeString(moveCode)
+	require.NoError(t, err)
+
+	msg := types.NewMsgExecuteBatch(acc, types.NewBatchModule(codeBytes), types.NewBatchScript(randomValue(32), nil))
+
+	// successful batch
+	{
+		ctx := input.ctx.WithEventManager(sdk.NewEventManager())
+		require.NoError(t, input.vk.ExecuteBatch(ctx, msg))
+
+		requests := vmMock.Requests()
+		require.Len(t, requests, 2)
+		require.Len(t, requests[0].Contracts, 1)
+		require.Len(t, requests[1].Contracts, 1)
+		require.Equal(t, vm_grpc.ContractType_Module, requests[0].Contracts[0].ContractType)
+		require.Equal(t, vm_grpc.ContractType_Script, requests[1].Contracts[0].ContractType)
+
+		_, found := input.vk.GetModule(ctx, acc, "EarmarkedLibraCoin")
+		require.True(t, found)
+
+		events := ctx.EventManager().Events()
+		require.Len(t, events, 2)
+		require.Equal(t, types.NewEventKeep(), events[0])
+		require.Equal(t, types.NewEventKeep(), events[1])
+	}
+
+	// aborted batch: first contract write set is not applied
+	{
+		vmMock.Reset()
+		ctx := input.ctx.WithEventManager(sdk.NewEventManager())
+
+		path := &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(acc), Path: randomValue(8)}
+		writeSet := []*vm_grpc.VMValue{{Type: vm_grpc.VmWriteOp_Value, Path: path, Value: randomValue(8)}}
+		vmMock.PushResponse(
+			tests.NewVMMockKeepResponse(1, writeSet, nil),
+			tests.NewVMMockErrorResponse(1, 4016, 0, "aborted"),
+		)
+
+		scriptMsg := types.NewMsgExecuteBatch(acc, types.NewBatchScript(randomValue(32), nil), types.NewBatchScript(randomValue(32), nil))
+		err := input.vk.ExecuteBatch(ctx, scriptMsg)
+		tests.CheckExpectedErr(t, types.ErrBatchAborted, err)
+		require.Contains(t, err.Error(), "contract 1")
+
+		require.False(t, input.vk.HasValue(ctx, path))
+		require.Empty(t, ctx.EventManager().Events())
+	}
+
+	// script reads module deployed earlier in the same batch via DS server
+	{
+		vmMock.Reset()
+		ctx, _ := input.ctx.CacheContext()
+
+		moduleAddr := sdk.AccAddress(randomValue(20))
+		moduleCode := codeBytes
+		codeHash := sha256.Sum256(moduleCode)
+		modulePath := &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(moduleAddr), Path: append([]byte{0x0}, codeHash[:]...)}
+
+		vmMock.SetExecHandler(func(contract *vm_grpc.VMContract, ds tests.VMMockDataSource) (*vm_grpc.VMExecuteResponse, error) {
+			if contract.ContractType == vm_grpc.ContractType_Module {
+				return tests.VMMockDefaultExecHandler(contract, ds)
+			}
+
+			value, err := ds.GetRaw(modulePath.Address, modulePath.Path)
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(value, moduleCode) {
+				return tests.NewVMMockErrorResponse(1, 4008, 0, "module not found"), nil
+			}
+
+			return tests.NewVMMockKeepResponse(1, nil, nil), nil
+		})
+		defer vmMock.SetExecHandler(nil)
+
+		batchMsg := types.NewMsgExecuteBatch(moduleAddr, types.NewBatchModule(moduleCode), types.NewBatchScript(randomValue(32), nil))
+		require.NoError(t, input.vk.ExecuteBatch(ctx, batchMsg))
+		require.True(t, input.vk.HasValue(ctx, modulePath))
+	}
+
+	// module upgrade policy is checked
+	{
+		vmMock.Reset()
+		ctx := input.ctx.WithEventManager(sdk.NewEventManager())
+
+		immutableMsg := types.NewMsgDeployModule(acc, codeBytes).WithPolicy(types.ModulePolicyImmutable)
+		require.NoError(t, input.vk.DeployContract(ctx, immutableMsg))
+
+		err := input.vk.ExecuteBatch(ctx, msg)
+		tests.CheckExpectedErr(t, types.ErrModuleUpgradeForbidden, err)
+		require.Len(t, vmMock.Requests(), 1)
+	}
+}
+
+// Benchmark script execution with mocked VM reading module bytecode sized values via DS server (like VM loads
+// stdlib modules and resources) with and without DS read cache.
+// Mocked counterpart of BenchmarkKeeper_ExecuteScriptDSCache (integ), doesn't require VM docker container.
+func BenchmarkKeeper_ExecuteScriptDSCacheMock(b *testing.B) {
+	const pathsCount, valueLen = 32, 4096
+
+	benchmarks := []struct {
+		name      string
+		cacheSize int
+	}{
+		{name: "NoCache", cacheSize: 0},
+		{name: "Cache", cacheSize: pathsCount},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			input := setupTestInput(true)
+			defer closeInput(input)
+
+			vmMock := LaunchVMMockServer(&input)
+			defer vmMock.Stop()
+
+			input.vk.dsServer.cache = newDSCache(bm.cacheSize)
+
+			// storage is "committed", block is being executed
+			ctx := input.ctx.WithBlockHeight(1)
+			input.vk.ResetDSCache(ctx.BlockHeight())
+
+			paths := make([]*vm_grpc.VMAccessPath, 0, pathsCount)
+			for i := 0; i < pathsCount; i++ {
+				path := randomPath()
+				input.vk.setValue(ctx, path, randomValue(valueLen))
+				paths = append(paths, path)
+			}
+
+			vmMock.SetExecHandler(func(_ *vm_grpc.VMContract, ds tests.VMMockDataSource) (*vm_grpc.VMExecuteResponse, error) {
+				for _, path := range paths {
+					if _, err := ds.GetRaw(path.Address, path.Path); err != nil {
+						return nil, err
+					}
+				}
+
+				return tests.NewVMMockKeepResponse(1, nil, nil), nil
+			})
+
+			// values written within the block are not cached
+			input.vk.ResetDSCache(ctx.BlockHeight())
+
+			msg := types.NewMsgExecuteScript(sdk.AccAddress(randomValue(20)), randomValue(32), nil)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := input.vk.ExecuteScript(ctx, msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
 	}
 }
@@ -0,0 +1,45 @@
+// Read-only script execution.
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/dfinance/dnode/x/vm/internal/types"
+)
+
+// Execute script without applying write set, returns execution result (events, write set, gas used).
+// Context is expected to be a query (read-only) context, gas is limited by {gasLimit}.
+func (keeper Keeper) ExecuteScriptView(ctx sdk.Context, msg types.MsgExecuteScript, gasLimit uint64) (types.QueryCallScriptResp, error) {
+	if gasLimit == 0 {
+		gasLimit = types.DefaultViewGasLimit
+	}
+
+	// query context has infinite gas meter, so free gas couldn't be calculated.
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(gasLimit))
+
+	req, err := NewExecuteRequest(ctx, msg, keeper.GetParams(ctx))
+	if err != nil {
+		return types.QueryCallScriptResp{}, sdkErrors.Wrap(types.ErrInternal, err.Error())
+	}
+
+	// no retries for queries: VM is not available / busy - just return error.
+	resp, err := keeper.retryExecReq(ctx, RetryExecReq{
+		Raw:             req,
+		CurrentTimeout:  keeper.config.MaxBackoff,
+		MaxAttempts:     1,
+		ExecWaitTimeout: types.ExecWaitTimeout,
+	})
+	if err != nil {
+		keeper.Logger(ctx).Error(fmt.Sprintf("grpc error: %s", err.Error()))
+		return types.QueryCallScriptResp{}, sdkErrors.Wrap(types.ErrVMCrashed, err.Error())
+	}
+
+	if len(resp.Executions) != 1 {
+		return types.QueryCallScriptResp{}, sdkErrors.Wrapf(types.ErrWrongExecutionResponse, "%v", *resp)
+	}
+
+	return types.NewQueryCallScriptResp(resp.Executions[0]), nil
+}
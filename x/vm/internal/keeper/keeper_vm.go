@@ -13,6 +13,7 @@ import (
 
 	"github.com/dfinance/dvm-proto/go/vm_grpc"
 
+	"github.com/dfinance/dnode/helpers/metrics"
 	"github.com/dfinance/dnode/x/common_vm"
 	"github.com/dfinance/dnode/x/vm/internal/types"
 )
@@ -23,6 +24,7 @@ func (keeper Keeper) setValue(ctx sdk.Context, accessPath *vm_grpc.VMAccessPath,
 	key := common_vm.MakePathKey(accessPath)
 
 	store.Set(key, value)
+	keeper.invalidateDSCache(key)
 }
 
 // Check if VM storage has value by access path.
@@ -45,6 +47,24 @@ func (keeper Keeper) DelValue(ctx sdk.Context, accessPath *vm_grpc.VMAccessPath)
 	keeper.delValue(ctx, accessPath)
 }
 
+// Iterate over all VM storage values (ordered by key), iteration stops if handler returns true.
+func (keeper Keeper) IterateValues(ctx sdk.Context, handler func(accessPath *vm_grpc.VMAccessPath, value []byte) (stop bool)) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, append(common_vm.VMKey, common_vm.KeyDelimiter...))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		accessPath, err := common_vm.ParsePathKey(iterator.Key())
+		if err != nil {
+			panic(err)
+		}
+
+		if handler(accessPath, iterator.Value()) {
+			break
+		}
+	}
+}
+
 // Public get path for oracle price.
 func (keeper Keeper) GetOracleAccessPath(assetCode string) *vm_grpc.VMAccessPath {
 	seed := xxhash.NewS64(0)
@@ -94,12 +114,13 @@ func (keeper Keeper) delValue(ctx sdk.Context, accessPath *vm_grpc.VMAccessPath)
 	key := common_vm.MakePathKey(accessPath)
 
 	store.Delete(key)
+	keeper.invalidateDSCache(key)
 }
 
-// Process result of VM module/script execution.
-func (keeper Keeper) processExecution(ctx sdk.Context, exec *vm_grpc.VMExecuteResponse) {
-	// consume gas, if execution took too much gas - panic and mark transaction as out of gas.
-	ctx.GasMeter().ConsumeGas(exec.GasUsed, "vm script/module execution")
+// Process result of VM module/script execution, returns true if write set was applied.
+func (keeper Keeper) processExecution(ctx sdk.Context, exec *vm_grpc.VMExecuteResponse, params types.Params) bool {
+	// consume gas (VM gas is converted by gas unit price), if execution took too much gas - panic and mark transaction as out of gas.
+	ctx.GasMeter().ConsumeGas(params.ToSDKGas(exec.GasUsed), "vm script/module execution")
 
 	// process status
 	if exec.Status == vm_grpc.ContractStatus_Discard {
@@ -110,7 +131,7 @@ func (keeper Keeper) processExecution(ctx sdk.Context, exec *vm_grpc.VMExecuteRe
 		if exec.StatusStruct != nil && exec.StatusStruct.MajorStatus != types.VMCodeExecuted {
 			ctx.EventManager().EmitEvent(types.NewEventError(exec.StatusStruct))
 			types.PrintVMStackTrace(keeper.Logger(ctx), exec)
-			return
+			return false
 		}
 
 		keeper.processWriteSet(ctx, exec.WriteSet)
@@ -118,10 +139,30 @@ func (keeper Keeper) processExecution(ctx sdk.Context, exec *vm_grpc.VMExecuteRe
 		for _, vmEvent := range exec.Events {
 			ctx.EventManager().EmitEvent(types.NewEventFromVM(vmEvent))
 		}
+
+		return true
 	}
+
+	return false
+}
+
+// Observe VM gas used by message executions (metrics).
+func (keeper Keeper) observeGasUsed(msgType string, resp *vm_grpc.VMExecuteResponses) {
+	var gasUsed uint64
+	for _, exec := range resp.Executions {
+		gasUsed += exec.GasUsed
+	}
+
+	metrics.Get().VMGasUsed.With(metrics.LabelMsgType, msgType).Observe(float64(gasUsed))
 }
 
-// Process write set of module/script execution.
+// Register VM execution write set handler.
+// Should be called on app initialization, handlers are processed in registration order.
+func (keeper Keeper) RegisterVMWriteSetHandler(handler common_vm.VMWriteSetHandler) {
+	*keeper.writeSetHandlers = append(*keeper.writeSetHandlers, handler)
+}
+
+// Process write set of module/script execution, registered write set handlers are called after write set is applied.
 func (keeper Keeper) processWriteSet(ctx sdk.Context, writeSet []*vm_grpc.VMValue) {
 	for _, value := range writeSet {
 		// check type and solve what to do.
@@ -136,4 +177,8 @@ func (keeper Keeper) processWriteSet(ctx sdk.Context, writeSet []*vm_grpc.VMValu
 			panic(fmt.Errorf("unknown write op, couldn't happen: %d", value.Type))
 		}
 	}
+
+	for _, handler := range *keeper.writeSetHandlers {
+		handler(ctx, writeSet)
+	}
 }
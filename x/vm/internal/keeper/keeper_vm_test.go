@@ -99,6 +99,30 @@ func TestDelValue(t *testing.T) {
 	require.False(t, isExists)
 }
 
+// Check VM gas used is converted to SDK gas by gas unit price.
+func TestProcessExecution_GasUnitPrice(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	resp := &vm_grpc.VMExecuteResponse{
+		Status:  vm_grpc.ContractStatus_Keep,
+		GasUsed: 100,
+	}
+
+	params := types.DefaultParams()
+	params.GasUnitPrice = 3
+
+	ctx := input.ctx.WithGasMeter(sdk.NewGasMeter(1000))
+	input.vk.processExecution(ctx, resp, params)
+	require.EqualValues(t, 300, ctx.GasMeter().GasConsumed())
+
+	// out of gas
+	resp.GasUsed = 300
+	require.Panics(t, func() {
+		input.vk.processExecution(ctx, resp, params)
+	})
+}
+
 // Check process execution (response from VM) functional.
 func TestProcessExecution(t *testing.T) {
 	// ignoring gas for now.
@@ -114,7 +138,7 @@ func TestProcessExecution(t *testing.T) {
 		},
 	}
 
-	input.vk.processExecution(input.ctx, resp)
+	input.vk.processExecution(input.ctx, resp, types.DefaultParams())
 
 	events := input.ctx.EventManager().Events()
 	event := types.NewEventDiscard(resp.StatusStruct)
@@ -129,7 +153,7 @@ func TestProcessExecution(t *testing.T) {
 	}
 
 	ctx := input.ctx.WithEventManager(sdk.NewEventManager())
-	input.vk.processExecution(ctx, resp)
+	input.vk.processExecution(ctx, resp, types.DefaultParams())
 
 	events = ctx.EventManager().Events()
 	event = types.NewEventDiscard(nil)
@@ -143,7 +167,7 @@ func TestProcessExecution(t *testing.T) {
 	}
 
 	ctx = input.ctx.WithEventManager(sdk.NewEventManager())
-	input.vk.processExecution(ctx, resp)
+	input.vk.processExecution(ctx, resp, types.DefaultParams())
 
 	events = ctx.EventManager().Events()
 	event = types.NewEventKeep()
@@ -197,7 +221,7 @@ func TestProcessExecution(t *testing.T) {
 	}
 
 	ctx = input.ctx.WithEventManager(sdk.NewEventManager())
-	input.vk.processExecution(ctx, resp)
+	input.vk.processExecution(ctx, resp, types.DefaultParams())
 	events = ctx.EventManager().Events()
 
 	// check that everything fine with write set
@@ -229,7 +253,7 @@ func TestProcessExecution(t *testing.T) {
 	}
 
 	ctx = input.ctx.WithEventManager(sdk.NewEventManager())
-	input.vk.processExecution(ctx, resp)
+	input.vk.processExecution(ctx, resp, types.DefaultParams())
 	events = ctx.EventManager().Events()
 
 	require.Len(t, events, 1)
@@ -287,6 +311,33 @@ func TestProcessWriteSet(t *testing.T) {
 	}
 }
 
+// Check registered write set handlers are called after write set is applied.
+func TestProcessWriteSet_Handlers(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	writeSet := []*vm_grpc.VMValue{
+		{
+			Type:  vm_grpc.VmWriteOp_Value,
+			Value: randomValue(32),
+			Path:  randomPath(),
+		},
+	}
+
+	calls := make([]int, 0)
+	for i := 0; i < 2; i++ {
+		handlerIdx := i
+		input.vk.RegisterVMWriteSetHandler(func(ctx sdk.Context, handlerWriteSet []*vm_grpc.VMValue) {
+			require.Equal(t, writeSet, handlerWriteSet)
+			require.Equal(t, writeSet[0].Value, input.vk.getValue(ctx, writeSet[0].Path))
+			calls = append(calls, handlerIdx)
+		})
+	}
+
+	input.vk.processWriteSet(input.ctx, writeSet)
+	require.Equal(t, []int{0, 1}, calls)
+}
+
 // Status keep (4001) still doesn't contains error.
 func TestExecStatusKeeperNotAnError(t *testing.T) {
 	input := setupTestInput(true)
@@ -305,7 +356,7 @@ func TestExecStatusKeeperNotAnError(t *testing.T) {
 		StatusStruct: &errorStatus,
 	}
 
-	input.vk.processExecution(input.ctx, resp)
+	input.vk.processExecution(input.ctx, resp, types.DefaultParams())
 	events := input.ctx.EventManager().Events()
 
 	require.EqualValues(t, types.EventTypeContractStatus, events[0].Type)
@@ -336,7 +387,7 @@ func TestExecKeepAndError(t *testing.T) {
 		StatusStruct: &errorStatus,
 	}
 
-	input.vk.processExecution(input.ctx, resp)
+	input.vk.processExecution(input.ctx, resp, types.DefaultParams())
 	events := input.ctx.EventManager().Events()
 
 	require.EqualValues(t, types.EventTypeContractStatus, events[0].Type)
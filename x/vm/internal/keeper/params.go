@@ -0,0 +1,41 @@
+// Parameters key table implementation for VM parameters store.
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+
+	"github.com/dfinance/dnode/x/vm/internal/types"
+)
+
+// New Paramstore for VM module.
+func NewKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&types.Params{})
+}
+
+// Get params.
+// Default value is used for a param which is not set (chains started before VM params were added).
+func (keeper Keeper) GetParams(ctx sdk.Context) types.Params {
+	params := types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		keeper.paramStore.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+
+	return params
+}
+
+// Set the params.
+func (keeper Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	keeper.paramStore.SetParamSet(ctx, &params)
+}
+
+// Update params via multisig call.
+func (keeper Keeper) UpdateParams(ctx sdk.Context, msg types.MsgUpdateParams) error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	keeper.SetParams(ctx, msg.Params)
+
+	return nil
+}
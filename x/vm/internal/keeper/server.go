@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/tendermint/tendermint/libs/log"
@@ -17,25 +18,43 @@ import (
 	"github.com/dfinance/dvm-proto/go/ds_grpc"
 	"github.com/dfinance/dvm-proto/go/vm_grpc"
 
+	"github.com/dfinance/dnode/cmd/config"
+	"github.com/dfinance/dnode/helpers/metrics"
 	"github.com/dfinance/dnode/x/common_vm"
 	"github.com/dfinance/dnode/x/vm/internal/types"
 )
 
 // Check type.
-var _ ds_grpc.DSServiceServer = DSServer{}
+var _ ds_grpc.DSServiceServer = (*DSServer)(nil)
+
+const (
+	// DS methods names (metrics labels).
+	dsMethodGetRaw      = "get_raw"
+	dsMethodMultiGetRaw = "multi_get_raw"
+)
 
 // Server to catch VM data client requests.
+// DS server has a single endpoint and DVM doesn't identify DS requests by execution, so VM executions are serialized:
+// only one execution runs at a time and its context is used for all DS requests while it is running (see RegisterExecution).
+// This is serialization, not isolation: queries and CheckTx executions wait for the running DeliverTx execution.
+// Default context is used for requests out of VM executions.
 type DSServer struct {
 	ds_grpc.UnimplementedDSServiceServer
 
 	isStarted bool // check if server already listen
 
 	keeper *Keeper
-	ctx    sdk.Context // should be careful with it, but for now we store default context
+	ctx    sdk.Context // default context (used out of VM executions)
 
 	mux sync.Mutex
 
+	execSlot    chan struct{} // serializes VM executions (single slot semaphore)
+	execCtx     sdk.Context   // running execution context
+	execRunning bool          // execution is running
+
 	dataMiddlewares []common_vm.DSDataMiddleware
+
+	cache *dsCache // storage read cache
 }
 
 // Error when no data found.
@@ -48,7 +67,15 @@ func ErrNoData(path *ds_grpc.DSAccessPath) *ds_grpc.DSRawResponse {
 
 // Server logger.
 func (server *DSServer) Logger() log.Logger {
-	return server.ctx.Logger().With("module", fmt.Sprintf("x/%s/dsserver", types.ModuleName))
+	server.mux.Lock()
+	defer server.mux.Unlock()
+
+	return server.logger(server.ctx)
+}
+
+// Server logger for context.
+func (server *DSServer) logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s/dsserver", types.ModuleName))
 }
 
 // Register new data middleware.
@@ -57,10 +84,15 @@ func (server *DSServer) RegisterDataMiddleware(md common_vm.DSDataMiddleware) {
 }
 
 // Process middlewares.
-func (server DSServer) processMiddlewares(path *vm_grpc.VMAccessPath) (data []byte, err error) {
+func (server *DSServer) processMiddlewares(ctx sdk.Context, path *vm_grpc.VMAccessPath) (data []byte, err error) {
 	for _, f := range server.dataMiddlewares {
-		data, err = f(server.ctx, path)
-		if err != nil || data != nil {
+		data, err = f(ctx, path)
+		if err != nil {
+			return
+		}
+
+		if data != nil {
+			metrics.Get().DSMiddlewareHits.Add(1)
 			return
 		}
 	}
@@ -68,7 +100,7 @@ func (server DSServer) processMiddlewares(path *vm_grpc.VMAccessPath) (data []by
 	return
 }
 
-// Set server context.
+// Set server default context.
 func (server *DSServer) SetContext(ctx sdk.Context) {
 	server.mux.Lock()
 
@@ -77,72 +109,173 @@ func (server *DSServer) SetContext(ctx sdk.Context) {
 	server.mux.Unlock()
 }
 
+// Register VM execution context, returns release function.
+// Executions are serialized: registration waits until the previous execution is released.
+// Wait is bounded by {waitTimeout} (zero - wait infinitely), error is returned on timeout.
+func (server *DSServer) RegisterExecution(ctx sdk.Context, waitTimeout time.Duration) (release func(), retErr error) {
+	if waitTimeout > 0 {
+		timer := time.NewTimer(waitTimeout)
+		defer timer.Stop()
+
+		select {
+		case server.execSlot <- struct{}{}:
+		case <-timer.C:
+			retErr = fmt.Errorf("another VM execution is in progress for more than %v", waitTimeout)
+			return
+		}
+	} else {
+		server.execSlot <- struct{}{}
+	}
+
+	server.mux.Lock()
+	server.execCtx, server.execRunning = ctx, true
+	server.mux.Unlock()
+
+	release = func() {
+		server.mux.Lock()
+		server.execCtx, server.execRunning = sdk.Context{}, false
+		server.mux.Unlock()
+
+		<-server.execSlot
+	}
+
+	return
+}
+
+// Resolve context for DS request: running execution context or default context.
+func (server *DSServer) resolveContext() sdk.Context {
+	server.mux.Lock()
+	defer server.mux.Unlock()
+
+	if server.execRunning {
+		return server.execCtx
+	}
+
+	return server.ctx
+}
+
 // Check if server is already in listen mode.
-func (server DSServer) IsStarted() bool {
+func (server *DSServer) IsStarted() bool {
 	return server.isStarted
 }
 
+// Read value by access path: walks through middlewares and then VM storage.
+// Returns nil blob and nil error if no data found.
+func (server *DSServer) readPath(ctx sdk.Context, path *vm_grpc.VMAccessPath) ([]byte, error) {
+	// here go with middlewares
+	blob, err := server.processMiddlewares(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("processing middlewares: %w", err)
+	}
+
+	if blob != nil {
+		return blob, nil
+	}
+
+	key := common_vm.MakePathKey(path)
+	if value, ok := server.cache.get(ctx, key); ok {
+		return value, nil
+	}
+
+	// we can move it to middleware too later.
+	var value []byte
+	if server.keeper.hasValue(ctx, path) {
+		value = server.keeper.getValue(ctx, path)
+	}
+	server.cache.add(ctx, key, value)
+
+	return value, nil
+}
+
 // Data source processing request to return value from storage.
-func (server DSServer) GetRaw(_ context.Context, req *ds_grpc.DSAccessPath) (*ds_grpc.DSRawResponse, error) {
+func (server *DSServer) GetRaw(_ context.Context, req *ds_grpc.DSAccessPath) (*ds_grpc.DSRawResponse, error) {
 	path := &vm_grpc.VMAccessPath{
 		Address: req.Address,
 		Path:    req.Path,
 	}
 
-	server.Logger().Info(fmt.Sprintf("Get path: %s", types.PathToHex(path)))
+	ctx := server.resolveContext()
+	logger := server.logger(ctx)
 
-	// here go with middlewares
-	blob, err := server.processMiddlewares(path)
+	logger.Info(fmt.Sprintf("Get path: %s", types.PathToHex(path)))
+
+	dsMetrics := metrics.Get()
+	dsMetrics.DSRequests.With(metrics.LabelMethod, dsMethodGetRaw).Add(1)
+
+	blob, err := server.readPath(ctx, path)
 	if err != nil {
-		server.Logger().Error(fmt.Sprintf("Error processing middlewares for path %s: %v", types.PathToHex(path), err))
+		dsMetrics.DSMisses.With(metrics.LabelMethod, dsMethodGetRaw).Add(1)
+		logger.Error(fmt.Sprintf("Error reading path %s: %v", types.PathToHex(path), err))
 		return ErrNoData(req), nil
 	}
 
-	if blob != nil {
-		return &ds_grpc.DSRawResponse{Blob: blob}, nil
-	}
-
-	// we can move it to middleware too later.
-	if !server.keeper.hasValue(server.ctx, path) {
-		server.Logger().Debug(fmt.Sprintf("Can't find path: %s", types.PathToHex(path)))
+	if blob == nil {
+		dsMetrics.DSMisses.With(metrics.LabelMethod, dsMethodGetRaw).Add(1)
+		logger.Debug(fmt.Sprintf("Can't find path: %s", types.PathToHex(path)))
 		return ErrNoData(req), nil
 	}
 
-	server.Logger().Debug(fmt.Sprintf("Get path: %s", types.PathToHex(path)))
-  blob = server.keeper.getValue(server.ctx, path)
-	server.Logger().Debug(fmt.Sprintf("Return values: %s\n", hex.EncodeToString(blob)))
+	logger.Debug(fmt.Sprintf("Return values: %s\n", hex.EncodeToString(blob)))
 
 	return &ds_grpc.DSRawResponse{Blob: blob}, nil
 }
 
-// Data source processing request to return multiplay values form storage.
-func (server DSServer) MultiGetRaw(_ context.Context, req *ds_grpc.DSAccessPaths) (*ds_grpc.DSRawResponses, error) {
-	/*resps := &ds_grpc.DSRawResponses{
-		Blobs: make([][]byte, 0),
+// Data source processing request to return multiple values from storage.
+// Each path is processed the same way as GetRaw does (middlewares, then storage).
+// Blobs order matches request paths order. Missing path results in an empty blob (protocol has no per path
+// error code), so one missing path doesn't fail the whole batch. Middleware / storage read error fails
+// the whole request with Internal gRPC status.
+func (server *DSServer) MultiGetRaw(_ context.Context, req *ds_grpc.DSAccessPaths) (*ds_grpc.DSRawResponses, error) {
+	if len(req.Paths) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "empty access paths list")
+	}
+
+	ctx := server.resolveContext()
+	logger := server.logger(ctx)
+
+	resps := &ds_grpc.DSRawResponses{
+		Blobs: make([][]byte, len(req.Paths)),
 	}
 
-	for _, dsAccessPath := range req.Paths {
+	dsMetrics := metrics.Get()
+	dsMetrics.DSRequests.With(metrics.LabelMethod, dsMethodMultiGetRaw).Add(float64(len(req.Paths)))
+
+	for i, dsAccessPath := range req.Paths {
 		path := &vm_grpc.VMAccessPath{
 			Address: dsAccessPath.Address,
 			Path:    dsAccessPath.Path,
 		}
 
-		if !server.keeper.hasValue(*server.ctx, path) {
-			return nil, types.ErrDSMissedValue(*path)
+		blob, err := server.readPath(ctx, path)
+		if err != nil {
+			dsMetrics.DSMisses.With(metrics.LabelMethod, dsMethodMultiGetRaw).Add(1)
+			logger.Error(fmt.Sprintf("Error reading path %s (batch index %d): %v", types.PathToHex(path), i, err))
+			return nil, status.Errorf(codes.Internal, "reading path %s (batch index %d): %v", types.PathToHex(path), i, err)
 		}
 
-		blob := server.keeper.getValue(*server.ctx, path)
-		resps.Blobs = append(resps.Blobs, blob)
+		if blob == nil {
+			dsMetrics.DSMisses.With(metrics.LabelMethod, dsMethodMultiGetRaw).Add(1)
+			logger.Debug(fmt.Sprintf("Can't find path %s (batch index %d)", types.PathToHex(path), i))
+			blob = []byte{}
+		}
+
+		resps.Blobs[i] = blob
 	}
 
-	return resps, nil*/
-	return nil, status.Errorf(codes.Unimplemented, "MultiGetRaw unimplemented")
+	return resps, nil
 }
 
 // Creating new DS server.
 func NewDSServer(keeper *Keeper) *DSServer {
+	cacheSize := config.DefaultDSCacheSize
+	if keeper.config != nil {
+		cacheSize = keeper.config.DSCacheSize
+	}
+
 	return &DSServer{
-		keeper: keeper,
+		keeper:   keeper,
+		execSlot: make(chan struct{}, 1),
+		cache:    newDSCache(cacheSize),
 	}
 }
 
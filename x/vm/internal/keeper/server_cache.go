@@ -0,0 +1,117 @@
+// Data source (DS) server storage read cache.
+package keeper
+
+import (
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/dfinance/dnode/helpers/metrics"
+)
+
+// Cached storage value (nil value - no data found).
+type dsCacheEntry struct {
+	value []byte
+}
+
+// Block-scoped LRU cache of VM storage values read by DS server, keyed by storage key (access path).
+// Cache contains only values committed on previous blocks: it is used only within the current block (DeliverTx,
+// Begin/EndBlock contexts), values written during the current block are not cached till commit (written keys are
+// marked dirty). Cache is reset at commit.
+type dsCache struct {
+	mu     sync.Mutex
+	lru    *lru.Cache          // nil - cache is disabled
+	dirty  map[string]struct{} // keys written during the current block
+	height int64               // current block height (cache is not used till the first reset)
+}
+
+// Check context could use cache (current block execution, not CheckTx / simulation / queries).
+func (c *dsCache) isCtxValid(ctx sdk.Context) bool {
+	return !ctx.IsCheckTx() && ctx.BlockHeight() == c.height
+}
+
+// Get cached value, returns false if value is not cached.
+func (c *dsCache) get(ctx sdk.Context, key []byte) ([]byte, bool) {
+	if c.lru == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.isCtxValid(ctx) {
+		return nil, false
+	}
+
+	entry, ok := c.lru.Get(string(key))
+	if !ok {
+		metrics.Get().DSCacheMisses.Add(1)
+		return nil, false
+	}
+	metrics.Get().DSCacheHits.Add(1)
+
+	return entry.(dsCacheEntry).value, true
+}
+
+// Add value read from storage to cache (skipped for keys written during the current block).
+func (c *dsCache) add(ctx sdk.Context, key, value []byte) {
+	if c.lru == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.isCtxValid(ctx) {
+		return
+	}
+
+	if _, ok := c.dirty[string(key)]; ok {
+		return
+	}
+
+	c.lru.Add(string(key), dsCacheEntry{value: value})
+}
+
+// Invalidate key on storage write / delete: key is removed and marked dirty till commit.
+func (c *dsCache) invalidate(key []byte) {
+	if c.lru == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lru.Remove(string(key))
+	c.dirty[string(key)] = struct{}{}
+}
+
+// Reset cache for the next block.
+func (c *dsCache) reset(nextHeight int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lru != nil {
+		c.lru.Purge()
+	}
+	c.dirty = make(map[string]struct{})
+	c.height = nextHeight
+}
+
+// Create DS cache, zero size disables cache.
+func newDSCache(size int) *dsCache {
+	c := &dsCache{
+		dirty:  make(map[string]struct{}),
+		height: -1,
+	}
+
+	if size > 0 {
+		var err error
+		if c.lru, err = lru.New(size); err != nil {
+			panic(err)
+		}
+	}
+
+	return c
+}
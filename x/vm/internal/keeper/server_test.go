@@ -3,19 +3,31 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/lcs"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
 	"github.com/dfinance/dvm-proto/go/ds_grpc"
 	"github.com/dfinance/dvm-proto/go/vm_grpc"
+
+	"github.com/dfinance/dnode/x/common_vm"
+	"github.com/dfinance/dnode/x/vm/internal/middlewares"
 )
 
 // Initialize connection to DS server.
-func getClient(t *testing.T, listener *bufconn.Listener) ds_grpc.DSServiceClient {
+func getClient(t testing.TB, listener *bufconn.Listener) ds_grpc.DSServiceClient {
 	dsConn, err := grpc.DialContext(context.TODO(), "", grpc.WithContextDialer(GetBufDialer(listener)), grpc.WithInsecure())
 	if err != nil {
 		t.Fatal(err)
@@ -97,10 +109,449 @@ func TestDSServer_MultiGetRaw(t *testing.T) {
 
 	connCtx := context.Background()
 	resp, err := client.MultiGetRaw(connCtx, req)
-	require.Nil(t, resp)
-	require.Error(t, err)
+	require.NoError(t, err)
+	require.Len(t, resp.Blobs, argsCount)
 
-	/*for i, val := range resp.Blobs {
+	for i, val := range resp.Blobs {
 		require.EqualValues(t, values[i], val)
-	}*/
+	}
+
+	// empty request
+	_, err = client.MultiGetRaw(connCtx, &ds_grpc.DSAccessPaths{})
+	require.Error(t, err)
+}
+
+// Test get multiraw data from server with missing paths.
+func TestDSServer_MultiGetRawMissingPath(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	rawServer := StartServer(input.vk.listener, input.vk.dsServer)
+	defer rawServer.Stop()
+
+	input.vk.dsServer.SetContext(input.ctx)
+
+	client := getClient(t, input.dsListener)
+
+	existingPath, existingValue := randomPath(), randomValue(16)
+	input.vk.setValue(input.ctx, existingPath, existingValue)
+	missingPath := randomPath()
+
+	req := &ds_grpc.DSAccessPaths{
+		Paths: []*ds_grpc.DSAccessPath{
+			{Address: missingPath.Address, Path: missingPath.Path},
+			{Address: existingPath.Address, Path: existingPath.Path},
+		},
+	}
+
+	resp, err := client.MultiGetRaw(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, resp.Blobs, 2)
+	require.Empty(t, resp.Blobs[0])
+	require.EqualValues(t, existingValue, resp.Blobs[1])
+}
+
+// Test get multiraw data from server processes middlewares per path.
+func TestDSServer_MultiGetRawMiddlewares(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	rawServer := StartServer(input.vk.listener, input.vk.dsServer)
+	defer rawServer.Stop()
+
+	input.vk.dsServer.SetContext(input.ctx)
+
+	mdPath, mdValue := randomPath(), randomValue(8)
+	input.vk.dsServer.RegisterDataMiddleware(func(_ sdk.Context, path *vm_grpc.VMAccessPath) ([]byte, error) {
+		if bytes.Equal(path.Address, mdPath.Address) && bytes.Equal(path.Path, mdPath.Path) {
+			return mdValue, nil
+		}
+
+		return nil, nil
+	})
+
+	client := getClient(t, input.dsListener)
+
+	storagePath, storageValue := randomPath(), randomValue(16)
+	input.vk.setValue(input.ctx, storagePath, storageValue)
+
+	req := &ds_grpc.DSAccessPaths{
+		Paths: []*ds_grpc.DSAccessPath{
+			{Address: storagePath.Address, Path: storagePath.Path},
+			{Address: mdPath.Address, Path: mdPath.Path},
+		},
+	}
+
+	resp, err := client.MultiGetRaw(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, resp.Blobs, 2)
+	require.EqualValues(t, storageValue, resp.Blobs[0])
+	require.EqualValues(t, mdValue, resp.Blobs[1])
+}
+
+// Test get multiraw data from server fails on middleware error.
+func TestDSServer_MultiGetRawMiddlewareError(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	rawServer := StartServer(input.vk.listener, input.vk.dsServer)
+	defer rawServer.Stop()
+
+	input.vk.dsServer.SetContext(input.ctx)
+
+	errPath := randomPath()
+	input.vk.dsServer.RegisterDataMiddleware(func(_ sdk.Context, path *vm_grpc.VMAccessPath) ([]byte, error) {
+		if bytes.Equal(path.Address, errPath.Address) && bytes.Equal(path.Path, errPath.Path) {
+			return nil, fmt.Errorf("middleware error")
+		}
+
+		return nil, nil
+	})
+
+	client := getClient(t, input.dsListener)
+
+	storagePath, storageValue := randomPath(), randomValue(16)
+	input.vk.setValue(input.ctx, storagePath, storageValue)
+
+	req := &ds_grpc.DSAccessPaths{
+		Paths: []*ds_grpc.DSAccessPath{
+			{Address: storagePath.Address, Path: storagePath.Path},
+			{Address: errPath.Address, Path: errPath.Path},
+		},
+	}
+
+	_, err := client.MultiGetRaw(context.Background(), req)
+	require.Error(t, err)
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+// Test DS requests are resolved to the running execution context.
+func TestDSServer_ExecutionContext(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	rawServer := StartServer(input.vk.listener, input.vk.dsServer)
+	defer rawServer.Stop()
+
+	client := getClient(t, input.dsListener)
+	ap := randomPath()
+	dsPath := &ds_grpc.DSAccessPath{Address: ap.Address, Path: ap.Path}
+
+	// default context and execution context (branched) have different values
+	defaultValue, execValue := randomValue(8), randomValue(8)
+	input.vk.setValue(input.ctx, ap, defaultValue)
+	input.vk.SetDSContext(input.ctx)
+
+	execCtx, _ := input.ctx.CacheContext()
+	input.vk.setValue(execCtx, ap, execValue)
+
+	// no execution: default context is used
+	{
+		resp, err := client.GetRaw(context.Background(), dsPath)
+		require.NoError(t, err)
+		require.EqualValues(t, defaultValue, resp.Blob)
+	}
+
+	// execution is running: execution context is used
+	release, err := input.vk.dsServer.RegisterExecution(execCtx, 0)
+	require.NoError(t, err)
+	{
+		resp, err := client.GetRaw(context.Background(), dsPath)
+		require.NoError(t, err)
+		require.EqualValues(t, execValue, resp.Blob)
+
+		respMulti, err := client.MultiGetRaw(context.Background(), &ds_grpc.DSAccessPaths{Paths: []*ds_grpc.DSAccessPath{dsPath}})
+		require.NoError(t, err)
+		require.EqualValues(t, execValue, respMulti.Blobs[0])
+	}
+
+	// execution is released: default context is used
+	release()
+	{
+		resp, err := client.GetRaw(context.Background(), dsPath)
+		require.NoError(t, err)
+		require.EqualValues(t, defaultValue, resp.Blob)
+	}
+}
+
+// Test VM executions are serialized and bounded wait fails while another execution is running.
+func TestDSServer_ExecutionWaitTimeout(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	release, err := input.vk.dsServer.RegisterExecution(input.ctx, 0)
+	require.NoError(t, err)
+
+	// bounded wait: timeout
+	{
+		_, err := input.vk.dsServer.RegisterExecution(input.ctx, 50*time.Millisecond)
+		require.Error(t, err)
+	}
+
+	// unbounded wait: registered after the running execution is released
+	{
+		doneCh := make(chan error)
+		go func() {
+			nextRelease, err := input.vk.dsServer.RegisterExecution(input.ctx, 0)
+			if err == nil {
+				nextRelease()
+			}
+			doneCh <- err
+		}()
+
+		select {
+		case <-doneCh:
+			t.Fatal("execution registered while another one is running")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		release()
+		require.NoError(t, <-doneCh)
+	}
+
+	// bounded wait: slot is free
+	release, err = input.vk.dsServer.RegisterExecution(input.ctx, 50*time.Millisecond)
+	require.NoError(t, err)
+	release()
+}
+
+// Test chain, tx and proposer synthetic resources are served by registered middlewares.
+func TestDSServer_SyntheticResources(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	rawServer := StartServer(input.vk.listener, input.vk.dsServer)
+	defer rawServer.Stop()
+
+	input.vk.RegisterDSDataMiddleware(middlewares.NewChainMiddleware())
+	input.vk.RegisterDSDataMiddleware(middlewares.NewTxMiddleware())
+
+	proposerConsAddr := sdk.ConsAddress(randomValue(sdk.AddrLen))
+	proposerAccAddr := sdk.AccAddress(randomValue(sdk.AddrLen))
+	input.vk.RegisterDSDataMiddleware(middlewares.NewProposerMiddleware(func(_ sdk.Context, consAddress sdk.ConsAddress) (sdk.AccAddress, bool) {
+		return proposerAccAddr, consAddress.Equals(proposerConsAddr)
+	}))
+
+	sender := sdk.AccAddress(randomValue(sdk.AddrLen))
+	txBytes := randomValue(64)
+	ctx := common_vm.WithTxSender(input.ctx, sender).WithTxBytes(txBytes).WithChainID("dn-testnet")
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "dn-testnet", ProposerAddress: proposerConsAddr})
+	input.vk.dsServer.SetContext(ctx)
+
+	client := getClient(t, input.dsListener)
+	getRaw := func(path *vm_grpc.VMAccessPath) []byte {
+		resp, err := client.GetRaw(context.Background(), &ds_grpc.DSAccessPath{Address: path.Address, Path: path.Path})
+		require.NoError(t, err)
+		require.Equal(t, ds_grpc.DSRawResponse_NONE, resp.ErrorCode)
+
+		return resp.Blob
+	}
+
+	// chain ID
+	{
+		chainInfo := middlewares.ChainInfo{}
+		blob := getRaw(common_vm.GetSyntheticResourcePath(middlewares.ChainInfoModule, middlewares.ChainInfoName))
+		require.NoError(t, lcs.Unmarshal(blob, &chainInfo))
+		require.Equal(t, "dn-testnet", string(chainInfo.ChainID))
+	}
+
+	// tx sender and hash
+	{
+		txInfo := middlewares.TxInfo{}
+		blob := getRaw(common_vm.GetSyntheticResourcePath(middlewares.TxInfoModule, middlewares.TxInfoName))
+		require.NoError(t, lcs.Unmarshal(blob, &txInfo))
+		require.EqualValues(t, common_vm.Bech32ToLibra(sender), txInfo.Sender[:])
+		require.EqualValues(t, tmhash.Sum(txBytes), txInfo.Hash)
+	}
+
+	// proposer
+	{
+		proposer := middlewares.Proposer{}
+		blob := getRaw(common_vm.GetSyntheticResourcePath(middlewares.ProposerModule, middlewares.ProposerName))
+		require.NoError(t, lcs.Unmarshal(blob, &proposer))
+		require.EqualValues(t, common_vm.Bech32ToLibra(proposerAccAddr), proposer.Address[:])
+	}
+
+	// out of tx: no tx info
+	input.vk.dsServer.SetContext(input.ctx)
+	path := common_vm.GetSyntheticResourcePath(middlewares.TxInfoModule, middlewares.TxInfoName)
+	resp, err := client.GetRaw(context.Background(), &ds_grpc.DSAccessPath{Address: path.Address, Path: path.Path})
+	require.NoError(t, err)
+	require.Equal(t, ds_grpc.DSRawResponse_NO_DATA, resp.ErrorCode)
+}
+
+// Test DS server read cache: values are cached within the current block, writes invalidate cache.
+func TestDSServer_ReadCache(t *testing.T) {
+	input := setupTestInput(true)
+	defer closeInput(input)
+
+	ctx := input.ctx.WithBlockHeight(10)
+	store := ctx.KVStore(input.vk.storeKey)
+	server := input.vk.dsServer
+	server.cache = newDSCache(16)
+
+	path, value := randomPath(), randomValue(32)
+	key := common_vm.MakePathKey(path)
+	store.Set(key, value)
+
+	// cache is not used till reset
+	{
+		blob, err := server.readPath(ctx, path)
+		require.NoError(t, err)
+		require.EqualValues(t, value, blob)
+
+		_, ok := server.cache.get(ctx, key)
+		require.False(t, ok)
+	}
+
+	input.vk.ResetDSCache(ctx.BlockHeight())
+
+	// value is cached: direct store write (bypassing keeper) is not visible
+	{
+		blob, err := server.readPath(ctx, path)
+		require.NoError(t, err)
+		require.EqualValues(t, value, blob)
+
+		store.Set(key, randomValue(32))
+		blob, err = server.readPath(ctx, path)
+		require.NoError(t, err)
+		require.EqualValues(t, value, blob)
+	}
+
+	// missing value is cached too
+	{
+		missingPath := randomPath()
+		blob, err := server.readPath(ctx, missingPath)
+		require.NoError(t, err)
+		require.Nil(t, blob)
+
+		cachedBlob, ok := server.cache.get(ctx, common_vm.MakePathKey(missingPath))
+		require.True(t, ok)
+		require.Nil(t, cachedBlob)
+	}
+
+	// CheckTx / other heights contexts don't use cache
+	{
+		_, ok := server.cache.get(ctx.WithIsCheckTx(true), key)
+		require.False(t, ok)
+
+		_, ok = server.cache.get(ctx.WithBlockHeight(9), key)
+		require.False(t, ok)
+	}
+
+	// keeper write invalidates cache, written key is not cached till reset
+	{
+		newValue := randomValue(32)
+		input.vk.SetValue(ctx, path, newValue)
+
+		blob, err := server.readPath(ctx, path)
+		require.NoError(t, err)
+		require.EqualValues(t, newValue, blob)
+
+		_, ok := server.cache.get(ctx, key)
+		require.False(t, ok)
+
+		input.vk.DelValue(ctx, path)
+		blob, err = server.readPath(ctx, path)
+		require.NoError(t, err)
+		require.Nil(t, blob)
+	}
+
+	// reset (commit)
+	{
+		store.Set(key, value)
+		input.vk.ResetDSCache(ctx.BlockHeight() + 1)
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+
+		blob, err := server.readPath(ctx, path)
+		require.NoError(t, err)
+		require.EqualValues(t, value, blob)
+
+		cachedBlob, ok := server.cache.get(ctx, key)
+		require.True(t, ok)
+		require.EqualValues(t, value, cachedBlob)
+	}
+}
+
+// Benchmark DS server GetRaw requests for module bytecode sized values with and without read cache.
+func BenchmarkDSServer_GetRaw(b *testing.B) {
+	const pathsCount, valueLen = 64, 4096
+
+	benchmarks := []struct {
+		name      string
+		cacheSize int
+	}{
+		{name: "NoCache", cacheSize: 0},
+		{name: "Cache", cacheSize: pathsCount},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			input := setupTestInput(true)
+			defer closeInput(input)
+
+			input.vk.dsServer.cache = newDSCache(bm.cacheSize)
+			rawServer := StartServer(input.vk.listener, input.vk.dsServer)
+			defer rawServer.Stop()
+
+			ctx := input.ctx.WithBlockHeight(1)
+			input.vk.ResetDSCache(ctx.BlockHeight())
+			input.vk.dsServer.SetContext(ctx)
+
+			paths := make([]*ds_grpc.DSAccessPath, 0, pathsCount)
+			for i := 0; i < pathsCount; i++ {
+				path := randomPath()
+				ctx.KVStore(input.vk.storeKey).Set(common_vm.MakePathKey(path), randomValue(valueLen))
+				paths = append(paths, &ds_grpc.DSAccessPath{Address: path.Address, Path: path.Path})
+			}
+
+			client := getClient(b, input.dsListener)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := client.GetRaw(context.Background(), paths[i%pathsCount]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// Benchmark DS server storage reads (no gRPC overhead) with and without read cache.
+func BenchmarkDSServer_ReadPath(b *testing.B) {
+	const pathsCount, valueLen = 64, 4096
+
+	benchmarks := []struct {
+		name      string
+		cacheSize int
+	}{
+		{name: "NoCache", cacheSize: 0},
+		{name: "Cache", cacheSize: pathsCount},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			input := setupTestInput(false)
+			defer closeInput(input)
+
+			input.vk.dsServer.cache = newDSCache(bm.cacheSize)
+
+			ctx := input.ctx.WithBlockHeight(1)
+			input.vk.ResetDSCache(ctx.BlockHeight())
+
+			paths := make([]*vm_grpc.VMAccessPath, 0, pathsCount)
+			for i := 0; i < pathsCount; i++ {
+				path := randomPath()
+				ctx.KVStore(input.vk.storeKey).Set(common_vm.MakePathKey(path), randomValue(valueLen))
+				paths = append(paths, path)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := input.vk.dsServer.readPath(ctx, paths[i%pathsCount]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
 }
@@ -8,7 +8,7 @@ import (
 	"testing"
 )
 
-func getGenesis(t *testing.T) []byte {
+func getGenesis(t testing.TB) []byte {
 	fileName := "./genesis_ws.json"
 
 	handle, err := os.Open(fileName)
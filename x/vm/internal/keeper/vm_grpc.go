@@ -4,8 +4,10 @@ package keeper
 import (
 	"encoding/hex"
 	"fmt"
+	"math"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/dfinance/dvm-proto/go/vm_grpc"
 
@@ -14,27 +16,59 @@ import (
 )
 
 // Get free gas from execution context.
+// Infinite gas meter (tx simulation, multisig calls) has zero limit, in that case free gas is unlimited
+// and VM gets the params max gas for contract type (see GetMaxGas).
 func GetFreeGas(ctx sdk.Context) sdk.Gas {
-	return ctx.GasMeter().Limit() - ctx.GasMeter().GasConsumed()
+	limit, consumed := ctx.GasMeter().Limit(), ctx.GasMeter().GasConsumed()
+	if limit == 0 {
+		return math.MaxUint64
+	}
+
+	if consumed >= limit {
+		return 0
+	}
+
+	return limit - consumed
+}
+
+// Get max VM gas for contract execution: free gas (converted to VM gas by gas unit price) limited by params max gas for contract type.
+func GetMaxGas(ctx sdk.Context, params types.Params, contractType vm_grpc.ContractType) uint64 {
+	gas := params.ToVMGas(GetFreeGas(ctx))
+	if maxGas := params.MaxGas(contractType); gas > maxGas {
+		return maxGas
+	}
+
+	return gas
 }
 
 // Create new contract in grpc format for VM request.
-func NewContract(address sdk.AccAddress, maxGas sdk.Gas, code []byte, contractType vm_grpc.ContractType, args []*vm_grpc.VMArgs) (*vm_grpc.VMContract, error) {
+func NewContract(address sdk.AccAddress, maxGas, gasUnitPrice uint64, code []byte, contractType vm_grpc.ContractType, args []*vm_grpc.VMArgs) (*vm_grpc.VMContract, error) {
 	return &vm_grpc.VMContract{
 		Address:      "0x" + hex.EncodeToString(common_vm.Bech32ToLibra(address)),
 		MaxGasAmount: maxGas,
-		GasUnitPrice: types.VmGasPrice,
+		GasUnitPrice: gasUnitPrice,
 		Code:         code,
 		ContractType: contractType,
 		Args:         args,
 	}, nil
 }
 
-// Create deploy request for VM grpc server.
-func NewDeployRequest(ctx sdk.Context, msg types.MsgDeployModule) (*vm_grpc.VMExecuteRequest, error) {
-	gas := GetFreeGas(ctx)
+// Create deploy request for VM grpc server, VM params limits are applied.
+func NewDeployRequest(ctx sdk.Context, msg types.MsgDeployModule, params types.Params) (*vm_grpc.VMExecuteRequest, error) {
+	contract, err := newModuleContract(ctx, msg.Signer, msg.Module, params)
+	if err != nil {
+		return nil, err
+	}
 
-	contract, err := NewContract(msg.Signer, gas, msg.Module, vm_grpc.ContractType_Module, []*vm_grpc.VMArgs{})
+	return &vm_grpc.VMExecuteRequest{
+		Contracts: []*vm_grpc.VMContract{contract},
+		Options:   0,
+	}, nil
+}
+
+// Create execute script request for VM grpc server, VM params limits are applied.
+func NewExecuteRequest(ctx sdk.Context, msg types.MsgExecuteScript, params types.Params) (*vm_grpc.VMExecuteRequest, error) {
+	contract, err := newScriptContract(ctx, msg.Signer, msg.Script, msg.Args, params)
 	if err != nil {
 		return nil, err
 	}
@@ -45,13 +79,41 @@ func NewDeployRequest(ctx sdk.Context, msg types.MsgDeployModule) (*vm_grpc.VMEx
 	}, nil
 }
 
-// Create execute script request for VM grpc server.
-func NewExecuteRequest(ctx sdk.Context, msg types.MsgExecuteScript) (*vm_grpc.VMExecuteRequest, error) {
-	gas := GetFreeGas(ctx)
+// Create batch contract (module or script) for VM request, VM params limits are applied.
+// Batch contracts are sent to VM one per request (see Keeper.ExecuteBatch).
+func NewBatchContract(ctx sdk.Context, signer sdk.AccAddress, batchContract types.BatchContract, params types.Params) (*vm_grpc.VMContract, error) {
+	switch batchContract.Type {
+	case vm_grpc.ContractType_Module:
+		return newModuleContract(ctx, signer, batchContract.Code, params)
+	case vm_grpc.ContractType_Script:
+		return newScriptContract(ctx, signer, batchContract.Code, batchContract.Args, params)
+	default:
+		return nil, sdkErrors.Wrapf(types.ErrWrongBatch, "unknown contract type: %d", batchContract.Type)
+	}
+}
 
-	args := make([]*vm_grpc.VMArgs, len(msg.Args))
+// Create module contract for VM request, VM params limits are applied.
+func newModuleContract(ctx sdk.Context, signer sdk.AccAddress, code types.Contract, params types.Params) (*vm_grpc.VMContract, error) {
+	if err := types.CheckBytecodeSize(code, params.MaxBytecodeSize); err != nil {
+		return nil, err
+	}
+
+	gas := GetMaxGas(ctx, params, vm_grpc.ContractType_Module)
+
+	return NewContract(signer, gas, params.GasUnitPrice, code, vm_grpc.ContractType_Module, []*vm_grpc.VMArgs{})
+}
+
+// Create script contract for VM request, VM params limits are applied.
+func newScriptContract(ctx sdk.Context, signer sdk.AccAddress, code types.Contract, scriptArgs []types.ScriptArg, params types.Params) (*vm_grpc.VMContract, error) {
+	if err := types.CheckBytecodeSize(code, params.MaxBytecodeSize); err != nil {
+		return nil, err
+	}
+
+	gas := GetMaxGas(ctx, params, vm_grpc.ContractType_Script)
 
-	for i, arg := range msg.Args {
+	args := make([]*vm_grpc.VMArgs, len(scriptArgs))
+
+	for i, arg := range scriptArgs {
 		if arg.Type == vm_grpc.VMTypeTag_Address {
 			addr, err := sdk.AccAddressFromBech32(arg.Value)
 			if err != nil {
@@ -70,13 +132,5 @@ func NewExecuteRequest(ctx sdk.Context, msg types.MsgExecuteScript) (*vm_grpc.VM
 		}
 	}
 
-	contract, err := NewContract(msg.Signer, gas, msg.Script, vm_grpc.ContractType_Script, args)
-	if err != nil {
-		return nil, err
-	}
-
-	return &vm_grpc.VMExecuteRequest{
-		Contracts: []*vm_grpc.VMContract{contract},
-		Options:   0,
-	}, nil
+	return NewContract(signer, gas, params.GasUnitPrice, code, vm_grpc.ContractType_Script, args)
 }
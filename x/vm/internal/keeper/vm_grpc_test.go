@@ -4,6 +4,7 @@ package keeper
 
 import (
 	"encoding/hex"
+	"math"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/store"
@@ -70,7 +71,7 @@ func getArgs() []*vm_grpc.VMArgs {
 
 // Get contract.
 func getContract(addr sdk.AccAddress, contractType vm_grpc.ContractType, code []byte, maxGas uint64, args []*vm_grpc.VMArgs, t *testing.T) *vm_grpc.VMContract {
-	contractModule, err := NewContract(addr, maxGas, code, contractType, args)
+	contractModule, err := NewContract(addr, maxGas, types.DefaultGasUnitPrice, code, contractType, args)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -107,6 +108,41 @@ func TestGetFreeGas(t *testing.T) {
 	ctx.GasMeter().ConsumeGas(restGas, "test spend to zero")
 	freeGas = GetFreeGas(ctx)
 	require.EqualValues(t, 0, freeGas)
+
+	// infinite gas meter (simulation)
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	ctx.GasMeter().ConsumeGas(gasToSpend, "test spend")
+	freeGas = GetFreeGas(ctx)
+	require.EqualValues(t, uint64(math.MaxUint64), freeGas)
+}
+
+// Check contracts max gas is limited by params for simulation (infinite gas meter).
+func TestNewExecuteRequest_Simulation(t *testing.T) {
+	db := dbm.NewMemDB()
+	mstore := store.NewCommitMultiStore(db)
+	require.NoError(t, mstore.LoadLatestVersion())
+
+	ctx := sdk.NewContext(mstore, abci.Header{ChainID: "dn-testnet-vm-keeper-test"}, false, log.NewNopLogger())
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+	params := types.DefaultParams()
+	params.MaxGasPerScript = 2000000
+	params.MaxGasPerModule = 3000000
+	signer := sdk.AccAddress(randomValue(20))
+
+	scriptReq, err := NewExecuteRequest(ctx, types.NewMsgExecuteScript(signer, randomValue(100), nil), params)
+	require.NoError(t, err)
+	require.Equal(t, params.MaxGasPerScript, scriptReq.Contracts[0].MaxGasAmount)
+
+	moduleReq, err := NewDeployRequest(ctx, types.NewMsgDeployModule(signer, randomValue(100)), params)
+	require.NoError(t, err)
+	require.Equal(t, params.MaxGasPerModule, moduleReq.Contracts[0].MaxGasAmount)
+
+	// finite gas meter limits contracts max gas
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(1000))
+	scriptReq, err = NewExecuteRequest(ctx, types.NewMsgExecuteScript(signer, randomValue(100), nil), params)
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, scriptReq.Contracts[0].MaxGasAmount)
 }
 
 // Check creationg of new contract instance.
@@ -156,7 +192,7 @@ func TestNewDeployRequest(t *testing.T) {
 		Module: code,
 	}
 
-	req, err := NewDeployRequest(ctx, msg)
+	req, err := NewDeployRequest(ctx, msg, types.DefaultParams())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -167,3 +203,89 @@ func TestNewDeployRequest(t *testing.T) {
 	require.EqualValues(t, "0x"+hex.EncodeToString(common_vm.Bech32ToLibra(addr)), req.Contracts[0].Address)
 	require.Equal(t, 1, len(req.Contracts))
 }
+
+// Max gas and bytecode size limits by params.
+func TestNewExecuteRequest_Params(t *testing.T) {
+	addr := secp256k1.GenPrivKey().PubKey().Address().Bytes()
+	code := randomValue(1024)
+
+	db := dbm.NewMemDB()
+	mstore := store.NewCommitMultiStore(db)
+	err := mstore.LoadLatestVersion()
+	if err != nil {
+		panic(err)
+	}
+
+	ctx := sdk.NewContext(mstore, abci.Header{ChainID: "dn-testnet-vm-keeper-test"}, false, log.NewNopLogger())
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(100000))
+
+	params := types.NewParams(5, 1000, 2000, 2048)
+	msg := types.NewMsgExecuteScript(addr, code, nil)
+
+	// script gas limited by params
+	req, err := NewExecuteRequest(ctx, msg, params)
+	require.NoError(t, err)
+	require.EqualValues(t, params.MaxGasPerScript, req.Contracts[0].MaxGasAmount)
+	require.EqualValues(t, params.GasUnitPrice, req.Contracts[0].GasUnitPrice)
+
+	// module gas limited by params
+	req, err = NewDeployRequest(ctx, types.NewMsgDeployModule(addr, code), params)
+	require.NoError(t, err)
+	require.EqualValues(t, params.MaxGasPerModule, req.Contracts[0].MaxGasAmount)
+
+	// free gas is less than params limit (converted to VM gas by gas unit price)
+	ctx.GasMeter().ConsumeGas(99500, "test spend")
+	req, err = NewExecuteRequest(ctx, msg, params)
+	require.NoError(t, err)
+	require.EqualValues(t, 500/params.GasUnitPrice, req.Contracts[0].MaxGasAmount)
+
+	// bytecode size limit
+	params.MaxBytecodeSize = 512
+	_, err = NewExecuteRequest(ctx, msg, params)
+	require.True(t, types.ErrBytecodeTooLarge.Is(err))
+
+	_, err = NewDeployRequest(ctx, types.NewMsgDeployModule(addr, code), params)
+	require.True(t, types.ErrBytecodeTooLarge.Is(err))
+}
+
+// Test batch contracts: types and params limits.
+func TestNewBatchContract(t *testing.T) {
+	addr := secp256k1.GenPrivKey().PubKey().Address().Bytes()
+	code := randomValue(1024)
+
+	db := dbm.NewMemDB()
+	mstore := store.NewCommitMultiStore(db)
+	err := mstore.LoadLatestVersion()
+	if err != nil {
+		panic(err)
+	}
+
+	ctx := sdk.NewContext(mstore, abci.Header{ChainID: "dn-testnet-vm-keeper-test"}, false, log.NewNopLogger())
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(100000))
+
+	params := types.NewParams(5, 1000, 2000, 2048)
+	args := []types.ScriptArg{types.NewScriptArg("10", vm_grpc.VMTypeTag_U64)}
+	msg := types.NewMsgExecuteBatch(addr, types.NewBatchModule(code), types.NewBatchScript(code, args))
+
+	module, err := NewBatchContract(ctx, msg.Signer, msg.Contracts[0], params)
+	require.NoError(t, err)
+	require.Equal(t, vm_grpc.ContractType_Module, module.ContractType)
+	require.EqualValues(t, params.MaxGasPerModule, module.MaxGasAmount)
+	require.Empty(t, module.Args)
+
+	script, err := NewBatchContract(ctx, msg.Signer, msg.Contracts[1], params)
+	require.NoError(t, err)
+	require.Equal(t, vm_grpc.ContractType_Script, script.ContractType)
+	require.EqualValues(t, params.MaxGasPerScript, script.MaxGasAmount)
+	require.Len(t, script.Args, 1)
+	require.Equal(t, args[0].Value, script.Args[0].Value)
+
+	// unknown contract type
+	_, err = NewBatchContract(ctx, msg.Signer, types.BatchContract{Type: vm_grpc.ContractType(100), Code: code}, params)
+	require.True(t, types.ErrWrongBatch.Is(err))
+
+	// bytecode size limit
+	params.MaxBytecodeSize = 512
+	_, err = NewBatchContract(ctx, msg.Signer, msg.Contracts[1], params)
+	require.True(t, types.ErrBytecodeTooLarge.Is(err))
+}
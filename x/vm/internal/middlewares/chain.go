@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"github.com/dfinance/lcs"
+
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+const (
+	// Chain info synthetic resource identifier.
+	ChainInfoModule = "Chain"
+	ChainInfoName   = "ChainInfo"
+)
+
+// Chain info resource.
+type ChainInfo struct {
+	ChainID []byte
+}
+
+// Chain info middleware: returns current chain ID.
+func NewChainMiddleware() common_vm.DSDataMiddleware {
+	chainInfoPath := common_vm.GetSyntheticResourcePath(ChainInfoModule, ChainInfoName)
+
+	return func(ctx sdk.Context, path *vm_grpc.VMAccessPath) (data []byte, err error) {
+		if common_vm.IsPathEqual(chainInfoPath, path) {
+			return lcs.Marshal(ChainInfo{ChainID: []byte(ctx.ChainID())})
+		}
+
+		return
+	}
+}
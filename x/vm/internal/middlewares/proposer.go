@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"github.com/dfinance/lcs"
+
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+const (
+	// Block proposer synthetic resource identifier.
+	ProposerModule = "Block"
+	ProposerName   = "Proposer"
+)
+
+// Resolves block proposer consensus address to account address (validator operator).
+type ProposerResolver func(ctx sdk.Context, consAddress sdk.ConsAddress) (sdk.AccAddress, bool)
+
+// Block proposer resource.
+type Proposer struct {
+	Address [common_vm.VMAddressLength]byte
+}
+
+// Block proposer middleware: returns current block proposer address.
+// Proposer consensus address is converted to account address by resolver (if provided and resolved), otherwise
+// consensus address is returned as is.
+func NewProposerMiddleware(resolver ProposerResolver) common_vm.DSDataMiddleware {
+	proposerPath := common_vm.GetSyntheticResourcePath(ProposerModule, ProposerName)
+
+	return func(ctx sdk.Context, path *vm_grpc.VMAccessPath) (data []byte, err error) {
+		if !common_vm.IsPathEqual(proposerPath, path) {
+			return
+		}
+
+		consAddress := sdk.ConsAddress(ctx.BlockHeader().ProposerAddress)
+		if consAddress.Empty() {
+			return
+		}
+
+		address := sdk.AccAddress(consAddress)
+		if resolver != nil {
+			if accAddress, ok := resolver(ctx, consAddress); ok {
+				address = accAddress
+			}
+		}
+
+		proposer := Proposer{}
+		copy(proposer.Address[:], address)
+
+		return lcs.Marshal(proposer)
+	}
+}
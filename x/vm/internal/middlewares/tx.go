@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"github.com/dfinance/lcs"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+const (
+	// Tx info synthetic resource identifier.
+	TxInfoModule = "Transaction"
+	TxInfoName   = "TxInfo"
+)
+
+// Current tx info resource.
+type TxInfo struct {
+	Sender [common_vm.VMAddressLength]byte
+	Hash   []byte
+}
+
+// Tx info middleware: returns current tx sender and hash.
+// No data is returned out of tx (no sender / tx bytes in context).
+func NewTxMiddleware() common_vm.DSDataMiddleware {
+	txInfoPath := common_vm.GetSyntheticResourcePath(TxInfoModule, TxInfoName)
+
+	return func(ctx sdk.Context, path *vm_grpc.VMAccessPath) (data []byte, err error) {
+		if !common_vm.IsPathEqual(txInfoPath, path) {
+			return
+		}
+
+		sender, ok := common_vm.GetTxSender(ctx)
+		if !ok || len(ctx.TxBytes()) == 0 {
+			return
+		}
+
+		txInfo := TxInfo{Hash: tmhash.Sum(ctx.TxBytes())}
+		copy(txInfo.Sender[:], sender)
+
+		return lcs.Marshal(txInfo)
+	}
+}
@@ -8,6 +8,9 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgDeployModule{}, ModuleName+"/MsgDeployModule", nil)
 	cdc.RegisterConcrete(MsgExecuteScript{}, ModuleName+"/MsgExecuteScript", nil)
+	cdc.RegisterConcrete(MsgUpgradeModule{}, ModuleName+"/MsgUpgradeModule", nil)
+	cdc.RegisterConcrete(MsgUpdateParams{}, ModuleName+"/MsgUpdateParams", nil)
+	cdc.RegisterConcrete(MsgExecuteBatch{}, ModuleName+"/MsgExecuteBatch", nil)
 }
 
 // module codec
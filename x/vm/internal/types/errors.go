@@ -16,6 +16,8 @@ var (
 	ErrEmptyContract = sdkErrors.Register(ModuleName, 101, "contract code is empty")
 	// Move VM crashes, means don't return response, disconnect, etc (that error breaks consensus)
 	ErrVMCrashed = sdkErrors.Register(ModuleName, 102, "VM has crashed / not reachable")
+	// Move VM is not reachable, tx is failed according to VM failure policy (doesn't halt the node).
+	ErrVMUnavailable = sdkErrors.Register(ModuleName, 103, "VM is not available")
 
 	// Wrong address length.
 	ErrWrongAddressLength = sdkErrors.Register(ModuleName, 201, "address passed to vm has wrong length")
@@ -23,6 +25,21 @@ var (
 	ErrWrongArgTypeTag = sdkErrors.Register(ModuleName, 202, "something wrong with argument type")
 	// Move VM can't process request correctly: number of resp.Executions != 1.
 	ErrWrongExecutionResponse = sdkErrors.Register(ModuleName, 203, "wrong execution response from vm")
+	// Msg module upgrade policy is invalid.
+	ErrWrongModulePolicy = sdkErrors.Register(ModuleName, 204, "wrong module upgrade policy")
+	// Msg contract bytecode size exceeds limit.
+	ErrBytecodeTooLarge = sdkErrors.Register(ModuleName, 205, "contract bytecode is too large")
+	// VM params are invalid.
+	ErrWrongParams = sdkErrors.Register(ModuleName, 206, "wrong VM params")
+	// Msg batch is invalid: empty, too large or contains unknown contract type.
+	ErrWrongBatch = sdkErrors.Register(ModuleName, 207, "wrong contracts batch")
+	// Batch contract execution failed (discarded / error status), whole batch is aborted.
+	ErrBatchAborted = sdkErrors.Register(ModuleName, 208, "contracts batch aborted")
+
+	// Modules registry: module not found.
+	ErrModuleNotFound = sdkErrors.Register(ModuleName, 301, "module not found")
+	// Modules registry: module upgrade is forbidden by module policy.
+	ErrModuleUpgradeForbidden = sdkErrors.Register(ModuleName, 302, "module upgrade is forbidden")
 
 	// Data source: value missed in Data Source server.
 	ErrDSMissedValue = sdkErrors.Register(ModuleName, 401, "value is missed in storage")
@@ -23,6 +23,8 @@ const (
 	AttrKeyType           = "type"
 	AttrKeyData           = "data"
 	AttrKeyGuid           = "guid"
+	AttrKeyStructTag      = "struct_tag"
+	AttrKeyDecodedData    = "decoded_data"
 
 	// Values.
 	StatusDiscard = "discard"
@@ -65,14 +67,30 @@ func NewEventDiscard(errorStatus *vm_grpc.VMStatus) sdk.Event {
 }
 
 // Parse VM event to standard SDK event.
-// In case of event data equal "struct" we don't process struct, and just keep bytes, as for any other type.
+// Event data is decoded for primitive types and for structs with registered layout, raw data is kept anyway.
 func NewEventFromVM(event *vm_grpc.VMEvent) sdk.Event {
-	return sdk.NewEvent(
-		EventTypeMoveEvent,
+	attributes := []sdk.Attribute{
 		sdk.NewAttribute(AttrKeyGuid, "0x"+hex.EncodeToString(event.Key)),
 		sdk.NewAttribute(AttrKeySequenceNumber, strconv.FormatUint(event.SequenceNumber, 10)),
 		sdk.NewAttribute(AttrKeyType, VMTypeToStringPanic(event.Type.Tag)),
-		// we will not parse event data, as it doesn't make sense
 		sdk.NewAttribute(AttrKeyData, "0x"+hex.EncodeToString(event.EventData)),
+	}
+
+	layout, ok := GetTypeTagLayout(event.Type.Tag)
+	if event.Type.Tag == vm_grpc.VMTypeTag_Struct && event.Type.StructTag != nil {
+		structTag := event.Type.StructTag
+		attributes = append(attributes, sdk.NewAttribute(AttrKeyStructTag, StructTagToString(structTag.Address, structTag.Module, structTag.Name)))
+		layout, ok = GetStructLayout(structTag.Address, structTag.Module, structTag.Name)
+	}
+
+	if ok {
+		if decoded, err := DecodeLCSToString(event.EventData, layout); err == nil {
+			attributes = append(attributes, sdk.NewAttribute(AttrKeyDecodedData, decoded))
+		}
+	}
+
+	return sdk.NewEvent(
+		EventTypeMoveEvent,
+		attributes...,
 	)
 }
@@ -5,6 +5,7 @@ package types
 import (
 	"encoding/binary"
 	"encoding/hex"
+	"fmt"
 	"strconv"
 	"testing"
 
@@ -81,7 +82,7 @@ func TestNewEventFromVM(t *testing.T) {
 
 	event := NewEventFromVM(&vmEvent)
 	require.Equal(t, EventTypeMoveEvent, event.Type)
-	require.Len(t, event.Attributes, 4)
+	require.Len(t, event.Attributes, 5)
 
 	require.EqualValues(t, AttrKeyGuid, event.Attributes[0].Key)
 	require.EqualValues(t, "0x"+hex.EncodeToString(vmEvent.Key), event.Attributes[0].Value)
@@ -91,6 +92,51 @@ func TestNewEventFromVM(t *testing.T) {
 	require.EqualValues(t, VMTypeToStringPanic(vmEvent.Type.Tag), event.Attributes[2].Value)
 	require.EqualValues(t, AttrKeyData, event.Attributes[3].Key)
 	require.EqualValues(t, "0x"+hex.EncodeToString(valBytes), event.Attributes[3].Value)
+	require.EqualValues(t, AttrKeyDecodedData, event.Attributes[4].Key)
+	require.EqualValues(t, strconv.FormatUint(value, 10), event.Attributes[4].Value)
+}
+
+// Test struct event with registered layout is decoded.
+func TestNewEventFromVM_Struct(t *testing.T) {
+	payee := make([]byte, 24)
+	payee[0] = 1
+
+	// amount (u128), denom (vector<u8>), payee (address), metadata (vector<u8>).
+	data := make([]byte, 16)
+	data[0] = 100
+	data = append(data, 3, 'd', 'f', 'i')
+	data = append(data, payee...)
+	data = append(data, 0)
+
+	vmEvent := vm_grpc.VMEvent{
+		Key:            []byte("payment"),
+		SequenceNumber: 0,
+		Type: &vm_grpc.VMType{
+			Tag: vm_grpc.VMTypeTag_Struct,
+			StructTag: &vm_grpc.VMStructTag{
+				Address: make([]byte, 24),
+				Module:  "Account",
+				Name:    "SentPaymentEvent",
+			},
+		},
+		EventData: data,
+	}
+
+	event := NewEventFromVM(&vmEvent)
+	require.Len(t, event.Attributes, 6)
+	require.EqualValues(t, AttrKeyStructTag, event.Attributes[4].Key)
+	require.EqualValues(t, StructTagToString(make([]byte, 24), "Account", "SentPaymentEvent"), event.Attributes[4].Value)
+	require.EqualValues(t, AttrKeyDecodedData, event.Attributes[5].Key)
+	require.JSONEq(t,
+		fmt.Sprintf(`{"amount":"100","denom":"0x646669","payee":"%s","metadata":"0x"}`, sdk.AccAddress(payee[:20])),
+		string(event.Attributes[5].Value),
+	)
+
+	// unknown struct: raw data only
+	vmEvent.Type.StructTag.Name = "Unknown"
+	event = NewEventFromVM(&vmEvent)
+	require.Len(t, event.Attributes, 5)
+	require.EqualValues(t, AttrKeyStructTag, event.Attributes[4].Key)
 }
 
 // Test event happens when VM return status with errors.
@@ -8,7 +8,10 @@ type GenesisWriteOp struct {
 	Value   string `json:"value"`
 }
 
-// Genesis state contains write operations.
+// Genesis state contains write operations, deployed modules registry and VM params.
+// Default params are used if params are not set.
 type GenesisState struct {
-	WriteSet []GenesisWriteOp `json:"write_set"`
+	WriteSet   []GenesisWriteOp `json:"write_set"`
+	Modules    ModulesMetadata  `json:"modules,omitempty"`
+	Parameters *Params          `json:"parameters,omitempty"`
 }
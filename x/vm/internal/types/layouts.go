@@ -0,0 +1,562 @@
+// Move values layouts and LCS decoding.
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+const (
+	// Value layout types.
+	LayoutU8      = "u8"
+	LayoutU64     = "u64"
+	LayoutU128    = "u128"
+	LayoutBool    = "bool"
+	LayoutAddress = "address"
+	LayoutBytes   = "bytes" // vector<u8>
+	LayoutVector  = "vector"
+	LayoutStruct  = "struct"
+)
+
+var (
+	// Registered struct layouts by struct tag.
+	structLayouts = make(map[string]ValueLayout)
+	// Registered known resources layouts by resource name.
+	resourceLayouts = make(map[string]ValueLayout)
+)
+
+// Move value layout, describes how to decode LCS encoded value.
+type ValueLayout struct {
+	Type   string        `json:"type"`
+	Elem   *ValueLayout  `json:"elem,omitempty"`   // vector element layout
+	Fields []FieldLayout `json:"fields,omitempty"` // struct fields layouts (in declaration order)
+}
+
+// Move struct field layout.
+type FieldLayout struct {
+	Name string `json:"name"`
+	ValueLayout
+}
+
+// Validate layout.
+func (l ValueLayout) Validate() error {
+	switch l.Type {
+	case LayoutU8, LayoutU64, LayoutU128, LayoutBool, LayoutAddress, LayoutBytes:
+		return nil
+
+	case LayoutVector:
+		if l.Elem == nil {
+			return fmt.Errorf("vector: elem layout not specified")
+		}
+
+		if err := l.Elem.Validate(); err != nil {
+			return fmt.Errorf("vector: %w", err)
+		}
+
+		return nil
+
+	case LayoutStruct:
+		for _, field := range l.Fields {
+			if field.Name == "" {
+				return fmt.Errorf("struct: empty field name")
+			}
+
+			if err := field.ValueLayout.Validate(); err != nil {
+				return fmt.Errorf("struct field %q: %w", field.Name, err)
+			}
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown layout type %q", l.Type)
+	}
+}
+
+// Create struct layout.
+func NewStructLayout(fields ...FieldLayout) ValueLayout {
+	return ValueLayout{Type: LayoutStruct, Fields: fields}
+}
+
+// Create struct field layout.
+func NewFieldLayout(name string, layout ValueLayout) FieldLayout {
+	return FieldLayout{Name: name, ValueLayout: layout}
+}
+
+// Create primitive layout.
+func NewLayout(layoutType string) ValueLayout {
+	return ValueLayout{Type: layoutType}
+}
+
+// Create vector layout.
+func NewVectorLayout(elem ValueLayout) ValueLayout {
+	return ValueLayout{Type: LayoutVector, Elem: &elem}
+}
+
+// Get layout for primitive VM type tag.
+func GetTypeTagLayout(tag vm_grpc.VMTypeTag) (ValueLayout, bool) {
+	switch tag {
+	case vm_grpc.VMTypeTag_U8:
+		return NewLayout(LayoutU8), true
+	case vm_grpc.VMTypeTag_U64:
+		return NewLayout(LayoutU64), true
+	case vm_grpc.VMTypeTag_U128:
+		return NewLayout(LayoutU128), true
+	case vm_grpc.VMTypeTag_Bool:
+		return NewLayout(LayoutBool), true
+	case vm_grpc.VMTypeTag_Address:
+		return NewLayout(LayoutAddress), true
+	case vm_grpc.VMTypeTag_ByteArray:
+		return NewLayout(LayoutBytes), true
+	default:
+		return ValueLayout{}, false
+	}
+}
+
+// Build struct tag string: 0x{address}::{module}::{name}.
+func StructTagToString(address []byte, module, name string) string {
+	return fmt.Sprintf("0x%s::%s::%s", hex.EncodeToString(address), module, name)
+}
+
+// Register struct layout (should be called on init).
+func RegisterStructLayout(address []byte, module, name string, layout ValueLayout) {
+	if err := layout.Validate(); err != nil {
+		panic(fmt.Errorf("struct %s::%s layout: %w", module, name, err))
+	}
+
+	structLayouts[StructTagToString(address, module, name)] = layout
+}
+
+// Get registered struct layout.
+func GetStructLayout(address []byte, module, name string) (ValueLayout, bool) {
+	layout, ok := structLayouts[StructTagToString(address, module, name)]
+	return layout, ok
+}
+
+// Register known resource layout by name (should be called on init).
+func RegisterResourceLayout(name string, layout ValueLayout) {
+	if err := layout.Validate(); err != nil {
+		panic(fmt.Errorf("resource %s layout: %w", name, err))
+	}
+
+	resourceLayouts[name] = layout
+}
+
+// Get known resource layout by name.
+func GetResourceLayout(name string) (ValueLayout, bool) {
+	layout, ok := resourceLayouts[name]
+	return layout, ok
+}
+
+// Get known resources names.
+func GetResourceLayoutNames() []string {
+	names := make([]string, 0, len(resourceLayouts))
+	for name := range resourceLayouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Decode LCS encoded value using layout.
+// Result is JSON friendly: numbers (except u8) are decimal strings, addresses are bech32 (or hex), bytes are hex.
+func DecodeLCS(data []byte, layout ValueLayout) (interface{}, error) {
+	reader := bytes.NewReader(data)
+
+	value, err := decodeLCSValue(reader, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("%d bytes left after decoding", reader.Len())
+	}
+
+	return value, nil
+}
+
+// Decode LCS value and return it as string: primitives are formatted, complex values are JSON encoded.
+func DecodeLCSToString(data []byte, layout ValueLayout) (string, error) {
+	value, err := DecodeLCS(data, layout)
+	if err != nil {
+		return "", err
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case uint8, bool:
+		return fmt.Sprintf("%v", v), nil
+	default:
+		bz, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+
+		return string(bz), nil
+	}
+}
+
+// Encode value to LCS using layout.
+// Value is expected in JSON decoded form (json.Number / string for numbers, bech32 / hex string for addresses,
+// hex string or numbers array for bytes, array for vectors, object for structs).
+func EncodeLCS(value interface{}, layout ValueLayout) ([]byte, error) {
+	buf := bytes.Buffer{}
+	if err := encodeLCSValue(&buf, value, layout); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeLCSValue(buf *bytes.Buffer, value interface{}, layout ValueLayout) error {
+	switch layout.Type {
+	case LayoutU8, LayoutU64, LayoutU128:
+		bitLen := map[string]int{LayoutU8: 8, LayoutU64: 64, LayoutU128: 128}[layout.Type]
+
+		n, ok := new(big.Int), false
+		switch v := value.(type) {
+		case json.Number:
+			n, ok = n.SetString(v.String(), 10)
+		case string:
+			n, ok = n.SetString(v, 10)
+		case float64:
+			n, ok = n.SetString(strconv.FormatFloat(v, 'f', -1, 64), 10)
+		}
+		if !ok || n.Sign() < 0 {
+			return fmt.Errorf("%v is not an unsigned number", value)
+		}
+
+		if n.BitLen() > bitLen {
+			return fmt.Errorf("%v overflows %s", value, layout.Type)
+		}
+
+		// big endian to little endian
+		bz := n.Bytes()
+		le := make([]byte, bitLen/8)
+		for i := range bz {
+			le[i] = bz[len(bz)-1-i]
+		}
+		buf.Write(le)
+
+		return nil
+
+	case LayoutBool:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("%v is not a bool", value)
+		}
+
+		if v {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+
+		return nil
+
+	case LayoutAddress:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%v is not an address string", value)
+		}
+
+		address, err := parseLibraAddress(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(address)
+
+		return nil
+
+	case LayoutBytes:
+		var bz []byte
+		switch v := value.(type) {
+		case string:
+			decoded, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+			if err != nil {
+				return fmt.Errorf("%q is not a HEX string: %w", v, err)
+			}
+			bz = decoded
+
+		case []interface{}:
+			bz = make([]byte, 0, len(v))
+			for i, item := range v {
+				itemBuf := bytes.Buffer{}
+				if err := encodeLCSValue(&itemBuf, item, NewLayout(LayoutU8)); err != nil {
+					return fmt.Errorf("bytes item %d: %w", i, err)
+				}
+				bz = append(bz, itemBuf.Bytes()...)
+			}
+
+		default:
+			return fmt.Errorf("%v is not a HEX string or bytes array", value)
+		}
+
+		writeLCSLength(buf, len(bz))
+		buf.Write(bz)
+
+		return nil
+
+	case LayoutVector:
+		if layout.Elem == nil {
+			return fmt.Errorf("vector: elem layout not specified")
+		}
+
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%v is not an array", value)
+		}
+
+		writeLCSLength(buf, len(items))
+		for i, item := range items {
+			if err := encodeLCSValue(buf, item, *layout.Elem); err != nil {
+				return fmt.Errorf("vector elem %d: %w", i, err)
+			}
+		}
+
+		return nil
+
+	case LayoutStruct:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%v is not an object", value)
+		}
+
+		if len(fields) != len(layout.Fields) {
+			return fmt.Errorf("struct fields count mismatch: expected %d, got %d", len(layout.Fields), len(fields))
+		}
+
+		for _, field := range layout.Fields {
+			fieldValue, ok := fields[field.Name]
+			if !ok {
+				return fmt.Errorf("field %q not found", field.Name)
+			}
+
+			if err := encodeLCSValue(buf, fieldValue, field.ValueLayout); err != nil {
+				return fmt.Errorf("field %q: %w", field.Name, err)
+			}
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown layout type %q", layout.Type)
+	}
+}
+
+// Write LCS length prefix (ULEB128).
+func writeLCSLength(buf *bytes.Buffer, length int) {
+	value := uint64(length)
+	for value >= 0x80 {
+		buf.WriteByte(byte(value) | 0x80)
+		value >>= 7
+	}
+	buf.WriteByte(byte(value))
+}
+
+// Parse bech32 or HEX (0x prefixed, 20 or 24 bytes) address to Libra address.
+func parseLibraAddress(address string) ([]byte, error) {
+	if strings.HasPrefix(address, "0x") {
+		bz, err := hex.DecodeString(address[2:])
+		if err != nil {
+			return nil, fmt.Errorf("address %q: %w", address, err)
+		}
+
+		switch len(bz) {
+		case common_vm.VMAddressLength:
+			return bz, nil
+		case sdk.AddrLen:
+			return common_vm.Bech32ToLibra(bz), nil
+		default:
+			return nil, fmt.Errorf("address %q: invalid length %d", address, len(bz))
+		}
+	}
+
+	accAddress, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		return nil, fmt.Errorf("address %q: %w", address, err)
+	}
+
+	return common_vm.Bech32ToLibra(accAddress), nil
+}
+
+func decodeLCSValue(reader *bytes.Reader, layout ValueLayout) (interface{}, error) {
+	switch layout.Type {
+	case LayoutU8:
+		return reader.ReadByte()
+
+	case LayoutBool:
+		b, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch b {
+		case 0:
+			return false, nil
+		case 1:
+			return true, nil
+		default:
+			return nil, fmt.Errorf("invalid bool value: %d", b)
+		}
+
+	case LayoutU64:
+		bz, err := readBytes(reader, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		return fmt.Sprintf("%d", binary.LittleEndian.Uint64(bz)), nil
+
+	case LayoutU128:
+		bz, err := readBytes(reader, 16)
+		if err != nil {
+			return nil, err
+		}
+
+		// little endian to big endian
+		for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+			bz[i], bz[j] = bz[j], bz[i]
+		}
+
+		return new(big.Int).SetBytes(bz).String(), nil
+
+	case LayoutAddress:
+		bz, err := readBytes(reader, common_vm.VMAddressLength)
+		if err != nil {
+			return nil, err
+		}
+
+		return LibraAddressToString(bz), nil
+
+	case LayoutBytes:
+		length, err := readLCSLength(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		bz, err := readBytes(reader, length)
+		if err != nil {
+			return nil, err
+		}
+
+		return "0x" + hex.EncodeToString(bz), nil
+
+	case LayoutVector:
+		if layout.Elem == nil {
+			return nil, fmt.Errorf("vector: elem layout not specified")
+		}
+
+		length, err := readLCSLength(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]interface{}, 0, length)
+		for i := 0; i < length; i++ {
+			value, err := decodeLCSValue(reader, *layout.Elem)
+			if err != nil {
+				return nil, fmt.Errorf("vector elem %d: %w", i, err)
+			}
+			values = append(values, value)
+		}
+
+		return values, nil
+
+	case LayoutStruct:
+		values := make(map[string]interface{}, len(layout.Fields))
+		for _, field := range layout.Fields {
+			value, err := decodeLCSValue(reader, field.ValueLayout)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			values[field.Name] = value
+		}
+
+		return values, nil
+
+	default:
+		return nil, fmt.Errorf("unknown layout type %q", layout.Type)
+	}
+}
+
+// Read LCS length prefix (ULEB128).
+func readLCSLength(reader *bytes.Reader) (int, error) {
+	var length uint64
+	for shift := uint(0); shift < 32; shift += 7 {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		length |= uint64(b&0x7F) << shift
+		if b&0x80 == 0 {
+			if length > uint64(reader.Len()) {
+				return 0, fmt.Errorf("length %d exceeds data size", length)
+			}
+
+			return int(length), nil
+		}
+	}
+
+	return 0, fmt.Errorf("malformed length prefix")
+}
+
+// Read exact amount of bytes.
+func readBytes(reader *bytes.Reader, n int) ([]byte, error) {
+	if reader.Len() < n {
+		return nil, fmt.Errorf("expected %d bytes, got %d", n, reader.Len())
+	}
+
+	bz := make([]byte, n)
+	if _, err := io.ReadFull(reader, bz); err != nil {
+		return nil, err
+	}
+
+	return bz, nil
+}
+
+// Convert Libra address to bech32 string (if address was converted from bech32) or hex.
+func LibraAddressToString(address []byte) string {
+	if len(address) == common_vm.VMAddressLength && bytes.Equal(address[sdk.AddrLen:], make([]byte, common_vm.VMAddressLength-sdk.AddrLen)) {
+		return sdk.AccAddress(address[:sdk.AddrLen]).String()
+	}
+
+	return "0x" + hex.EncodeToString(address)
+}
+
+// Format struct layout for output.
+func (l ValueLayout) String() string {
+	switch l.Type {
+	case LayoutVector:
+		if l.Elem == nil {
+			return "vector<?>"
+		}
+		return "vector<" + l.Elem.String() + ">"
+
+	case LayoutStruct:
+		fields := make([]string, 0, len(l.Fields))
+		for _, field := range l.Fields {
+			fields = append(fields, field.Name+": "+field.ValueLayout.String())
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+
+	default:
+		return l.Type
+	}
+}
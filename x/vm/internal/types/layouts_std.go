@@ -0,0 +1,60 @@
+// Standard library structs layouts.
+package types
+
+import (
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+// Register standard library structs layouts (events and resources).
+func init() {
+	eventHandleLayout := NewStructLayout(
+		NewFieldLayout("counter", NewLayout(LayoutU64)),
+		NewFieldLayout("guid", NewLayout(LayoutBytes)),
+	)
+
+	// events.
+	RegisterStructLayout(common_vm.ZeroAddress, "Account", "SentPaymentEvent", NewStructLayout(
+		NewFieldLayout("amount", NewLayout(LayoutU128)),
+		NewFieldLayout("denom", NewLayout(LayoutBytes)),
+		NewFieldLayout("payee", NewLayout(LayoutAddress)),
+		NewFieldLayout("metadata", NewLayout(LayoutBytes)),
+	))
+
+	RegisterStructLayout(common_vm.ZeroAddress, "Account", "ReceivedPaymentEvent", NewStructLayout(
+		NewFieldLayout("amount", NewLayout(LayoutU128)),
+		NewFieldLayout("denom", NewLayout(LayoutBytes)),
+		NewFieldLayout("payer", NewLayout(LayoutAddress)),
+		NewFieldLayout("metadata", NewLayout(LayoutBytes)),
+	))
+
+	// resources.
+	balanceLayout := NewStructLayout(
+		NewFieldLayout("value", NewLayout(LayoutU128)),
+	)
+	RegisterStructLayout(common_vm.ZeroAddress, "Account", "Balance", balanceLayout)
+	RegisterResourceLayout("BalanceResource", balanceLayout)
+
+	accountLayout := NewStructLayout(
+		NewFieldLayout("sent_events", eventHandleLayout),
+		NewFieldLayout("received_events", eventHandleLayout),
+	)
+	RegisterStructLayout(common_vm.ZeroAddress, "Account", "T", accountLayout)
+	RegisterResourceLayout("AccountResource", accountLayout)
+
+	eventHandleGenLayout := NewStructLayout(
+		NewFieldLayout("counter", NewLayout(LayoutU64)),
+		NewFieldLayout("addr", NewLayout(LayoutAddress)),
+	)
+	RegisterStructLayout(common_vm.ZeroAddress, "Event", "EventHandleGenerator", eventHandleGenLayout)
+	RegisterResourceLayout("EventHandleGenerator", eventHandleGenLayout)
+
+	currencyInfoLayout := NewStructLayout(
+		NewFieldLayout("denom", NewLayout(LayoutBytes)),
+		NewFieldLayout("decimals", NewLayout(LayoutU8)),
+		NewFieldLayout("is_token", NewLayout(LayoutBool)),
+		NewFieldLayout("owner", NewLayout(LayoutAddress)),
+		NewFieldLayout("total_supply", NewLayout(LayoutU128)),
+	)
+	RegisterStructLayout(common_vm.ZeroAddress, "Dfinance", "Info", currencyInfoLayout)
+	RegisterResourceLayout("CurrencyInfo", currencyInfoLayout)
+}
@@ -0,0 +1,224 @@
+// +build unit
+
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dfinance/dnode/x/common_vm"
+	"github.com/dfinance/dnode/x/vmauth"
+)
+
+// Test LCS decoding of primitive values.
+func TestDecodeLCS_Primitives(t *testing.T) {
+	t.Parallel()
+
+	// u8
+	{
+		value, err := DecodeLCSToString([]byte{0xFF}, NewLayout(LayoutU8))
+		require.NoError(t, err)
+		require.Equal(t, "255", value)
+	}
+
+	// u64
+	{
+		value, err := DecodeLCSToString([]byte{0x6E, 0, 0, 0, 0, 0, 0, 0}, NewLayout(LayoutU64))
+		require.NoError(t, err)
+		require.Equal(t, "110", value)
+	}
+
+	// u128 (max)
+	{
+		bz := make([]byte, 16)
+		for i := range bz {
+			bz[i] = 0xFF
+		}
+
+		value, err := DecodeLCSToString(bz, NewLayout(LayoutU128))
+		require.NoError(t, err)
+		require.Equal(t, "340282366920938463463374607431768211455", value)
+	}
+
+	// bool
+	{
+		value, err := DecodeLCSToString([]byte{1}, NewLayout(LayoutBool))
+		require.NoError(t, err)
+		require.Equal(t, "true", value)
+
+		_, err = DecodeLCS([]byte{2}, NewLayout(LayoutBool))
+		require.Error(t, err)
+	}
+
+	// address: bech32 and raw
+	{
+		addr := make([]byte, 24)
+		addr[0] = 1
+
+		value, err := DecodeLCSToString(addr, NewLayout(LayoutAddress))
+		require.NoError(t, err)
+		require.Equal(t, sdk.AccAddress(addr[:20]).String(), value)
+
+		addr[23] = 1
+		value, err = DecodeLCSToString(addr, NewLayout(LayoutAddress))
+		require.NoError(t, err)
+		require.Equal(t, "0x010000000000000000000000000000000000000000000001", value)
+	}
+
+	// bytes
+	{
+		value, err := DecodeLCSToString([]byte{2, 0xAB, 0xCD}, NewLayout(LayoutBytes))
+		require.NoError(t, err)
+		require.Equal(t, "0xabcd", value)
+	}
+}
+
+// Test LCS decoding of complex values and malformed data.
+func TestDecodeLCS_Complex(t *testing.T) {
+	t.Parallel()
+
+	layout := NewStructLayout(
+		NewFieldLayout("flag", NewLayout(LayoutBool)),
+		NewFieldLayout("values", NewVectorLayout(NewLayout(LayoutU64))),
+	)
+	data := []byte{
+		1,
+		2,
+		1, 0, 0, 0, 0, 0, 0, 0,
+		2, 0, 0, 0, 0, 0, 0, 0,
+	}
+
+	value, err := DecodeLCSToString(data, layout)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"flag":true,"values":["1","2"]}`, value)
+
+	// trailing bytes
+	_, err = DecodeLCS(append(data, 0), layout)
+	require.Error(t, err)
+
+	// not enough bytes
+	_, err = DecodeLCS(data[:len(data)-1], layout)
+	require.Error(t, err)
+
+	// vector length exceeds data
+	_, err = DecodeLCS([]byte{10, 1}, NewLayout(LayoutBytes))
+	require.Error(t, err)
+}
+
+// Test layout JSON format and validation.
+func TestValueLayout_JSON(t *testing.T) {
+	t.Parallel()
+
+	layout := NewStructLayout(
+		NewFieldLayout("amount", NewLayout(LayoutU128)),
+		NewFieldLayout("items", NewVectorLayout(NewLayout(LayoutAddress))),
+	)
+
+	bz, err := json.Marshal(layout)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"struct","fields":[{"name":"amount","type":"u128"},{"name":"items","type":"vector","elem":{"type":"address"}}]}`, string(bz))
+
+	var parsedLayout ValueLayout
+	require.NoError(t, json.Unmarshal(bz, &parsedLayout))
+	require.NoError(t, parsedLayout.Validate())
+	require.Equal(t, layout, parsedLayout)
+
+	require.Error(t, ValueLayout{Type: "u32"}.Validate())
+	require.Error(t, ValueLayout{Type: LayoutVector}.Validate())
+	require.Error(t, NewStructLayout(NewFieldLayout("", NewLayout(LayoutU8))).Validate())
+}
+
+// Test known resources layouts are compatible with vmauth resources LCS encoding.
+func TestResourceLayouts(t *testing.T) {
+	t.Parallel()
+
+	addr := sdk.AccAddress(make([]byte, sdk.AddrLen))
+	addr[0] = 1
+
+	// balance
+	{
+		layout, ok := GetResourceLayout("BalanceResource")
+		require.True(t, ok)
+
+		value, err := DecodeLCSToString(vmauth.BalanceToBytes(vmauth.BalanceResource{Value: big.NewInt(1000)}), layout)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"value":"1000"}`, value)
+	}
+
+	// account and event handle generator
+	{
+		acc := auth.NewBaseAccountWithAddress(addr)
+		accRes, ehGen := vmauth.CreateVMAccount(&acc)
+
+		layout, ok := GetResourceLayout("AccountResource")
+		require.True(t, ok)
+
+		value, err := DecodeLCS(vmauth.AccResToBytes(accRes), layout)
+		require.NoError(t, err)
+		require.Equal(t, "0", value.(map[string]interface{})["sent_events"].(map[string]interface{})["counter"])
+		require.Equal(t, "0", value.(map[string]interface{})["received_events"].(map[string]interface{})["counter"])
+
+		layout, ok = GetResourceLayout("EventHandleGenerator")
+		require.True(t, ok)
+
+		valueStr, err := DecodeLCSToString(vmauth.EventHandlerGenToBytes(ehGen), layout)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"counter":"2","addr":"`+addr.String()+`"}`, valueStr)
+	}
+
+	// layout by struct tag
+	{
+		_, ok := GetStructLayout(common_vm.ZeroAddress, "Dfinance", "Info")
+		require.True(t, ok)
+	}
+
+	require.Equal(t, []string{"AccountResource", "BalanceResource", "CurrencyInfo", "EventHandleGenerator"}, GetResourceLayoutNames())
+}
+
+// Test LCS encoding is compatible with decoding.
+func TestEncodeLCS(t *testing.T) {
+	t.Parallel()
+
+	addr := sdk.AccAddress(make([]byte, sdk.AddrLen))
+	addr[0] = 1
+
+	layout := NewStructLayout(
+		NewFieldLayout("amount", NewLayout(LayoutU128)),
+		NewFieldLayout("flags", NewVectorLayout(NewLayout(LayoutBool))),
+		NewFieldLayout("payee", NewLayout(LayoutAddress)),
+		NewFieldLayout("data", NewLayout(LayoutBytes)),
+		NewFieldLayout("small", NewLayout(LayoutU8)),
+	)
+	value := map[string]interface{}{
+		"amount": json.Number("1000"),
+		"flags":  []interface{}{true, false},
+		"payee":  addr.String(),
+		"data":   "0x0102",
+		"small":  json.Number("7"),
+	}
+
+	bz, err := EncodeLCS(value, layout)
+	require.NoError(t, err)
+
+	decoded, err := DecodeLCSToString(bz, layout)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount":"1000","flags":[true,false],"payee":"`+addr.String()+`","data":"0x0102","small":7}`, decoded)
+
+	// missing field
+	delete(value, "small")
+	_, err = EncodeLCS(value, layout)
+	require.Error(t, err)
+
+	// overflow
+	_, err = EncodeLCS(json.Number("256"), NewLayout(LayoutU8))
+	require.Error(t, err)
+
+	// negative
+	_, err = EncodeLCS("-1", NewLayout(LayoutU64))
+	require.Error(t, err)
+}
@@ -0,0 +1,219 @@
+// Deployed modules registry types.
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+const (
+	// Supported Move bytecode binary format version.
+	bytecodeVersion = 1
+
+	// Move bytecode table types.
+	// Current and legacy binary formats have the same version, but different table types: identifiers pool is 0x7
+	// in the current format and 0x5 in the legacy one, where 0x7 is the script main function table (absent in modules).
+	bytecodeModuleHandlesTable     = 0x1
+	bytecodeIdentifiersTable       = 0x7
+	bytecodeLegacyIdentifiersTable = 0x5
+)
+
+const (
+	// Module upgrade policies.
+	ModulePolicyImmutable = "immutable" // module can't be upgraded
+	ModulePolicyOwner     = "owner"     // module can be upgraded by deployer or via multisig call
+	ModulePolicyMultisig  = "multisig"  // module can be upgraded only via multisig call
+
+	DefaultModulePolicy = ModulePolicyOwner
+)
+
+var (
+	KeyModulesPrefix = []byte("modules") // used to store modules registry
+
+	// Address of privileged (standard library) modules, that could be upgraded only via multisig call.
+	PrivilegedModulesAddress = sdk.AccAddress(make([]byte, sdk.AddrLen))
+
+	bytecodeMagic = []byte{0xA1, 0x1C, 0xEB, 0x0B}
+
+)
+
+// Deployed module metadata.
+type ModuleMetadata struct {
+	Deployer sdk.AccAddress `json:"deployer"`
+	Name     string         `json:"name"`
+	Height   int64          `json:"height"`
+	CodeHash string         `json:"code_hash" format:"HEX string"`
+	Path     string         `json:"path" format:"HEX string"` // VM access path of module bytecode
+	Policy   string         `json:"policy"`                   // upgrade policy
+}
+
+func (m ModuleMetadata) String() string {
+	return fmt.Sprintf("Module:\n"+
+		"  Deployer: %s\n"+
+		"  Name:     %s\n"+
+		"  Height:   %d\n"+
+		"  CodeHash: %s\n"+
+		"  Path:     %s\n"+
+		"  Policy:   %s\n",
+		m.Deployer, m.Name, m.Height, m.CodeHash, m.Path, m.Policy,
+	)
+}
+
+// Check module upgrade policy is valid.
+func IsValidModulePolicy(policy string) bool {
+	switch policy {
+	case ModulePolicyImmutable, ModulePolicyOwner, ModulePolicyMultisig:
+		return true
+	default:
+		return false
+	}
+}
+
+// Modules metadata list.
+type ModulesMetadata []ModuleMetadata
+
+func (list ModulesMetadata) String() string {
+	b := strings.Builder{}
+	for _, m := range list {
+		b.WriteString(m.String())
+	}
+
+	return b.String()
+}
+
+// Get modules registry key prefix for address.
+func GetModulesAddressPrefix(address sdk.AccAddress) []byte {
+	return bytes.Join(
+		[][]byte{
+			KeyModulesPrefix,
+			address,
+			{},
+		},
+		common_vm.KeyDelimiter,
+	)
+}
+
+// Get modules registry key.
+func GetModuleKey(address sdk.AccAddress, name string) []byte {
+	return append(GetModulesAddressPrefix(address), []byte(name)...)
+}
+
+// Extract module name from Move module bytecode (self module handle name).
+// Binary format (current or legacy) is detected by the identifiers table type (see bytecodeIdentifiersTable).
+func GetModuleName(code []byte) (string, error) {
+	// magic (4 bytes), version (2 bytes), tables count (1 byte).
+	if len(code) < 7 || !bytes.Equal(code[:4], bytecodeMagic) {
+		return "", fmt.Errorf("invalid bytecode: wrong header")
+	}
+
+	if version := binary.LittleEndian.Uint16(code[4:6]); version != bytecodeVersion {
+		return "", fmt.Errorf("unsupported bytecode version %d", version)
+	}
+
+	tables := make(map[byte][]byte, code[6])
+	for i, offset := 0, 7; i < int(code[6]); i, offset = i+1, offset+9 {
+		// table kind (1 byte), table offset (4 bytes), table length (4 bytes).
+		if len(code) < offset+9 {
+			return "", fmt.Errorf("invalid bytecode: table header %d out of bounds", i)
+		}
+
+		start := uint64(binary.LittleEndian.Uint32(code[offset+1:]))
+		end := start + uint64(binary.LittleEndian.Uint32(code[offset+5:]))
+		if end > uint64(len(code)) {
+			return "", fmt.Errorf("invalid bytecode: table %d out of bounds", i)
+		}
+
+		tables[code[offset]] = code[start:end]
+	}
+
+	handles, ok := tables[bytecodeModuleHandlesTable]
+	if !ok {
+		return "", fmt.Errorf("invalid bytecode: module handles table not found")
+	}
+
+	// self module handle is always the first one: address index, name index.
+	_, n, err := readUleb128(handles)
+	if err != nil {
+		return "", fmt.Errorf("invalid bytecode: module handle: %w", err)
+	}
+
+	nameIdx, _, err := readUleb128(handles[n:])
+	if err != nil {
+		return "", fmt.Errorf("invalid bytecode: module handle: %w", err)
+	}
+
+	identifiers, ok := tables[bytecodeIdentifiersTable]
+	if !ok {
+		if identifiers, ok = tables[bytecodeLegacyIdentifiersTable]; !ok {
+			return "", fmt.Errorf("invalid bytecode: identifiers table not found")
+		}
+	}
+
+	name, err := getIdentifier(identifiers, nameIdx)
+	if err != nil {
+		return "", fmt.Errorf("invalid bytecode: module name: %w", err)
+	}
+
+	if !isValidIdentifier(name) {
+		return "", fmt.Errorf("invalid bytecode: module name %q is not a valid identifier", name)
+	}
+
+	return name, nil
+}
+
+// Get identifier by index from identifiers table.
+func getIdentifier(table []byte, idx uint64) (string, error) {
+	for i := uint64(0); len(table) > 0; i++ {
+		length, n, err := readUleb128(table)
+		if err != nil {
+			return "", err
+		}
+
+		if uint64(len(table)-n) < length {
+			return "", fmt.Errorf("identifier %d out of bounds", i)
+		}
+
+		if i == idx {
+			return string(table[n : n+int(length)]), nil
+		}
+
+		table = table[n+int(length):]
+	}
+
+	return "", fmt.Errorf("identifier %d not found", idx)
+}
+
+// Read ULEB128 encoded value, returns value and amount of bytes read.
+func readUleb128(buf []byte) (uint64, int, error) {
+	var value uint64
+	for i := 0; i < len(buf) && i < 10; i++ {
+		value |= uint64(buf[i]&0x7F) << (7 * uint(i))
+		if buf[i]&0x80 == 0 {
+			return value, i + 1, nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("malformed ULEB128 value")
+}
+
+// Check Move identifier is valid: [a-zA-Z_][a-zA-Z0-9_]*.
+func isValidIdentifier(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+
+	for i, c := range name {
+		isAlpha := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+		if !isAlpha && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,79 @@
+// +build unit
+
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+// Test module name extraction from bytecode.
+func TestGetModuleName(t *testing.T) {
+	t.Parallel()
+
+	// current binary format (standard library "Oracle" module)
+	{
+		code, err := hex.DecodeString("a11ceb0b010007014600000002000000034800000005000000054d00000002000000074f000000110000000860000000180000000f78000000010000000c7900000003000000000000010000000103064f7261636c65096765745f707269636500000000000000000000000000000000000000000000000000000300")
+		require.NoError(t, err)
+
+		name, err := GetModuleName(code)
+		require.NoError(t, err)
+		require.Equal(t, "Oracle", name)
+	}
+
+	// current binary format (standard library "DFI" module)
+	{
+		code, err := hex.DecodeString("a11ceb0b010006013d00000002000000023f000000040000000743000000120000000855000000180000000f6d000000010000000a6e000000050000000000000102000344464901540b64756d6d795f6669656c64000000000000000000000000000000000000000000000000000002010201")
+		require.NoError(t, err)
+
+		name, err := GetModuleName(code)
+		require.NoError(t, err)
+		require.Equal(t, "DFI", name)
+	}
+
+	// legacy binary format (no 0x7 table, identifiers are in 0x5 table)
+	{
+		code, err := hex.DecodeString("a11ceb0b01000b016a00000004000000026e0000000800000003760000000c0000000b82000000060000000c88000000210000000da90000002500000005ce000000620000000430010000400000000870010000040000000974010000060000000a7a0100006d00000000000101000201000102010000030000040100050200060301080100010502000208010005000201080000010500020108000000000201080100010800000003030801000508000003000304050800000608000005030208000005030308000008010005124561726d61726b65644c69627261436f696e094c69627261436f696e01540663726561746513636c61696d5f666f725f726563697069656e7411636c61696d5f666f725f63726561746f7206756e7772617004636f696e09726563697069656e742eb8d97a078f3ae572b0ea70362080c3e188a7e6000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000020200000700000801000100020007000b000b011300010c020b023100010201010100020212000b003000010c010e010c022c0c030b021001150b032221041000066300000000000000280b010202010100010307002c0c010b013000010c000b0002030100010406000b001400010c020c010b0102")
+		require.NoError(t, err)
+
+		name, err := GetModuleName(code)
+		require.NoError(t, err)
+		require.Equal(t, "EarmarkedLibraCoin", name)
+	}
+
+	// invalid header
+	{
+		_, err := GetModuleName([]byte{0x1, 0x2, 0x3})
+		require.Error(t, err)
+
+		_, err = GetModuleName([]byte{0xA1, 0x1C, 0xEB, 0x0B, 0x1, 0x0, 0x1})
+		require.Error(t, err)
+	}
+
+	// unsupported version
+	{
+		_, err := GetModuleName([]byte{0xA1, 0x1C, 0xEB, 0x0B, 0x2, 0x0, 0x0})
+		require.Error(t, err)
+	}
+
+	// table out of bounds
+	{
+		_, err := GetModuleName([]byte{0xA1, 0x1C, 0xEB, 0x0B, 0x1, 0x0, 0x1, 0x1, 0xFF, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0})
+		require.Error(t, err)
+	}
+}
+
+// Test modules registry keys.
+func TestGetModuleKey(t *testing.T) {
+	t.Parallel()
+
+	addr1 := sdk.AccAddress([]byte("addr1_______________"))
+	addr2 := sdk.AccAddress([]byte("addr2_______________"))
+
+	key := GetModuleKey(addr1, "Module")
+	require.Equal(t, append(GetModulesAddressPrefix(addr1), []byte("Module")...), key)
+	require.NotEqual(t, GetModulesAddressPrefix(addr1), GetModulesAddressPrefix(addr2))
+}
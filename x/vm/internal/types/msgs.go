@@ -12,15 +12,24 @@ import (
 var (
 	MsgDeployModuleType  = "deploy_module"
 	MsgExecuteScriptType = "execute_script"
+	MsgUpgradeModuleType = "upgrade_module"
+	MsgUpdateParamsType  = "update_params"
+	MsgExecuteBatchType  = "execute_batch"
+
+	// Max number of contracts in a batch.
+	MaxBatchContracts = 16
 
 	_ sdk.Msg = MsgDeployModule{}
 	_ sdk.Msg = MsgExecuteScript{}
+	_ sdk.Msg = MsgExecuteBatch{}
 )
 
 // Message to deploy contract.
+// Policy is optional: default policy is used for a new module, current policy is kept on upgrade.
 type MsgDeployModule struct {
 	Signer sdk.AccAddress `json:"signer"`
 	Module Contract       `json:"module"`
+	Policy string         `json:"policy,omitempty"`
 }
 
 func NewMsgDeployModule(signer sdk.AccAddress, module Contract) MsgDeployModule {
@@ -30,6 +39,12 @@ func NewMsgDeployModule(signer sdk.AccAddress, module Contract) MsgDeployModule
 	}
 }
 
+// Set module upgrade policy.
+func (msg MsgDeployModule) WithPolicy(policy string) MsgDeployModule {
+	msg.Policy = policy
+	return msg
+}
+
 func (MsgDeployModule) Route() string {
 	return RouterKey
 }
@@ -47,6 +62,14 @@ func (msg MsgDeployModule) ValidateBasic() error {
 		return ErrEmptyContract
 	}
 
+	if err := CheckBytecodeSize(msg.Module, MaxBytecodeSizeLimit); err != nil {
+		return err
+	}
+
+	if msg.Policy != "" && !IsValidModulePolicy(msg.Policy) {
+		return sdkErrors.Wrapf(ErrWrongModulePolicy, "%q", msg.Policy)
+	}
+
 	return nil
 }
 
@@ -109,6 +132,10 @@ func (msg MsgExecuteScript) ValidateBasic() error {
 		return ErrEmptyContract
 	}
 
+	if err := CheckBytecodeSize(msg.Script, MaxBytecodeSizeLimit); err != nil {
+		return err
+	}
+
 	for _, val := range msg.Args {
 		if _, err := VMTypeToString(val.Type); err != nil {
 			return sdkErrors.Wrap(ErrWrongArgTypeTag, err.Error())
@@ -130,3 +157,179 @@ func (msg MsgExecuteScript) GetSignBytes() []byte {
 func (msg MsgExecuteScript) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Signer}
 }
+
+// Batch contract: module to deploy or script to execute (with arguments).
+type BatchContract struct {
+	Type vm_grpc.ContractType `json:"type"`
+	Code Contract             `json:"code"`
+	Args []ScriptArg          `json:"args,omitempty"`
+}
+
+// New module batch contract.
+func NewBatchModule(module Contract) BatchContract {
+	return BatchContract{
+		Type: vm_grpc.ContractType_Module,
+		Code: module,
+	}
+}
+
+// New script batch contract.
+func NewBatchScript(script Contract, args []ScriptArg) BatchContract {
+	return BatchContract{
+		Type: vm_grpc.ContractType_Script,
+		Code: script,
+		Args: args,
+	}
+}
+
+// Check batch contract is valid.
+func (c BatchContract) Validate() error {
+	switch c.Type {
+	case vm_grpc.ContractType_Module:
+		if len(c.Args) > 0 {
+			return sdkErrors.Wrap(ErrWrongBatch, "module can't have arguments")
+		}
+	case vm_grpc.ContractType_Script:
+		for _, val := range c.Args {
+			if _, err := VMTypeToString(val.Type); err != nil {
+				return sdkErrors.Wrap(ErrWrongArgTypeTag, err.Error())
+			}
+		}
+	default:
+		return sdkErrors.Wrapf(ErrWrongBatch, "unknown contract type: %d", c.Type)
+	}
+
+	if len(c.Code) == 0 {
+		return ErrEmptyContract
+	}
+
+	return CheckBytecodeSize(c.Code, MaxBytecodeSizeLimit)
+}
+
+// Message to deploy modules and execute scripts atomically (in one transaction).
+// Write sets are applied in order, batch is aborted if any contract execution fails.
+type MsgExecuteBatch struct {
+	Signer    sdk.AccAddress  `json:"signer"`
+	Contracts []BatchContract `json:"contracts"`
+}
+
+func NewMsgExecuteBatch(signer sdk.AccAddress, contracts ...BatchContract) MsgExecuteBatch {
+	return MsgExecuteBatch{
+		Signer:    signer,
+		Contracts: contracts,
+	}
+}
+
+func (MsgExecuteBatch) Route() string {
+	return RouterKey
+}
+
+func (MsgExecuteBatch) Type() string {
+	return MsgExecuteBatchType
+}
+
+func (msg MsgExecuteBatch) ValidateBasic() error {
+	if msg.Signer.Empty() {
+		return sdkErrors.Wrap(sdkErrors.ErrInvalidAddress, "empty signer address")
+	}
+
+	if len(msg.Contracts) == 0 {
+		return sdkErrors.Wrap(ErrWrongBatch, "empty batch")
+	}
+
+	if len(msg.Contracts) > MaxBatchContracts {
+		return sdkErrors.Wrapf(ErrWrongBatch, "batch contains %d contracts, max %d", len(msg.Contracts), MaxBatchContracts)
+	}
+
+	for i, c := range msg.Contracts {
+		if err := c.Validate(); err != nil {
+			return sdkErrors.Wrapf(err, "contract %d", i)
+		}
+	}
+
+	return nil
+}
+
+func (msg MsgExecuteBatch) GetSignBytes() []byte {
+	bc, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(bc)
+}
+
+func (msg MsgExecuteBatch) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Signer}
+}
+
+// Multisignature message to deploy / upgrade module at any address (including privileged standard library address).
+// Module upgrade policy is checked, but "multisig" policy modules are allowed to be upgraded.
+type MsgUpgradeModule struct {
+	Address sdk.AccAddress `json:"address"`
+	Module  Contract       `json:"module"`
+	Policy  string         `json:"policy,omitempty"`
+}
+
+func NewMsgUpgradeModule(address sdk.AccAddress, module Contract, policy string) MsgUpgradeModule {
+	return MsgUpgradeModule{
+		Address: address,
+		Module:  module,
+		Policy:  policy,
+	}
+}
+
+func (MsgUpgradeModule) Route() string {
+	return RouterKey
+}
+
+func (MsgUpgradeModule) Type() string {
+	return MsgUpgradeModuleType
+}
+
+func (msg MsgUpgradeModule) ValidateBasic() error {
+	if len(msg.Address) != sdk.AddrLen {
+		return sdkErrors.Wrapf(sdkErrors.ErrInvalidAddress, "module address length should be %d", sdk.AddrLen)
+	}
+
+	if len(msg.Module) == 0 {
+		return ErrEmptyContract
+	}
+
+	if err := CheckBytecodeSize(msg.Module, MaxBytecodeSizeLimit); err != nil {
+		return err
+	}
+
+	if msg.Policy != "" && !IsValidModulePolicy(msg.Policy) {
+		return sdkErrors.Wrapf(ErrWrongModulePolicy, "%q", msg.Policy)
+	}
+
+	return nil
+}
+
+// Multisignature message to update VM params.
+type MsgUpdateParams struct {
+	Params Params `json:"params"`
+}
+
+func NewMsgUpdateParams(params Params) MsgUpdateParams {
+	return MsgUpdateParams{
+		Params: params,
+	}
+}
+
+func (MsgUpdateParams) Route() string {
+	return RouterKey
+}
+
+func (MsgUpdateParams) Type() string {
+	return MsgUpdateParamsType
+}
+
+func (msg MsgUpdateParams) ValidateBasic() error {
+	if err := msg.Params.Validate(); err != nil {
+		return sdkErrors.Wrap(ErrWrongParams, err.Error())
+	}
+
+	return nil
+}
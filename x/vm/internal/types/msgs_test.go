@@ -47,6 +47,38 @@ func TestMsgDeployModule(t *testing.T) {
 	msg = NewMsgDeployModule(acc, Contract{})
 	require.Empty(t, msg.Module)
 	tests.CheckExpectedErr(t, ErrEmptyContract, msg.ValidateBasic())
+
+	msg = NewMsgDeployModule(acc, code).WithPolicy(ModulePolicyImmutable)
+	require.NoError(t, msg.ValidateBasic())
+
+	msg = NewMsgDeployModule(acc, code).WithPolicy("unknown")
+	tests.CheckExpectedErr(t, ErrWrongModulePolicy, msg.ValidateBasic())
+
+	msg = NewMsgDeployModule(acc, make(Contract, MaxBytecodeSizeLimit+1))
+	tests.CheckExpectedErr(t, ErrBytecodeTooLarge, msg.ValidateBasic())
+}
+
+// Test MsgUpgradeModule.
+func TestMsgUpgradeModule(t *testing.T) {
+	t.Parallel()
+
+	code := make(Contract, 128)
+	msg := NewMsgUpgradeModule(PrivilegedModulesAddress, code, ModulePolicyMultisig)
+
+	require.Equal(t, msg.Address, PrivilegedModulesAddress)
+	require.Equal(t, msg.Module, code)
+	require.NoError(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, MsgUpgradeModuleType, msg.Type())
+
+	msg = NewMsgUpgradeModule(sdk.AccAddress([]byte("addr1")), code, "")
+	tests.CheckExpectedErr(t, sdkErrors.ErrInvalidAddress, msg.ValidateBasic())
+
+	msg = NewMsgUpgradeModule(PrivilegedModulesAddress, Contract{}, "")
+	tests.CheckExpectedErr(t, ErrEmptyContract, msg.ValidateBasic())
+
+	msg = NewMsgUpgradeModule(PrivilegedModulesAddress, code, "unknown")
+	tests.CheckExpectedErr(t, ErrWrongModulePolicy, msg.ValidateBasic())
 }
 
 // Test MsgExecuteScript.
@@ -97,3 +129,82 @@ func TestNewScriptArg(t *testing.T) {
 	require.Equal(t, tagType, arg.Type)
 	require.Equal(t, value, arg.Value)
 }
+
+// Test MsgUpdateParams.
+func TestMsgUpdateParams(t *testing.T) {
+	t.Parallel()
+
+	msg := NewMsgUpdateParams(DefaultParams())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, MsgUpdateParamsType, msg.Type())
+	require.NoError(t, msg.ValidateBasic())
+
+	msg = NewMsgUpdateParams(NewParams(0, DefaultMaxGasPerScript, DefaultMaxGasPerModule, DefaultMaxBytecodeSize))
+	tests.CheckExpectedErr(t, ErrWrongParams, msg.ValidateBasic())
+
+	msg = NewMsgUpdateParams(NewParams(DefaultGasUnitPrice, 0, DefaultMaxGasPerModule, DefaultMaxBytecodeSize))
+	tests.CheckExpectedErr(t, ErrWrongParams, msg.ValidateBasic())
+
+	msg = NewMsgUpdateParams(NewParams(DefaultGasUnitPrice, DefaultMaxGasPerScript, 0, DefaultMaxBytecodeSize))
+	tests.CheckExpectedErr(t, ErrWrongParams, msg.ValidateBasic())
+
+	msg = NewMsgUpdateParams(NewParams(DefaultGasUnitPrice, DefaultMaxGasPerScript, DefaultMaxGasPerModule, MaxBytecodeSizeLimit+1))
+	tests.CheckExpectedErr(t, ErrWrongParams, msg.ValidateBasic())
+}
+
+// Test MsgExecuteBatch.
+func TestMsgExecuteBatch(t *testing.T) {
+	t.Parallel()
+
+	acc := sdk.AccAddress([]byte("addr1"))
+	code := make(Contract, 128)
+	args := []ScriptArg{NewScriptArg("10", vm_grpc.VMTypeTag_U64)}
+
+	msg := NewMsgExecuteBatch(acc, NewBatchModule(code), NewBatchScript(code, args))
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, MsgExecuteBatchType, msg.Type())
+	require.Equal(t, msg.GetSigners(), []sdk.AccAddress{acc})
+	require.Equal(t, getMsgSignBytes(t, msg), msg.GetSignBytes())
+	require.NoError(t, msg.ValidateBasic())
+	require.Len(t, msg.Contracts, 2)
+	require.Equal(t, vm_grpc.ContractType_Module, msg.Contracts[0].Type)
+	require.Equal(t, vm_grpc.ContractType_Script, msg.Contracts[1].Type)
+
+	// message without signer
+	msg = NewMsgExecuteBatch([]byte{}, NewBatchModule(code))
+	tests.CheckExpectedErr(t, sdkErrors.ErrInvalidAddress, msg.ValidateBasic())
+
+	// empty batch
+	msg = NewMsgExecuteBatch(acc)
+	tests.CheckExpectedErr(t, ErrWrongBatch, msg.ValidateBasic())
+
+	// too large batch
+	contracts := make([]BatchContract, MaxBatchContracts+1)
+	for i := range contracts {
+		contracts[i] = NewBatchScript(code, nil)
+	}
+	msg = NewMsgExecuteBatch(acc, contracts...)
+	tests.CheckExpectedErr(t, ErrWrongBatch, msg.ValidateBasic())
+
+	// contract without code
+	msg = NewMsgExecuteBatch(acc, NewBatchModule(code), NewBatchScript([]byte{}, nil))
+	tests.CheckExpectedErr(t, ErrEmptyContract, msg.ValidateBasic())
+
+	// module with arguments
+	module := NewBatchModule(code)
+	module.Args = args
+	msg = NewMsgExecuteBatch(acc, module)
+	tests.CheckExpectedErr(t, ErrWrongBatch, msg.ValidateBasic())
+
+	// unknown contract type
+	msg = NewMsgExecuteBatch(acc, BatchContract{Type: vm_grpc.ContractType(100), Code: code})
+	tests.CheckExpectedErr(t, ErrWrongBatch, msg.ValidateBasic())
+
+	// wrong argument type
+	msg = NewMsgExecuteBatch(acc, NewBatchScript(code, []ScriptArg{NewScriptArg("10", vm_grpc.VMTypeTag(100))}))
+	tests.CheckExpectedErr(t, ErrWrongArgTypeTag, msg.ValidateBasic())
+
+	// too large bytecode
+	msg = NewMsgExecuteBatch(acc, NewBatchModule(make(Contract, MaxBytecodeSizeLimit+1)))
+	tests.CheckExpectedErr(t, ErrBytecodeTooLarge, msg.ValidateBasic())
+}
@@ -0,0 +1,192 @@
+// Parameters store for VM module.
+package types
+
+import (
+	"fmt"
+	"math"
+
+	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+)
+
+// Default parameters.
+const (
+	DefaultGasUnitPrice    uint64 = 1
+	DefaultMaxGasPerScript uint64 = 10000000
+	DefaultMaxGasPerModule uint64 = 10000000
+	DefaultMaxBytecodeSize uint64 = 64 * 1024
+
+	// Max bytecode size limit, checked by message ValidateBasic (params can't be read there).
+	MaxBytecodeSizeLimit uint64 = 1024 * 1024
+)
+
+// Keys to store VM parameters.
+var (
+	KeyGasUnitPrice    = []byte("GasUnitPrice")
+	KeyMaxGasPerScript = []byte("MaxGasPerScript")
+	KeyMaxGasPerModule = []byte("MaxGasPerModule")
+	KeyMaxBytecodeSize = []byte("MaxBytecodeSize")
+)
+
+// Describing parameters for VM module: gas unit price, max gas per contract, max bytecode size.
+// Max gas is set in VM gas units, VM gas unit costs GasUnitPrice SDK gas units.
+type Params struct {
+	GasUnitPrice    uint64 `json:"gas_unit_price"`
+	MaxGasPerScript uint64 `json:"max_gas_per_script"`
+	MaxGasPerModule uint64 `json:"max_gas_per_module"`
+	MaxBytecodeSize uint64 `json:"max_bytecode_size"`
+}
+
+// Create new instance to store parameters.
+func NewParams(gasUnitPrice, maxGasPerScript, maxGasPerModule, maxBytecodeSize uint64) Params {
+	return Params{
+		GasUnitPrice:    gasUnitPrice,
+		MaxGasPerScript: maxGasPerScript,
+		MaxGasPerModule: maxGasPerModule,
+		MaxBytecodeSize: maxBytecodeSize,
+	}
+}
+
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{Key: KeyGasUnitPrice, Value: &p.GasUnitPrice, ValidatorFn: validateGasUnitPrice},
+		{Key: KeyMaxGasPerScript, Value: &p.MaxGasPerScript, ValidatorFn: validateMaxGasPerScript},
+		{Key: KeyMaxGasPerModule, Value: &p.MaxGasPerModule, ValidatorFn: validateMaxGasPerModule},
+		{Key: KeyMaxBytecodeSize, Value: &p.MaxBytecodeSize, ValidatorFn: validateMaxBytecodeSize},
+	}
+}
+
+func (p *Params) Equal(p2 Params) bool {
+	return p.GasUnitPrice == p2.GasUnitPrice &&
+		p.MaxGasPerScript == p2.MaxGasPerScript &&
+		p.MaxGasPerModule == p2.MaxGasPerModule &&
+		p.MaxBytecodeSize == p2.MaxBytecodeSize
+}
+
+func (p Params) Validate() error {
+	if err := validateGasUnitPrice(p.GasUnitPrice); err != nil {
+		return err
+	}
+
+	if err := validateMaxGasPerScript(p.MaxGasPerScript); err != nil {
+		return err
+	}
+
+	if err := validateMaxGasPerModule(p.MaxGasPerModule); err != nil {
+		return err
+	}
+
+	if err := validateMaxBytecodeSize(p.MaxBytecodeSize); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Convert VM gas to SDK gas: VM gas unit costs GasUnitPrice SDK gas units.
+// Result is capped by max uint64 (SDK gas meter panics with out of gas / gas overflow).
+func (p Params) ToSDKGas(vmGas uint64) uint64 {
+	if p.GasUnitPrice != 0 && vmGas > math.MaxUint64/p.GasUnitPrice {
+		return math.MaxUint64
+	}
+
+	return vmGas * p.GasUnitPrice
+}
+
+// Convert SDK gas to VM gas (amount of VM gas units SDK gas can pay for).
+func (p Params) ToVMGas(sdkGas uint64) uint64 {
+	if p.GasUnitPrice == 0 {
+		return sdkGas
+	}
+
+	return sdkGas / p.GasUnitPrice
+}
+
+// Get max gas for contract type.
+func (p Params) MaxGas(contractType vm_grpc.ContractType) uint64 {
+	if contractType == vm_grpc.ContractType_Module {
+		return p.MaxGasPerModule
+	}
+
+	return p.MaxGasPerScript
+}
+
+func (p Params) String() string {
+	return fmt.Sprintf("VM params:\n"+
+		"  GasUnitPrice:    %d\n"+
+		"  MaxGasPerScript: %d\n"+
+		"  MaxGasPerModule: %d\n"+
+		"  MaxBytecodeSize: %d\n",
+		p.GasUnitPrice, p.MaxGasPerScript, p.MaxGasPerModule, p.MaxBytecodeSize,
+	)
+}
+
+// Check contract bytecode size doesn't exceed max size.
+func CheckBytecodeSize(code Contract, maxSize uint64) error {
+	if uint64(len(code)) > maxSize {
+		return sdkErrors.Wrapf(ErrBytecodeTooLarge, "%d bytes, max: %d", len(code), maxSize)
+	}
+
+	return nil
+}
+
+// Validate GasUnitPrice param value.
+func validateGasUnitPrice(value interface{}) error {
+	v, ok := value.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid gas unit price parameter type: %T", value)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("gas unit price should be greater than 0")
+	}
+
+	return nil
+}
+
+// Validate MaxGasPerScript param value.
+func validateMaxGasPerScript(value interface{}) error {
+	v, ok := value.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid max gas per script parameter type: %T", value)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("max gas per script should be greater than 0")
+	}
+
+	return nil
+}
+
+// Validate MaxGasPerModule param value.
+func validateMaxGasPerModule(value interface{}) error {
+	v, ok := value.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid max gas per module parameter type: %T", value)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("max gas per module should be greater than 0")
+	}
+
+	return nil
+}
+
+// Validate MaxBytecodeSize param value.
+func validateMaxBytecodeSize(value interface{}) error {
+	v, ok := value.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid max bytecode size parameter type: %T", value)
+	}
+
+	if v == 0 || v > MaxBytecodeSizeLimit {
+		return fmt.Errorf("max bytecode size should be in range [1:%d]", MaxBytecodeSizeLimit)
+	}
+
+	return nil
+}
+
+func DefaultParams() Params {
+	return NewParams(DefaultGasUnitPrice, DefaultMaxGasPerScript, DefaultMaxGasPerModule, DefaultMaxBytecodeSize)
+}
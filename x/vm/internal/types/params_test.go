@@ -0,0 +1,55 @@
+// +build unit
+
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/x/params/subspace"
+	"github.com/stretchr/testify/require"
+)
+
+// Test params set pairs validators.
+func TestParams_ParamSetPairs(t *testing.T) {
+	t.Parallel()
+
+	params := DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		require.NoError(t, pair.ValidatorFn(pairValue(pair)), string(pair.Key))
+		require.Error(t, pair.ValidatorFn(uint64(0)), string(pair.Key))
+		require.Error(t, pair.ValidatorFn(int64(1)), string(pair.Key))
+	}
+
+	pairs := params.ParamSetPairs()
+	require.Error(t, findPair(t, pairs, KeyMaxBytecodeSize).ValidatorFn(MaxBytecodeSizeLimit+1))
+}
+
+// Test VM gas to SDK gas conversion.
+func TestParams_GasConversion(t *testing.T) {
+	t.Parallel()
+
+	params := DefaultParams()
+	params.GasUnitPrice = 4
+
+	require.EqualValues(t, 400, params.ToSDKGas(100))
+	require.EqualValues(t, uint64(math.MaxUint64), params.ToSDKGas(math.MaxUint64/2))
+	require.EqualValues(t, 25, params.ToVMGas(100))
+	require.EqualValues(t, 25, params.ToVMGas(103))
+}
+
+func pairValue(pair subspace.ParamSetPair) interface{} {
+	return *(pair.Value.(*uint64))
+}
+
+func findPair(t *testing.T, pairs subspace.ParamSetPairs, key []byte) subspace.ParamSetPair {
+	for _, pair := range pairs {
+		if string(pair.Key) == string(key) {
+			return pair
+		}
+	}
+
+	t.Fatalf("pair %s not found", key)
+
+	return subspace.ParamSetPair{}
+}
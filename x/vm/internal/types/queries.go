@@ -1,5 +1,25 @@
 package types
 
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+)
+
+const (
+	// Default gas limit for read-only script execution.
+	DefaultViewGasLimit = 500000
+	// Timeout in ms to wait for the running VM execution for read-only script execution / CheckTx.
+	ExecWaitTimeout = 5000
+	// Default and max page limit for resources query.
+	DefaultResourcesLimit = 100
+	MaxResourcesLimit     = 1000
+)
+
 // Query when access path to read value.
 type QueryAccessPath struct {
 	Address []byte `json:"address"`
@@ -8,9 +28,157 @@ type QueryAccessPath struct {
 
 // Query response.
 type QueryValueResp struct {
-	Value string `json:"value" format:"HEX string"`
+	Value   string          `json:"value" format:"HEX string"`
+	Decoded json.RawMessage `json:"decoded,omitempty" swaggertype:"object"` // LCS decoded value (if layout provided)
 }
 
 func (resp QueryValueResp) String() string {
+	if len(resp.Decoded) > 0 {
+		return "Value: " + resp.Value + "\nDecoded: " + string(resp.Decoded)
+	}
+
 	return "Value: " + resp.Value
 }
+
+// Query to get resources stored under VM address, page starts from 1.
+type QueryResources struct {
+	Address []byte `json:"address"`
+	Page    int    `json:"page"`
+	Limit   int    `json:"limit"`
+}
+
+// Resource stored under VM address.
+type QueryResource struct {
+	Path    string          `json:"path" format:"HEX string"`
+	Value   string          `json:"value" format:"HEX string"`
+	Layout  string          `json:"layout,omitempty"`                       // layout used to decode value
+	Decoded json.RawMessage `json:"decoded,omitempty" swaggertype:"object"` // LCS decoded value
+}
+
+// Resources query response.
+type QueryResourcesResp struct {
+	Page      int             `json:"page"`
+	Limit     int             `json:"limit"`
+	Resources []QueryResource `json:"resources"`
+}
+
+func (resp QueryResourcesResp) String() string {
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintf("Page %d, limit %d\n", resp.Page, resp.Limit))
+	for _, res := range resp.Resources {
+		b.WriteString(fmt.Sprintf("Path: %s\n", res.Path))
+		b.WriteString(fmt.Sprintf("  Value: %s\n", res.Value))
+		if len(res.Decoded) > 0 {
+			b.WriteString(fmt.Sprintf("  Decoded (%s): %s\n", res.Layout, string(res.Decoded)))
+		}
+	}
+
+	return b.String()
+}
+
+// Query to get modules deployed by address.
+type QueryModules struct {
+	Address sdk.AccAddress `json:"address"`
+}
+
+// Query to get module metadata / bytecode.
+type QueryModule struct {
+	Address sdk.AccAddress `json:"address"`
+	Name    string         `json:"name"`
+}
+
+// Module bytecode query response.
+type QueryModuleCodeResp struct {
+	Code string `json:"code" format:"HEX string"`
+}
+
+func (resp QueryModuleCodeResp) String() string {
+	return "Code: " + resp.Code
+}
+
+// Query to execute script in read-only mode.
+type QueryCallScript struct {
+	Address sdk.AccAddress `json:"address"`
+	Script  Contract       `json:"script"`
+	Args    []ScriptArg    `json:"args"`
+	Gas     uint64         `json:"gas"`
+}
+
+// Write operation produced by read-only script execution (not applied).
+type QueryWriteOp struct {
+	Type    string `json:"type"`
+	Address string `json:"address" format:"HEX string"`
+	Path    string `json:"path" format:"HEX string"`
+	Value   string `json:"value" format:"HEX string"`
+}
+
+// Read-only script execution response.
+type QueryCallScriptResp struct {
+	Status      string           `json:"status"`
+	MajorStatus uint64           `json:"major_status"`
+	SubStatus   uint64           `json:"sub_status"`
+	Message     string           `json:"message"`
+	GasUsed     uint64           `json:"gas_used"`
+	Events      sdk.StringEvents `json:"events"`
+	WriteSet    []QueryWriteOp   `json:"write_set"`
+}
+
+// Create read-only script execution response from VM execution result.
+func NewQueryCallScriptResp(exec *vm_grpc.VMExecuteResponse) QueryCallScriptResp {
+	resp := QueryCallScriptResp{
+		Status:   StatusKeep,
+		GasUsed:  exec.GasUsed,
+		Events:   sdk.StringEvents{},
+		WriteSet: make([]QueryWriteOp, 0, len(exec.WriteSet)),
+	}
+
+	if exec.StatusStruct != nil {
+		resp.MajorStatus = exec.StatusStruct.MajorStatus
+		resp.SubStatus = exec.StatusStruct.SubStatus
+		resp.Message = exec.StatusStruct.Message
+	}
+
+	if exec.Status == vm_grpc.ContractStatus_Discard {
+		resp.Status = StatusDiscard
+		return resp
+	}
+
+	if exec.StatusStruct != nil && exec.StatusStruct.MajorStatus != VMCodeExecuted {
+		resp.Status = StatusError
+		return resp
+	}
+
+	events := make(sdk.Events, 0, len(exec.Events))
+	for _, vmEvent := range exec.Events {
+		events = append(events, NewEventFromVM(vmEvent))
+	}
+	resp.Events = sdk.StringifyEvents(events.ToABCIEvents())
+
+	for _, value := range exec.WriteSet {
+		resp.WriteSet = append(resp.WriteSet, QueryWriteOp{
+			Type:    VMWriteOpToString(value.Type),
+			Address: hex.EncodeToString(value.Path.Address),
+			Path:    hex.EncodeToString(value.Path.Path),
+			Value:   hex.EncodeToString(value.Value),
+		})
+	}
+
+	return resp
+}
+
+func (resp QueryCallScriptResp) String() string {
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintf("Status: %s\n", resp.Status))
+	b.WriteString(fmt.Sprintf("Major status: %d\n", resp.MajorStatus))
+	b.WriteString(fmt.Sprintf("Sub status: %d\n", resp.SubStatus))
+	b.WriteString(fmt.Sprintf("Message: %s\n", resp.Message))
+	b.WriteString(fmt.Sprintf("Gas used: %d\n", resp.GasUsed))
+	b.WriteString("Events:\n")
+	b.WriteString(resp.Events.String())
+	b.WriteString("Write set:\n")
+	for _, op := range resp.WriteSet {
+		b.WriteString(fmt.Sprintf("  %s: %s/%s = %s\n", op.Type, op.Address, op.Path, op.Value))
+	}
+
+	return b.String()
+}
@@ -14,16 +14,16 @@ import (
 const (
 	ModuleName = "vm"
 
-	StoreKey  = ModuleName
-	RouterKey = ModuleName
+	StoreKey          = ModuleName
+	RouterKey         = ModuleName
+	DefaultParamspace = ModuleName
 
-	VmGasPrice      = 1
 	VmUnknowTagType = -1
 )
 
 // VM related variables.
 var (
-	KeyGenesis = []byte("gen") // used to save genesis
+	KeyGenesis = []byte("gen") // genesis initialized marker (chains started earlier store genesis state here)
 )
 
 // Type of Move contract (bytes).
@@ -15,13 +15,14 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/dfinance/dnode/x/common_vm"
+	"github.com/dfinance/dnode/x/core"
 	"github.com/dfinance/dnode/x/vm/client/cli"
 	"github.com/dfinance/dnode/x/vm/client/rest"
 	types "github.com/dfinance/dnode/x/vm/internal/types"
 )
 
 var (
-	_ module.AppModule      = AppModule{}
+	_ core.AppMsModule      = AppModule{}
 	_ module.AppModuleBasic = AppModuleBasic{}
 )
 
@@ -42,6 +43,12 @@ func (AppModuleBasic) ValidateGenesis(data json.RawMessage) error {
 	var state types.GenesisState
 	types.ModuleCdc.MustUnmarshalJSON(data, &state)
 
+	if state.Parameters != nil {
+		if err := state.Parameters.Validate(); err != nil {
+			return fmt.Errorf("params: %w", err)
+		}
+	}
+
 	for _, genWriteOp := range state.WriteSet {
 		bzAddr, err := hex.DecodeString(genWriteOp.Address)
 		if err != nil {
@@ -62,12 +69,36 @@ func (AppModuleBasic) ValidateGenesis(data json.RawMessage) error {
 		}
 	}
 
+	for _, module := range state.Modules {
+		if module.Deployer.Empty() {
+			return fmt.Errorf("module %q: empty deployer", module.Name)
+		}
+
+		if module.Name == "" {
+			return fmt.Errorf("module deployed by %s: empty name", module.Deployer)
+		}
+
+		if _, err := hex.DecodeString(module.CodeHash); err != nil {
+			return fmt.Errorf("module %q: code hash: %v", module.Name, err)
+		}
+
+		if _, err := hex.DecodeString(module.Path); err != nil {
+			return fmt.Errorf("module %q: path: %v", module.Name, err)
+		}
+
+		if module.Policy != "" && !types.IsValidModulePolicy(module.Policy) {
+			return fmt.Errorf("module %q: unknown policy %q", module.Name, module.Policy)
+		}
+	}
+
 	return nil
 }
 
 // Generate default genesis.
 func (module AppModuleBasic) DefaultGenesis() json.RawMessage {
-	return types.ModuleCdc.MustMarshalJSON(&types.GenesisState{})
+	params := types.DefaultParams()
+
+	return types.ModuleCdc.MustMarshalJSON(&types.GenesisState{Parameters: &params})
 }
 
 // Register REST routes.
@@ -99,6 +130,11 @@ func NewAppModule(vmKeeper Keeper) AppModule {
 	}
 }
 
+// Create new VM module with multisignature handler.
+func NewAppMsModule(vmKeeper Keeper) core.AppMsModule {
+	return NewAppModule(vmKeeper)
+}
+
 // Get name of module.
 func (AppModule) Name() string {
 	return types.ModuleName
@@ -113,6 +149,9 @@ func (AppModule) Route() string { return types.RouterKey }
 // Create new handler.
 func (app AppModule) NewHandler() sdk.Handler { return NewHandler(app.vmKeeper) }
 
+// Create new multisignature handler.
+func (app AppModule) NewMsHandler() core.MsHandler { return NewMsHandler(app.vmKeeper) }
+
 // Get route for querier.
 func (AppModule) QuerierRoute() string { return types.RouterKey }
 
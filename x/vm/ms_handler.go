@@ -0,0 +1,35 @@
+// Implements multisignature message handler for VM module.
+package vm
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/dfinance/dnode/x/core"
+)
+
+// Handler for VM multisignature messages, upgrades modules (including privileged ones) and updates params.
+func NewMsHandler(keeper Keeper) core.MsHandler {
+	return func(ctx sdk.Context, msg core.MsMsg) error {
+		switch msg := msg.(type) {
+		case MsgUpgradeModule:
+			return handleMsMsgUpgradeModule(ctx, keeper, msg)
+
+		case MsgUpdateParams:
+			return handleMsMsgUpdateParams(ctx, keeper, msg)
+
+		default:
+			return sdkErrors.Wrapf(sdkErrors.ErrUnknownRequest, "unrecognized vm multisig msg type: %v", msg.Type())
+		}
+	}
+}
+
+// Handle upgrade module message.
+func handleMsMsgUpgradeModule(ctx sdk.Context, keeper Keeper, msg MsgUpgradeModule) error {
+	return keeper.UpgradeModule(ctx, msg)
+}
+
+// Handle update params message.
+func handleMsMsgUpdateParams(ctx sdk.Context, keeper Keeper, msg MsgUpdateParams) error {
+	return keeper.UpdateParams(ctx, msg)
+}
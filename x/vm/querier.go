@@ -2,6 +2,9 @@
 package vm
 
 import (
+	"encoding/hex"
+
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/dfinance/dvm-proto/go/vm_grpc"
@@ -12,7 +15,13 @@ import (
 
 const (
 	// Queries types for querier.
-	QueryValue = "value" // Get value by access path.
+	QueryValue         = "value"       // Get value by access path.
+	QueryCallScript    = "call_script" // Execute script in read-only mode.
+	QueryModules       = "modules"     // Get modules deployed by address.
+	QueryModule        = "module"      // Get deployed module metadata.
+	QueryModuleCode    = "module_code" // Get deployed module bytecode.
+	QueryParams        = "params"      // Get VM params.
+	QueryAddrResources = "resources"   // Get resources stored under address.
 )
 
 // Create new querier.
@@ -22,6 +31,24 @@ func NewQuerier(vmKeeper Keeper) sdk.Querier {
 		case QueryValue:
 			return queryGetValue(ctx, vmKeeper, req)
 
+		case QueryCallScript:
+			return queryCallScript(ctx, vmKeeper, req)
+
+		case QueryModules:
+			return queryModules(ctx, vmKeeper, req)
+
+		case QueryModule:
+			return queryModule(ctx, vmKeeper, req)
+
+		case QueryModuleCode:
+			return queryModuleCode(ctx, vmKeeper, req)
+
+		case QueryParams:
+			return queryParams(ctx, vmKeeper)
+
+		case QueryAddrResources:
+			return queryResources(ctx, vmKeeper, req)
+
 		default:
 			return nil, sdkErrors.Wrap(sdkErrors.ErrUnknownRequest, "unknown query")
 		}
@@ -41,3 +68,137 @@ func queryGetValue(ctx sdk.Context, vmKeeper Keeper, req abci.RequestQuery) ([]b
 		Path:    queryAccessPath.Path,
 	}), nil
 }
+
+// Processing query to execute script without applying write set.
+func queryCallScript(ctx sdk.Context, vmKeeper Keeper, req abci.RequestQuery) ([]byte, error) {
+	var params types.QueryCallScript
+
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkErrors.Wrapf(types.ErrInternal, "failed to parse params: %v", err)
+	}
+
+	msg := types.NewMsgExecuteScript(params.Address, params.Script, params.Args)
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	resp, err := vmKeeper.ExecuteScriptView(ctx, msg, params.Gas)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, resp)
+	if err != nil {
+		return nil, sdkErrors.Wrapf(types.ErrInternal, "failed to marshal response: %v", err)
+	}
+
+	return bz, nil
+}
+
+// Processing query to get modules deployed by address.
+func queryModules(ctx sdk.Context, vmKeeper Keeper, req abci.RequestQuery) ([]byte, error) {
+	var params types.QueryModules
+
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkErrors.Wrapf(types.ErrInternal, "failed to parse params: %v", err)
+	}
+
+	modules := vmKeeper.GetModules(ctx, params.Address)
+	if modules == nil {
+		modules = types.ModulesMetadata{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, modules)
+	if err != nil {
+		return nil, sdkErrors.Wrapf(types.ErrInternal, "failed to marshal response: %v", err)
+	}
+
+	return bz, nil
+}
+
+// Processing query to get deployed module metadata.
+func queryModule(ctx sdk.Context, vmKeeper Keeper, req abci.RequestQuery) ([]byte, error) {
+	var params types.QueryModule
+
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkErrors.Wrapf(types.ErrInternal, "failed to parse params: %v", err)
+	}
+
+	module, found := vmKeeper.GetModule(ctx, params.Address, params.Name)
+	if !found {
+		return nil, sdkErrors.Wrapf(types.ErrModuleNotFound, "%s: %s", params.Address, params.Name)
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, module)
+	if err != nil {
+		return nil, sdkErrors.Wrapf(types.ErrInternal, "failed to marshal response: %v", err)
+	}
+
+	return bz, nil
+}
+
+// Processing query to get deployed module bytecode.
+func queryModuleCode(ctx sdk.Context, vmKeeper Keeper, req abci.RequestQuery) ([]byte, error) {
+	var params types.QueryModule
+
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkErrors.Wrapf(types.ErrInternal, "failed to parse params: %v", err)
+	}
+
+	code, err := vmKeeper.GetModuleCode(ctx, params.Address, params.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, types.QueryModuleCodeResp{Code: hex.EncodeToString(code)})
+	if err != nil {
+		return nil, sdkErrors.Wrapf(types.ErrInternal, "failed to marshal response: %v", err)
+	}
+
+	return bz, nil
+}
+
+// Processing query to get VM params.
+func queryParams(ctx sdk.Context, vmKeeper Keeper) ([]byte, error) {
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, vmKeeper.GetParams(ctx))
+	if err != nil {
+		return nil, sdkErrors.Wrapf(types.ErrInternal, "failed to marshal response: %v", err)
+	}
+
+	return bz, nil
+}
+
+// Processing query to get resources stored under address (paginated).
+func queryResources(ctx sdk.Context, vmKeeper Keeper, req abci.RequestQuery) ([]byte, error) {
+	var params types.QueryResources
+
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkErrors.Wrapf(types.ErrInternal, "failed to parse params: %v", err)
+	}
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+
+	if params.Limit < 1 {
+		params.Limit = types.DefaultResourcesLimit
+	}
+
+	if params.Limit > types.MaxResourcesLimit {
+		return nil, sdkErrors.Wrapf(sdkErrors.ErrInvalidRequest, "limit %d: should be less or equal to %d", params.Limit, types.MaxResourcesLimit)
+	}
+
+	resources := vmKeeper.GetResources(ctx, params.Address, params.Page, params.Limit)
+	resp := types.QueryResourcesResp{
+		Page:      params.Page,
+		Limit:     params.Limit,
+		Resources: resources,
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, resp)
+	if err != nil {
+		return nil, sdkErrors.Wrapf(types.ErrInternal, "failed to marshal response: %v", err)
+	}
+
+	return bz, nil
+}
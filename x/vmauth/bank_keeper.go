@@ -0,0 +1,79 @@
+package vmauth
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+// Context key for current bank transfer.
+type bankTransferCtxKey struct{}
+
+// Bank transfer counterparties (VM addresses), zero address is used if counterparty is unknown (multi send).
+type bankTransfer struct {
+	payer []byte
+	payee []byte
+}
+
+// Set current bank transfer to context (used by VMAccountKeeper to emit payment events).
+func withBankTransfer(ctx sdk.Context, payer, payee sdk.AccAddress) sdk.Context {
+	transfer := bankTransfer{
+		payer: common_vm.ZeroAddress,
+		payee: common_vm.ZeroAddress,
+	}
+	if !payer.Empty() {
+		transfer.payer = common_vm.Bech32ToLibra(payer)
+	}
+	if !payee.Empty() {
+		transfer.payee = common_vm.Bech32ToLibra(payee)
+	}
+
+	return ctx.WithValue(bankTransferCtxKey{}, transfer)
+}
+
+// Get current bank transfer from context.
+func getBankTransfer(ctx sdk.Context) (bankTransfer, bool) {
+	transfer, ok := ctx.Value(bankTransferCtxKey{}).(bankTransfer)
+	return transfer, ok
+}
+
+// Bank keeper wrapper marking coins transfers, so VMAccountKeeper could update VM account event handles.
+type VMBankKeeper struct {
+	bank.Keeper
+}
+
+// Create new bank keeper wrapper.
+func NewVMBankKeeper(keeper bank.Keeper) VMBankKeeper {
+	return VMBankKeeper{
+		Keeper: keeper,
+	}
+}
+
+// SendCoins moves coins from one account to another.
+func (keeper VMBankKeeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error {
+	return keeper.Keeper.SendCoins(withBankTransfer(ctx, fromAddr, toAddr), fromAddr, toAddr, amt)
+}
+
+// InputOutputCoins handles multi send, counterparty is known only for a single input / output.
+func (keeper VMBankKeeper) InputOutputCoins(ctx sdk.Context, inputs []bank.Input, outputs []bank.Output) error {
+	var payer, payee sdk.AccAddress
+	if len(inputs) == 1 {
+		payer = inputs[0].Address
+	}
+	if len(outputs) == 1 {
+		payee = outputs[0].Address
+	}
+
+	return keeper.Keeper.InputOutputCoins(withBankTransfer(ctx, payer, payee), inputs, outputs)
+}
+
+// DelegateCoins moves coins from delegator to module account.
+func (keeper VMBankKeeper) DelegateCoins(ctx sdk.Context, delegatorAddr, moduleAccAddr sdk.AccAddress, amt sdk.Coins) error {
+	return keeper.Keeper.DelegateCoins(withBankTransfer(ctx, delegatorAddr, moduleAccAddr), delegatorAddr, moduleAccAddr, amt)
+}
+
+// UndelegateCoins moves coins from module account to delegator.
+func (keeper VMBankKeeper) UndelegateCoins(ctx sdk.Context, moduleAccAddr, delegatorAddr sdk.AccAddress, amt sdk.Coins) error {
+	return keeper.Keeper.UndelegateCoins(withBankTransfer(ctx, moduleAccAddr, delegatorAddr), moduleAccAddr, delegatorAddr, amt)
+}
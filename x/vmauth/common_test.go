@@ -3,6 +3,7 @@
 package vmauth
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -37,8 +38,46 @@ type testInput struct {
 
 	paramsKeeper  params.Keeper
 	accountKeeper VMAccountKeeper
-	bankKeeper    bank.BaseKeeper
+	bankKeeper    VMBankKeeper
 	vmStorage     common_vm.VMStorage
+	denomPaths    DenomPaths
+}
+
+// Denom paths provider for tests.
+type testDenomPathsProvider struct {
+	paths DenomPaths
+}
+
+func (p testDenomPathsProvider) GetDenomPath(_ sdk.Context, denom string) ([]byte, bool) {
+	path, ok := p.paths[denom]
+	return path, ok
+}
+
+func (p testDenomPathsProvider) IterateDenomPaths(_ sdk.Context, handler func(denom string, path []byte) (stop bool)) {
+	for _, denom := range p.paths.Denoms() {
+		if handler(denom, p.paths[denom]) {
+			break
+		}
+	}
+}
+
+// Create denom paths for tests.
+func newTestDenomPaths() DenomPaths {
+	paths := DenomPaths{}
+	for denom, path := range map[string]string{
+		"dfi":  "0136364df51f98e43cbe92dfcfdd807c7fc626aa9690793ebf7d301cfe0de72f11",
+		"eth":  "01f7c5cca156342bec3ac2bfb6fc439683da605ab7bc3f58881427861c8fc44577",
+		"usdt": "01affc8719224641c76bb20b65e4a26c002a39bbc82b753410976002ad7931b270",
+		"btc":  "01d09b573d3bea2a3853ad447728fa476925894836352c46711fb7604e962b2903",
+	} {
+		bz, err := hex.DecodeString(path)
+		if err != nil {
+			panic(err)
+		}
+		paths[denom] = bz
+	}
+
+	return paths
 }
 
 // Create VM storage for tests.
@@ -73,12 +112,29 @@ func (storage VMStorageImpl) HasValue(ctx sdk.Context, accessPath *vm_grpc.VMAcc
 	return store.Has(common_vm.MakePathKey(accessPath))
 }
 
+func (storage VMStorageImpl) IterateValues(ctx sdk.Context, handler func(accessPath *vm_grpc.VMAccessPath, value []byte) bool) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(storage.storeKey), append(common_vm.VMKey, common_vm.KeyDelimiter...))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		accessPath, err := common_vm.ParsePathKey(iterator.Key())
+		if err != nil {
+			panic(err)
+		}
+
+		if handler(accessPath, iterator.Value()) {
+			break
+		}
+	}
+}
+
 func newTestInput(t *testing.T) testInput {
 	input := testInput{
 		cdc:          codec.New(),
 		keyMain:      sdk.NewKVStoreKey("main"),
 		keyAccount:   sdk.NewKVStoreKey("acc"),
 		keyVMStorage: sdk.NewKVStoreKey("vm_storage"),
+		denomPaths:   newTestDenomPaths(),
 	}
 
 	auth.RegisterCodec(input.cdc)
@@ -108,13 +164,16 @@ func newTestInput(t *testing.T) testInput {
 		input.keyAccount,
 		input.paramsKeeper.Subspace(auth.DefaultParamspace),
 		input.vmStorage,
+		testDenomPathsProvider{paths: input.denomPaths},
 		auth.ProtoBaseAccount,
 	)
 
-	input.bankKeeper = bank.NewBaseKeeper(
-		input.accountKeeper,
-		input.paramsKeeper.Subspace(bank.DefaultParamspace),
-		make(map[string]bool),
+	input.bankKeeper = NewVMBankKeeper(
+		bank.NewBaseKeeper(
+			input.accountKeeper,
+			input.paramsKeeper.Subspace(bank.DefaultParamspace),
+			make(map[string]bool),
+		),
 	)
 
 	// Setup context.
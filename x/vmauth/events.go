@@ -0,0 +1,80 @@
+package vmauth
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+const (
+	// Event type and attributes keys (same as VM module contract events).
+	EventTypeMoveEvent    = "contract_events"
+	AttrKeyGuid           = "guid"
+	AttrKeySequenceNumber = "sequence_number"
+	AttrKeyType           = "type"
+	AttrKeyData           = "data"
+	AttrKeyStructTag      = "struct_tag"
+
+	// Standard library payment events.
+	paymentEventsModule      = "Account"
+	sentPaymentEventName     = "SentPaymentEvent"
+	receivedPaymentEventName = "ReceivedPaymentEvent"
+)
+
+// New contract event for standard library payment event emitted by event handle.
+func newPaymentEvent(handle *EventHandle, name string, data []byte) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeMoveEvent,
+		sdk.NewAttribute(AttrKeyGuid, "0x"+hex.EncodeToString(handle.Guid)),
+		sdk.NewAttribute(AttrKeySequenceNumber, strconv.FormatUint(handle.Counter, 10)),
+		sdk.NewAttribute(AttrKeyType, vm_grpc.VMTypeTag_Struct.String()),
+		sdk.NewAttribute(AttrKeyData, "0x"+hex.EncodeToString(data)),
+		sdk.NewAttribute(AttrKeyStructTag, fmt.Sprintf("0x%s::%s::%s", hex.EncodeToString(common_vm.ZeroAddress), paymentEventsModule, name)),
+	)
+}
+
+// Emit payment events for account coins changed by bank transfer and increase VM account event handles counters.
+// Coins decrease is a sent payment (to payee), coins increase is a received payment (from payer).
+func emitPaymentEvents(ctx sdk.Context, vmAccount *AccountResource, transfer bankTransfer, prevCoins, coins sdk.Coins) {
+	denomsSet := make(map[string]bool)
+	for _, coin := range prevCoins.Add(coins...) {
+		denomsSet[coin.Denom] = true
+	}
+
+	denoms := make([]string, 0, len(denomsSet))
+	for denom := range denomsSet {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+
+	for _, denom := range denoms {
+		diff := coins.AmountOf(denom).Sub(prevCoins.AmountOf(denom))
+
+		switch {
+		case diff.IsNegative():
+			data := SentPaymentEventToBytes(SentPaymentEvent{
+				Amount:   diff.Neg().BigInt(),
+				Denom:    []byte(denom),
+				Payee:    transfer.payee,
+				Metadata: []byte{},
+			})
+			ctx.EventManager().EmitEvent(newPaymentEvent(vmAccount.SentEvents, sentPaymentEventName, data))
+			vmAccount.SentEvents.Counter++
+		case diff.IsPositive():
+			data := ReceivedPaymentEventToBytes(ReceivedPaymentEvent{
+				Amount:   diff.BigInt(),
+				Denom:    []byte(denom),
+				Payer:    transfer.payer,
+				Metadata: []byte{},
+			})
+			ctx.EventManager().EmitEvent(newPaymentEvent(vmAccount.ReceivedEvents, receivedPaymentEventName, data))
+			vmAccount.ReceivedEvents.Counter++
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Accounts VM balances invariants.
+package vmauth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/exported"
+)
+
+const (
+	// Invariants route (module is registered under auth module name).
+	InvariantsRoute = "vmauth"
+)
+
+// Register vmauth invariants.
+func RegisterInvariants(ir sdk.InvariantRegistry, keeper VMAccountKeeper) {
+	ir.RegisterRoute(InvariantsRoute, "vm-balances", BalancesInvariant(keeper))
+}
+
+// Check auth accounts coins match VM balances: every account has VM account resource,
+// every coin denom has registered VM balance path and VM balance resource with the same amount,
+// VM balances for other registered denoms are zero.
+// VM balances changed by executions are synced to auth accounts by VM write set handler (see NewVMWriteSetHandler).
+func BalancesInvariant(keeper VMAccountKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var broken bool
+
+		denomPaths := keeper.getDenomPaths(ctx)
+		keeper.AccountKeeper.IterateAccounts(ctx, func(acc exported.Account) bool {
+			addr := acc.GetAddress()
+
+			if _, ok := keeper.getVMAccount(ctx, addr); !ok {
+				broken = true
+				msg += fmt.Sprintf("\t%s: VM account resource not found\n", addr)
+			}
+
+			coins := acc.GetCoins()
+			for _, coin := range coins {
+				if _, err := coinToBalance(denomPaths, addr, coin); err != nil {
+					broken = true
+					msg += fmt.Sprintf("\t%s: %v\n", addr, err)
+				}
+			}
+
+			vmBalances := make(map[string]bool)
+			for _, balance := range keeper.loadBalances(ctx, addr) {
+				vmBalances[balance.denom] = true
+
+				vmAmount := sdk.NewIntFromBigInt(balance.balance.Value)
+				if amount := coins.AmountOf(balance.denom); !amount.Equal(vmAmount) {
+					broken = true
+					msg += fmt.Sprintf("\t%s: %s amount mismatch: account %s, VM balance %s\n", addr, balance.denom, amount, vmAmount)
+				}
+			}
+
+			for _, coin := range coins {
+				if _, ok := denomPaths[coin.Denom]; ok && !vmBalances[coin.Denom] {
+					broken = true
+					msg += fmt.Sprintf("\t%s: VM balance resource for %s not found\n", addr, coin.Denom)
+				}
+			}
+
+			return false
+		})
+
+		return sdk.FormatInvariant(InvariantsRoute, "vm-balances", fmt.Sprintf("accounts coins don't match VM balances:\n%s", msg)), broken
+	}
+}
@@ -0,0 +1,86 @@
+// +build unit
+
+package vmauth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/dfinance/dvm-proto/go/vm_grpc"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/dfinance/dnode/x/common_vm"
+)
+
+// Check VM balances invariant detects accounts coins and VM balances divergence.
+func TestBalancesInvariant(t *testing.T) {
+	input := newTestInput(t)
+	invariant := BalancesInvariant(input.accountKeeper)
+
+	addr := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address().Bytes())
+	acc := auth.NewBaseAccountWithAddress(addr)
+	require.NoError(t, acc.SetCoins(types.NewCoins(types.NewCoin("dfi", types.NewInt(100)), types.NewCoin("eth", types.NewInt(10)))))
+	input.accountKeeper.SetAccount(input.ctx, &acc)
+
+	balancePath := func(denom string) *vm_grpc.VMAccessPath {
+		return &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(addr), Path: input.denomPaths[denom]}
+	}
+
+	_, broken := invariant(input.ctx)
+	require.False(t, broken)
+
+	// VM balance amount mismatch
+	{
+		ctx, _ := input.ctx.CacheContext()
+		input.vmStorage.SetValue(ctx, balancePath("dfi"), BalanceToBytes(BalanceResource{Value: big.NewInt(99)}))
+
+		msg, broken := invariant(ctx)
+		require.True(t, broken)
+		require.Contains(t, msg, "dfi amount mismatch")
+	}
+
+	// VM balance for denom not in account coins
+	{
+		ctx, _ := input.ctx.CacheContext()
+		input.vmStorage.SetValue(ctx, balancePath("btc"), BalanceToBytes(BalanceResource{Value: big.NewInt(1)}))
+
+		msg, broken := invariant(ctx)
+		require.True(t, broken)
+		require.Contains(t, msg, "btc amount mismatch")
+	}
+
+	// VM balance resource removed
+	{
+		ctx, _ := input.ctx.CacheContext()
+		input.vmStorage.DelValue(ctx, balancePath("eth"))
+
+		msg, broken := invariant(ctx)
+		require.True(t, broken)
+		require.Contains(t, msg, "VM balance resource for eth not found")
+	}
+
+	// VM account resource removed
+	{
+		ctx, _ := input.ctx.CacheContext()
+		input.vmStorage.DelValue(ctx, &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(addr), Path: GetResPath()})
+
+		msg, broken := invariant(ctx)
+		require.True(t, broken)
+		require.Contains(t, msg, "VM account resource not found")
+	}
+
+	// coin denom without registered VM balance path
+	{
+		ctx, _ := input.ctx.CacheContext()
+		unregAcc := auth.NewBaseAccountWithAddress(addr)
+		require.NoError(t, unregAcc.SetCoins(types.NewCoins(types.NewCoin("dfi", types.NewInt(100)), types.NewCoin("eth", types.NewInt(10)), types.NewCoin("unknown", types.NewInt(1)))))
+		input.accountKeeper.SetAccount(ctx, &unregAcc)
+
+		msg, broken := invariant(ctx)
+		require.True(t, broken)
+		require.Contains(t, msg, "denom path is not registered")
+	}
+}
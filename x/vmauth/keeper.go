@@ -2,6 +2,9 @@
 package vmauth
 
 import (
+	"bytes"
+	"sort"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -17,21 +20,38 @@ import (
 type VMAccountKeeper struct {
 	*auth.AccountKeeper
 
-	cdc      *codec.Codec
-	vmKeeper common_vm.VMStorage
+	key        sdk.StoreKey
+	cdc        *codec.Codec
+	vmKeeper   common_vm.VMStorage
+	denomPaths common_vm.DenomPathsProvider
+	proto      func() exported.Account
 }
 
 // Create new account vm keeper.
-func NewVMAccountKeeper(cdc *codec.Codec, key sdk.StoreKey, paramstore params.Subspace, vmKeeper common_vm.VMStorage, proto func() exported.Account) VMAccountKeeper {
+func NewVMAccountKeeper(cdc *codec.Codec, key sdk.StoreKey, paramstore params.Subspace, vmKeeper common_vm.VMStorage, denomPaths common_vm.DenomPathsProvider, proto func() exported.Account) VMAccountKeeper {
 	keeper := auth.NewAccountKeeper(cdc, key, paramstore, proto)
 
 	return VMAccountKeeper{
 		AccountKeeper: &keeper,
+		key:           key,
 		vmKeeper:      vmKeeper,
+		denomPaths:    denomPaths,
 		cdc:           cdc,
+		proto:         proto,
 	}
 }
 
+// Get registered VM balance resource paths.
+func (keeper VMAccountKeeper) getDenomPaths(ctx sdk.Context) DenomPaths {
+	denomPaths := make(DenomPaths)
+	keeper.denomPaths.IterateDenomPaths(ctx, func(denom string, path []byte) bool {
+		denomPaths[denom] = path
+		return false
+	})
+
+	return denomPaths
+}
+
 // Get account from VM storage.
 // If no account found, second return parameter is false.
 func (keeper VMAccountKeeper) getVMAccount(ctx sdk.Context, address sdk.AccAddress) (AccountResource, bool) {
@@ -89,7 +109,7 @@ func (keeper VMAccountKeeper) saveBalances(ctx sdk.Context, balances Balances, t
 
 // Load balances from VM storage.
 func (keeper VMAccountKeeper) loadBalances(ctx sdk.Context, addr sdk.AccAddress) Balances {
-	balances := loadAccessPaths(addr)
+	balances := loadAccessPaths(keeper.getDenomPaths(ctx), addr)
 	realBalances := make([]Balance, 0)
 
 	for _, balance := range balances {
@@ -105,19 +125,39 @@ func (keeper VMAccountKeeper) loadBalances(ctx sdk.Context, addr sdk.AccAddress)
 }
 
 // Set account in storage.
+// If account coins are changed by bank transfer (see VMBankKeeper), payment events are emitted and
+// VM account event handles counters are increased.
 func (keeper VMAccountKeeper) SetAccount(ctx sdk.Context, acc exported.Account) {
+	keeper.setAccount(ctx, acc, true)
+}
+
+// Set account in storage, payment events are emitted only if handleTransfer is set.
+func (keeper VMAccountKeeper) setAccount(ctx sdk.Context, acc exported.Account, handleTransfer bool) {
 	addr := acc.GetAddress()
-	source, isExists := keeper.getVMAccount(ctx, addr)
+	vmAccount, isExists := keeper.getVMAccount(ctx, addr)
+
+	var eventHandleGen EventHandleGenerator
+	if !isExists {
+		vmAccount, eventHandleGen = CreateVMAccount(acc)
+	}
+
+	if transfer, ok := getBankTransfer(ctx); ok && handleTransfer {
+		prevCoins := sdk.NewCoins()
+		if prevAcc := keeper.AccountKeeper.GetAccount(ctx, addr); prevAcc != nil {
+			prevCoins = prevAcc.GetCoins()
+		}
+
+		emitPaymentEvents(ctx, &vmAccount, transfer, prevCoins, acc.GetCoins())
+	}
 
 	if isExists {
-		keeper.setVMAccount(ctx, addr, source)
+		keeper.setVMAccount(ctx, addr, vmAccount)
 	} else {
-		vmAccount, eventHandleGen := CreateVMAccount(acc)
 		keeper.saveNewVMAccount(ctx, addr, vmAccount, eventHandleGen)
 	}
 
 	// Update balances extracted from coins.
-	balances, toDelete := coinsToBalances(acc)
+	balances, toDelete := coinsToBalances(keeper.getDenomPaths(ctx), acc)
 	keeper.saveBalances(ctx, balances, toDelete)
 
 	keeper.AccountKeeper.SetAccount(ctx, acc)
@@ -132,12 +172,14 @@ func (keeper VMAccountKeeper) GetAccount(ctx sdk.Context, addr sdk.AccAddress) e
 		realCoins := balancesToCoins(balances)
 
 		if account != nil {
-			if !realCoins.IsEqual(account.GetCoins()) {
+			// coins not backed by VM balances are kept as is
+			realCoins = realCoins.Add(unregisteredCoins(keeper.getDenomPaths(ctx), account.GetCoins())...)
+			if !coinsEqual(realCoins, account.GetCoins()) {
 				if err := account.SetCoins(realCoins); err != nil {
 					panic(err) // must never happen
 				}
 
-				keeper.SetAccount(ctx, account)
+				keeper.setAccount(ctx, account, false)
 			}
 		} else {
 			account = keeper.NewAccountWithAddress(ctx, addr)
@@ -145,18 +187,169 @@ func (keeper VMAccountKeeper) GetAccount(ctx sdk.Context, addr sdk.AccAddress) e
 				panic(err) // should never happen
 			}
 
-			keeper.SetAccount(ctx, account)
+			keeper.setAccount(ctx, account, false)
 		}
 	}
 
 	return account
 }
 
-// GetAllAccounts returns all accounts in the accountKeeper.
-// as it's not calling anywhere, as it seems, we can ignore vm storage for now.
-// todo: process all vm storage accounts and compare with standard accounts.
+// Create VM execution write set handler: accounts coins are synced with VM balances changed by execution,
+// so auth accounts coins always match VM balances (see BalancesInvariant).
+func (keeper VMAccountKeeper) NewVMWriteSetHandler() common_vm.VMWriteSetHandler {
+	return func(ctx sdk.Context, writeSet []*vm_grpc.VMValue) {
+		// sync is a part of execution, VM gas is already charged
+		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+		balancePaths := make(map[string]bool)
+		for _, path := range keeper.getDenomPaths(ctx) {
+			balancePaths[string(path)] = true
+		}
+
+		synced := make(map[string]bool)
+		for _, value := range writeSet {
+			if !balancePaths[string(value.Path.Path)] {
+				continue
+			}
+
+			addr, ok := common_vm.LibraToBech32(value.Path.Address)
+			if !ok || synced[string(addr)] {
+				continue
+			}
+
+			keeper.syncVMBalances(ctx, addr)
+			synced[string(addr)] = true
+		}
+	}
+}
+
+// Sync account coins with VM balances, account is created if it doesn't exist and has non-zero VM balances.
+func (keeper VMAccountKeeper) syncVMBalances(ctx sdk.Context, addr sdk.AccAddress) {
+	realCoins := balancesToCoins(keeper.loadBalances(ctx, addr))
+
+	account := keeper.AccountKeeper.GetAccount(ctx, addr)
+	if account == nil {
+		if realCoins.Empty() {
+			return
+		}
+		account = keeper.NewAccountWithAddress(ctx, addr)
+	} else {
+		// coins not backed by VM balances are kept as is
+		realCoins = realCoins.Add(unregisteredCoins(keeper.getDenomPaths(ctx), account.GetCoins())...)
+		if coinsEqual(realCoins, account.GetCoins()) {
+			return
+		}
+	}
+
+	if err := account.SetCoins(realCoins); err != nil {
+		panic(err) // should never happen
+	}
+
+	keeper.setAccount(ctx, account, false)
+}
+
+// Get addresses having VM balance resources for registered denoms (ordered by address).
+func (keeper VMAccountKeeper) getVMBalancesAddresses(ctx sdk.Context) []sdk.AccAddress {
+	balancePaths := make(map[string]bool)
+	for _, path := range keeper.getDenomPaths(ctx) {
+		balancePaths[string(path)] = true
+	}
+
+	addresses := make([]sdk.AccAddress, 0)
+	keeper.vmKeeper.IterateValues(ctx, func(accessPath *vm_grpc.VMAccessPath, _ []byte) bool {
+		if !balancePaths[string(accessPath.Path)] {
+			return false
+		}
+
+		addr, ok := common_vm.LibraToBech32(accessPath.Address)
+		if !ok {
+			return false
+		}
+
+		// values are ordered by address, so duplicates are adjacent
+		if len(addresses) == 0 || !addresses[len(addresses)-1].Equals(addr) {
+			addresses = append(addresses, addr)
+		}
+
+		return false
+	})
+
+	return addresses
+}
+
+// Get next account number without increasing global account number (see auth.AccountKeeper.GetNextAccountNumber).
+func (keeper VMAccountKeeper) peekNextAccountNumber(ctx sdk.Context) uint64 {
+	var accNumber uint64
+
+	bz := ctx.KVStore(keeper.key).Get(auth.GlobalAccountNumberKey)
+	if bz != nil {
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &accNumber)
+	}
+
+	return accNumber
+}
+
+// IterateAccounts iterates over all accounts ordered by address (including accounts existing only in VM storage),
+// iteration stops if handler returns true.
+// Account coins are reconciled with VM balances, VM only accounts get account numbers following the global account
+// number (in address order, like NewAccountWithAddress would assign them): accounts are not saved to storage
+// (unlike GetAccount), so iteration doesn't change the state.
+func (keeper VMAccountKeeper) IterateAccounts(ctx sdk.Context, process func(exported.Account) (stop bool)) {
+	accounts := make([]exported.Account, 0)
+	authAddresses := make(map[string]bool)
+	keeper.AccountKeeper.IterateAccounts(ctx, func(acc exported.Account) bool {
+		accounts = append(accounts, acc)
+		authAddresses[string(acc.GetAddress())] = true
+
+		return false
+	})
+
+	nextAccNumber := keeper.peekNextAccountNumber(ctx)
+	for _, addr := range keeper.getVMBalancesAddresses(ctx) {
+		if authAddresses[string(addr)] {
+			continue
+		}
+
+		acc := keeper.proto()
+		if err := acc.SetAddress(addr); err != nil {
+			panic(err) // should never happen
+		}
+		if err := acc.SetAccountNumber(nextAccNumber); err != nil {
+			panic(err) // should never happen
+		}
+		nextAccNumber++
+		accounts = append(accounts, acc)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].GetAddress(), accounts[j].GetAddress()) < 0
+	})
+
+	denomPaths := keeper.getDenomPaths(ctx)
+	for _, acc := range accounts {
+		balances := keeper.loadBalances(ctx, acc.GetAddress())
+		if len(balances) > 0 {
+			realCoins := balancesToCoins(balances).Add(unregisteredCoins(denomPaths, acc.GetCoins())...)
+			if !coinsEqual(realCoins, acc.GetCoins()) {
+				if err := acc.SetCoins(realCoins); err != nil {
+					panic(err) // should never happen
+				}
+			}
+		}
+
+		if process(acc) {
+			break
+		}
+	}
+}
+
+// GetAllAccounts returns all accounts including accounts existing only in VM storage (see IterateAccounts).
 func (keeper VMAccountKeeper) GetAllAccounts(ctx sdk.Context) []exported.Account {
-	accounts := keeper.AccountKeeper.GetAllAccounts(ctx)
+	accounts := make([]exported.Account, 0)
+	keeper.IterateAccounts(ctx, func(acc exported.Account) bool {
+		accounts = append(accounts, acc)
+		return false
+	})
 
 	return accounts
 }
@@ -180,7 +373,7 @@ func (keeper VMAccountKeeper) RemoveAccount(ctx sdk.Context, acc exported.Accoun
 	})
 
 	// Should remove all balances.
-	balances := loadAccessPaths(acc.GetAddress())
+	balances := loadAccessPaths(keeper.getDenomPaths(ctx), acc.GetAddress())
 	for _, b := range balances {
 		keeper.vmKeeper.DelValue(ctx, b.accessPath)
 	}
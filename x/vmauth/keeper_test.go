@@ -4,14 +4,20 @@ package vmauth
 
 import (
 	"bytes"
+	"encoding/hex"
 	"math/big"
+	"sort"
+	"strconv"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/exported"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/dfinance/dvm-proto/go/vm_grpc"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/kv"
 
 	"github.com/dfinance/dnode/x/common_vm"
 )
@@ -56,8 +62,8 @@ func TestVMAccountKeeper_SetAccount(t *testing.T) {
 	require.True(t, getter.GetCoins().IsEqual(vmCoins), "coins are not match after set account")
 
 	// add new resource in vm.
-	balances, toDelete := coinsToBalances(getter)
-	require.Len(t, toDelete, len(denomPaths)-len(coins)) // contains rest (exclude dfi)
+	balances, toDelete := coinsToBalances(input.denomPaths, getter)
+	require.Len(t, toDelete, len(input.denomPaths)-len(coins)) // contains rest (exclude dfi)
 
 	for _, toDel := range toDelete {
 		require.False(t, input.vmStorage.HasValue(input.ctx, toDel.accessPath))
@@ -70,12 +76,10 @@ func TestVMAccountKeeper_SetAccount(t *testing.T) {
 	getter = input.accountKeeper.GetAccount(input.ctx, addr)
 	require.Len(t, getter.GetCoins(), 1) // but still doesn't contains eth as it zero value.
 
-	err := AddDenomPath("test1", "00")
-	require.NoError(t, err)
-	defer RemoveDenomPath("test1")
+	input.denomPaths["test1"] = []byte{0x00}
 
-	balances, toDelete = coinsToBalances(getter)
-	require.Len(t, toDelete, len(denomPaths)-len(coins)) // contains 2 - eth and test1
+	balances, toDelete = coinsToBalances(input.denomPaths, getter)
+	require.Len(t, toDelete, len(input.denomPaths)-len(coins)) // contains 2 - eth and test1
 
 	for i := range toDelete {
 		if toDelete[i].denom == "test1" {
@@ -93,6 +97,40 @@ func TestVMAccountKeeper_SetAccount(t *testing.T) {
 	require.True(t, realCoins.IsEqual(getter.GetCoins()))
 }
 
+// Test set/get account with coins having not registered denom path (coins are not mirrored to VM).
+func TestVMAccountKeeper_SetAccountUnregisteredDenom(t *testing.T) {
+	input := newTestInput(t)
+
+	addr := secp256k1.GenPrivKey().PubKey().Address().Bytes()
+	acc := auth.NewBaseAccountWithAddress(addr)
+
+	coins := types.NewCoins(types.NewCoin("dfi", types.NewInt(1)), types.NewCoin("unknown", types.NewInt(2)))
+	require.NoError(t, acc.SetCoins(coins))
+
+	balances, toDelete := coinsToBalances(input.denomPaths, &acc)
+	require.Len(t, balances, 1)
+	require.Equal(t, "dfi", balances[0].denom)
+	require.Len(t, toDelete, len(input.denomPaths)-1)
+
+	require.NotPanics(t, func() {
+		input.accountKeeper.SetAccount(input.ctx, &acc)
+	})
+
+	balances = input.accountKeeper.loadBalances(input.ctx, addr)
+	require.Len(t, balances, 1)
+
+	getter := input.accountKeeper.GetAccount(input.ctx, addr)
+	require.True(t, coins.IsEqual(getter.GetCoins()), "coins not backed by VM balances are lost")
+
+	// VM balance change keeps not registered denom coins
+	balances[0].balance.Value = big.NewInt(10)
+	input.accountKeeper.saveBalances(input.ctx, balances, nil)
+
+	getter = input.accountKeeper.GetAccount(input.ctx, addr)
+	require.True(t, getter.GetCoins().AmountOf("dfi").Equal(types.NewInt(10)))
+	require.True(t, getter.GetCoins().AmountOf("unknown").Equal(types.NewInt(2)))
+}
+
 // Test event handler generator creation if account not exists yet in VM storage.
 func TestVMAccount_EventHandlerGeneratorNewAccount(t *testing.T) {
 	input := newTestInput(t)
@@ -204,8 +242,8 @@ func TestVMAccount_GetExistsAccount(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	balances, toDelete := coinsToBalances(&acc)
-	require.Len(t, toDelete, len(denomPaths)-len(coins))
+	balances, toDelete := coinsToBalances(input.denomPaths, &acc)
+	require.Len(t, toDelete, len(input.denomPaths)-len(coins))
 
 	require.Len(t, balances, len(coins), "balances length doesnt match coins")
 
@@ -293,8 +331,8 @@ func TestVMAccount_loadBalances(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	balances, toDelete := coinsToBalances(&acc)
-	require.Len(t, toDelete, len(denomPaths)-len(coins))
+	balances, toDelete := coinsToBalances(input.denomPaths, &acc)
+	require.Len(t, toDelete, len(input.denomPaths)-len(coins))
 
 	input.accountKeeper.saveBalances(input.ctx, balances, toDelete)
 
@@ -325,7 +363,7 @@ func TestVMAccountKeeper_RemoveAccount(t *testing.T) {
 	bz := input.vmStorage.GetValue(input.ctx, key)
 	require.Nil(t, bz)
 
-	balances := loadAccessPaths(addr)
+	balances := loadAccessPaths(input.denomPaths, addr)
 	for _, b := range balances {
 		bz := input.vmStorage.GetValue(input.ctx, b.accessPath)
 		if bz != nil {
@@ -356,14 +394,8 @@ func TestVMAccountKeeper_SetBalancesWithDelete(t *testing.T) {
 	addr := secp256k1.GenPrivKey().PubKey().Address().Bytes()
 	acc := auth.NewBaseAccountWithAddress(addr)
 
-	err := AddDenomPath("test1", "00")
-	require.NoError(t, err)
-
-	err = AddDenomPath("test2", "01")
-	require.NoError(t, err)
-
-	defer RemoveDenomPath("test1")
-	defer RemoveDenomPath("test2")
+	input.denomPaths["test1"] = []byte{0x00}
+	input.denomPaths["test2"] = []byte{0x01}
 
 	coins := types.Coins{
 		types.NewCoin("dfi", types.NewInt(100100)),
@@ -374,11 +406,11 @@ func TestVMAccountKeeper_SetBalancesWithDelete(t *testing.T) {
 		types.NewCoin("test2", types.NewInt(100400)),
 	}
 
-	err = acc.SetCoins(coins)
+	err := acc.SetCoins(coins)
 	require.NoError(t, err)
 
 	// just check that there is no toDelete
-	_, toDelete := coinsToBalances(&acc)
+	_, toDelete := coinsToBalances(input.denomPaths, &acc)
 	require.Empty(t, toDelete)
 
 	input.accountKeeper.SetAccount(input.ctx, &acc)
@@ -501,8 +533,8 @@ func TestVMAccountKeeper_SendBankKeeper(t *testing.T) {
 	require.NoError(t, err)
 
 	input.accountKeeper.SetAccount(input.ctx, &acc)
-	_, toDelete := coinsToBalances(&acc)
-	require.Len(t, toDelete, len(denomPaths)-len(coins))
+	_, toDelete := coinsToBalances(input.denomPaths, &acc)
+	require.Len(t, toDelete, len(input.denomPaths)-len(coins))
 
 	getter := input.accountKeeper.GetAccount(input.ctx, addr)
 	require.True(t, getter.GetCoins().IsEqual(coins))
@@ -557,3 +589,228 @@ func TestVMAccountKeeper_SendBankKeeper(t *testing.T) {
 		}
 	}
 }
+
+// Test get all accounts including accounts existing only in VM storage.
+func TestVMAccountKeeper_GetAllAccounts(t *testing.T) {
+	input := newTestInput(t)
+
+	// auth account with balances changed in VM
+	authAddr := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address().Bytes())
+	authAcc := auth.NewBaseAccountWithAddress(authAddr)
+	require.NoError(t, authAcc.SetCoins(types.Coins{types.NewCoin("dfi", types.NewInt(100))}))
+	input.accountKeeper.SetAccount(input.ctx, &authAcc)
+
+	vmCoins := types.Coins{types.NewCoin("dfi", types.NewInt(50)), types.NewCoin("eth", types.NewInt(10))}
+	changedAcc := auth.NewBaseAccountWithAddress(authAddr)
+	require.NoError(t, changedAcc.SetCoins(vmCoins))
+	balances, _ := coinsToBalances(input.denomPaths, &changedAcc)
+	input.accountKeeper.saveBalances(input.ctx, balances, nil)
+
+	// VM only account
+	vmAddr := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address().Bytes())
+	vmAcc := auth.NewBaseAccountWithAddress(vmAddr)
+	require.NoError(t, vmAcc.SetCoins(types.Coins{types.NewCoin("btc", types.NewInt(1))}))
+	balances, _ = coinsToBalances(input.denomPaths, &vmAcc)
+	input.accountKeeper.saveBalances(input.ctx, balances, nil)
+
+	// non-balance resources and balances under non-bech32 addresses are skipped
+	input.vmStorage.SetValue(input.ctx, &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(secp256k1.GenPrivKey().PubKey().Address().Bytes()), Path: []byte{0x02}}, []byte{0x01})
+	input.vmStorage.SetValue(input.ctx, &vm_grpc.VMAccessPath{Address: make([]byte, 25), Path: input.denomPaths["dfi"]}, BalanceToBytes(balances[0].balance))
+
+	// auth account numbers are taken from the global account number
+	otherAcc := input.accountKeeper.NewAccountWithAddress(input.ctx, secp256k1.GenPrivKey().PubKey().Address().Bytes())
+	input.accountKeeper.SetAccount(input.ctx, otherAcc)
+	nextAccNumber := input.accountKeeper.peekNextAccountNumber(input.ctx)
+	require.Equal(t, otherAcc.GetAccountNumber()+1, nextAccNumber)
+
+	accounts := input.accountKeeper.GetAllAccounts(input.ctx)
+	require.Len(t, accounts, 3)
+	require.True(t, sort.SliceIsSorted(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].GetAddress(), accounts[j].GetAddress()) < 0
+	}))
+
+	for _, acc := range accounts {
+		switch {
+		case acc.GetAddress().Equals(authAddr):
+			require.True(t, vmCoins.IsEqual(acc.GetCoins()))
+		case acc.GetAddress().Equals(vmAddr):
+			require.True(t, vmAcc.GetCoins().IsEqual(acc.GetCoins()))
+			require.Equal(t, nextAccNumber, acc.GetAccountNumber(), "VM only account number")
+		case acc.GetAddress().Equals(otherAcc.GetAddress()):
+			require.Equal(t, otherAcc.GetAccountNumber(), acc.GetAccountNumber())
+		default:
+			t.Fatalf("unexpected account %s", acc.GetAddress())
+		}
+	}
+
+	// iteration doesn't materialize VM only accounts and doesn't increase the global account number
+	require.Nil(t, input.accountKeeper.AccountKeeper.GetAccount(input.ctx, vmAddr))
+	require.Equal(t, nextAccNumber, input.accountKeeper.peekNextAccountNumber(input.ctx))
+
+	// iteration stop
+	count := 0
+	input.accountKeeper.IterateAccounts(input.ctx, func(acc exported.Account) bool {
+		count++
+		return true
+	})
+	require.Equal(t, 1, count)
+}
+
+// Check VM write set handler syncs accounts coins with VM balances changed by execution.
+func TestVMAccountKeeper_VMWriteSetHandler(t *testing.T) {
+	input := newTestInput(t)
+	handler := input.accountKeeper.NewVMWriteSetHandler()
+
+	newBalanceWrite := func(addr types.AccAddress, denom string, amount int64) *vm_grpc.VMValue {
+		path := &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(addr), Path: input.denomPaths[denom]}
+		value := BalanceToBytes(BalanceResource{Value: big.NewInt(amount)})
+		input.vmStorage.SetValue(input.ctx, path, value)
+
+		return &vm_grpc.VMValue{Type: vm_grpc.VmWriteOp_Value, Value: value, Path: path}
+	}
+
+	// existing account
+	addr := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address().Bytes())
+	acc := auth.NewBaseAccountWithAddress(addr)
+	require.NoError(t, acc.SetCoins(types.NewCoins(types.NewCoin("dfi", types.NewInt(100)), types.NewCoin("unknown", types.NewInt(1)))))
+	input.accountKeeper.SetAccount(input.ctx, &acc)
+
+	// VM only account
+	vmAddr := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address().Bytes())
+
+	writeSet := []*vm_grpc.VMValue{
+		newBalanceWrite(addr, "dfi", 50),
+		newBalanceWrite(addr, "eth", 10),
+		newBalanceWrite(vmAddr, "btc", 1),
+		// non-balance resource is skipped
+		{Type: vm_grpc.VmWriteOp_Value, Value: []byte{0x01}, Path: &vm_grpc.VMAccessPath{Address: common_vm.Bech32ToLibra(addr), Path: []byte{0x02}}},
+	}
+	handler(input.ctx, writeSet)
+
+	expCoins := types.NewCoins(types.NewCoin("dfi", types.NewInt(50)), types.NewCoin("eth", types.NewInt(10)), types.NewCoin("unknown", types.NewInt(1)))
+	syncedAcc := input.accountKeeper.AccountKeeper.GetAccount(input.ctx, addr)
+	require.True(t, expCoins.IsEqual(syncedAcc.GetCoins()), "synced coins: %s", syncedAcc.GetCoins())
+
+	vmAcc := input.accountKeeper.AccountKeeper.GetAccount(input.ctx, vmAddr)
+	require.NotNil(t, vmAcc)
+	require.True(t, types.NewCoins(types.NewCoin("btc", types.NewInt(1))).IsEqual(vmAcc.GetCoins()))
+	_, ok := input.accountKeeper.getVMAccount(input.ctx, vmAddr)
+	require.True(t, ok, "VM account resource for VM only account")
+
+	// zero balance for not existing account doesn't create account
+	zeroAddr := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address().Bytes())
+	handler(input.ctx, []*vm_grpc.VMValue{newBalanceWrite(zeroAddr, "dfi", 0)})
+	require.Nil(t, input.accountKeeper.AccountKeeper.GetAccount(input.ctx, zeroAddr))
+
+	// synced amounts match VM balances (not registered denom coin is the only invariant violation)
+	msg, _ := BalancesInvariant(input.accountKeeper)(input.ctx)
+	require.NotContains(t, msg, "mismatch")
+	require.NotContains(t, msg, "not found")
+}
+
+// Check bank transfers update VM account event handles and emit payment events.
+func TestVMAccountKeeper_TransferEvents(t *testing.T) {
+	input := newTestInput(t)
+
+	sender := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	recipient := types.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	senderAcc := auth.NewBaseAccountWithAddress(sender)
+	require.NoError(t, senderAcc.SetCoins(types.NewCoins(types.NewCoin("dfi", types.NewInt(100)), types.NewCoin("eth", types.NewInt(10)))))
+	input.accountKeeper.SetAccount(input.ctx, &senderAcc)
+
+	// set account without transfer: no events
+	{
+		vmAcc, ok := input.accountKeeper.getVMAccount(input.ctx, sender)
+		require.True(t, ok)
+		require.EqualValues(t, 0, vmAcc.SentEvents.Counter)
+		require.EqualValues(t, 0, vmAcc.ReceivedEvents.Counter)
+		require.Empty(t, input.ctx.EventManager().Events())
+	}
+
+	// send coins to new account
+	{
+		ctx := input.ctx.WithEventManager(types.NewEventManager())
+		amount := types.NewCoins(types.NewCoin("dfi", types.NewInt(40)), types.NewCoin("eth", types.NewInt(1)))
+		require.NoError(t, input.bankKeeper.SendCoins(ctx, sender, recipient, amount))
+
+		senderVMAcc, ok := input.accountKeeper.getVMAccount(ctx, sender)
+		require.True(t, ok)
+		require.EqualValues(t, 2, senderVMAcc.SentEvents.Counter)
+		require.EqualValues(t, 0, senderVMAcc.ReceivedEvents.Counter)
+
+		recipientVMAcc, ok := input.accountKeeper.getVMAccount(ctx, recipient)
+		require.True(t, ok)
+		require.EqualValues(t, 0, recipientVMAcc.SentEvents.Counter)
+		require.EqualValues(t, 2, recipientVMAcc.ReceivedEvents.Counter)
+
+		events := make(types.Events, 0)
+		for _, event := range ctx.EventManager().Events() {
+			if event.Type == EventTypeMoveEvent {
+				events = append(events, event)
+			}
+		}
+		require.Len(t, events, 4)
+
+		checkEvent := func(event types.Event, handle *EventHandle, seqNum, structName string, data []byte) {
+			attrs := make(map[string]string)
+			for _, attr := range event.Attributes {
+				attrs[string(attr.Key)] = string(attr.Value)
+			}
+
+			require.Equal(t, "0x"+hex.EncodeToString(handle.Guid), attrs[AttrKeyGuid])
+			require.Equal(t, seqNum, attrs[AttrKeySequenceNumber])
+			require.Equal(t, "Struct", attrs[AttrKeyType])
+			require.Equal(t, "0x"+hex.EncodeToString(data), attrs[AttrKeyData])
+			require.Equal(t, "0x"+hex.EncodeToString(common_vm.ZeroAddress)+"::Account::"+structName, attrs[AttrKeyStructTag])
+		}
+
+		for i, coin := range amount {
+			checkEvent(events[i], senderVMAcc.SentEvents, strconv.Itoa(i), "SentPaymentEvent", SentPaymentEventToBytes(SentPaymentEvent{
+				Amount:   coin.Amount.BigInt(),
+				Denom:    []byte(coin.Denom),
+				Payee:    common_vm.Bech32ToLibra(recipient),
+				Metadata: []byte{},
+			}))
+
+			checkEvent(events[len(amount)+i], recipientVMAcc.ReceivedEvents, strconv.Itoa(i), "ReceivedPaymentEvent", ReceivedPaymentEventToBytes(ReceivedPaymentEvent{
+				Amount:   coin.Amount.BigInt(),
+				Denom:    []byte(coin.Denom),
+				Payer:    common_vm.Bech32ToLibra(sender),
+				Metadata: []byte{},
+			}))
+		}
+	}
+
+	// multi send with several outputs: payee is unknown
+	{
+		ctx := input.ctx.WithEventManager(types.NewEventManager())
+		amount := types.NewCoins(types.NewCoin("dfi", types.NewInt(2)))
+		inputs := []bank.Input{bank.NewInput(sender, amount)}
+		outputs := []bank.Output{
+			bank.NewOutput(recipient, types.NewCoins(types.NewCoin("dfi", types.NewInt(1)))),
+			bank.NewOutput(recipient, types.NewCoins(types.NewCoin("dfi", types.NewInt(1)))),
+		}
+		require.NoError(t, input.bankKeeper.InputOutputCoins(ctx, inputs, outputs))
+
+		senderVMAcc, ok := input.accountKeeper.getVMAccount(ctx, sender)
+		require.True(t, ok)
+		require.EqualValues(t, 3, senderVMAcc.SentEvents.Counter)
+
+		recipientVMAcc, ok := input.accountKeeper.getVMAccount(ctx, recipient)
+		require.True(t, ok)
+		require.EqualValues(t, 4, recipientVMAcc.ReceivedEvents.Counter)
+
+		sentEvent := ctx.EventManager().Events()[0]
+		require.Equal(t, EventTypeMoveEvent, sentEvent.Type)
+		require.Contains(t, sentEvent.Attributes, kv.Pair{
+			Key: []byte(AttrKeyData),
+			Value: []byte("0x" + hex.EncodeToString(SentPaymentEventToBytes(SentPaymentEvent{
+				Amount:   big.NewInt(2),
+				Denom:    []byte("dfi"),
+				Payee:    common_vm.ZeroAddress,
+				Metadata: []byte{},
+			}))),
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sort"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -26,42 +27,20 @@ const (
 var (
 	// Errors.
 	ErrInternal = sdkErrors.Register(auth.ModuleName, 100, "internal")
-	denomPaths  map[string][]byte // path to denom.
 )
 
-// initialize denoms.
-func init() {
-	denomPaths = make(map[string][]byte)
+// VM balance resource paths by denom.
+type DenomPaths map[string][]byte
 
-	err := AddDenomPath("dfi", "0136364df51f98e43cbe92dfcfdd807c7fc626aa9690793ebf7d301cfe0de72f11")
-	if err != nil {
-		panic(err)
-	}
-
-	err = AddDenomPath("eth", "01f7c5cca156342bec3ac2bfb6fc439683da605ab7bc3f58881427861c8fc44577")
-	if err != nil {
-		panic(err)
-	}
-
-	err = AddDenomPath("usdt", "01affc8719224641c76bb20b65e4a26c002a39bbc82b753410976002ad7931b270")
-	if err != nil {
-		panic(err)
-	}
-
-	err = AddDenomPath("btc", "01d09b573d3bea2a3853ad447728fa476925894836352c46711fb7604e962b2903")
-	if err != nil {
-		panic(err)
+// Get denoms sorted.
+func (p DenomPaths) Denoms() []string {
+	denoms := make([]string, 0, len(p))
+	for denom := range p {
+		denoms = append(denoms, denom)
 	}
-}
-
-func AddDenomPath(denom string, path string) error {
-	var err error
-	denomPaths[denom], err = hex.DecodeString(path)
-	return err
-}
+	sort.Strings(denoms)
 
-func RemoveDenomPath(denom string) {
-	delete(denomPaths, denom)
+	return denoms
 }
 
 // Event generator for address.
@@ -97,33 +76,46 @@ type AccountResource struct {
 	ReceivedEvents *EventHandle // received events handler.
 }
 
-// Load access paths for balances.
-func loadAccessPaths(addr sdk.AccAddress) Balances {
-	balances := make(Balances, len(denomPaths))
+// Sent payment event (standard library Account::SentPaymentEvent).
+type SentPaymentEvent struct {
+	Amount   *big.Int
+	Denom    []byte
+	Payee    []byte `lcs:"len=24"`
+	Metadata []byte
+}
+
+// Received payment event (standard library Account::ReceivedPaymentEvent).
+type ReceivedPaymentEvent struct {
+	Amount   *big.Int
+	Denom    []byte
+	Payer    []byte `lcs:"len=24"`
+	Metadata []byte
+}
 
-	i := 0
-	for key, value := range denomPaths {
+// Load access paths for balances (sorted by denom).
+func loadAccessPaths(denomPaths DenomPaths, addr sdk.AccAddress) Balances {
+	balances := make(Balances, 0, len(denomPaths))
+
+	for _, denom := range denomPaths.Denoms() {
 		accessPath := &vm_grpc.VMAccessPath{
 			Address: common_vm.Bech32ToLibra(addr),
-			Path:    value,
+			Path:    denomPaths[denom],
 		}
 
-		balances[i] = Balance{
+		balances = append(balances, Balance{
 			accessPath: accessPath,
-			denom:      key,
-		}
-
-		i++
+			denom:      denom,
+		})
 	}
 
 	return balances
 }
 
 // Convert sdk.Coin to balance.
-func coinToBalance(addr sdk.AccAddress, coin sdk.Coin) (Balance, error) {
+func coinToBalance(denomPaths DenomPaths, addr sdk.AccAddress, coin sdk.Coin) (Balance, error) {
 	path, ok := denomPaths[coin.Denom]
 	if !ok {
-		return Balance{}, fmt.Errorf("cant find path for denom %s", coin.Denom)
+		return Balance{}, fmt.Errorf("cant find VM balance path for denom %s (denom path is not registered)", coin.Denom)
 	}
 
 	return Balance{
@@ -140,27 +132,26 @@ func coinToBalance(addr sdk.AccAddress, coin sdk.Coin) (Balance, error) {
 
 // Convert coins to balances resources.
 // Returns two kind of balances - to write and to delete.
-func coinsToBalances(acc exported.Account) (Balances, Balances) {
+// Coins with not registered denom paths are skipped (they are kept by auth account only).
+func coinsToBalances(denomPaths DenomPaths, acc exported.Account) (Balances, Balances) {
 	coins := acc.GetCoins()
-	balances := make(Balances, len(coins))
+	balances := make(Balances, 0, len(coins))
 	found := make(map[string]bool)
 
-	for i, coin := range coins {
-		var err error
-		balances[i], err = coinToBalance(acc.GetAddress(), coin)
+	for _, coin := range coins {
+		found[coin.Denom] = true
+
+		balance, err := coinToBalance(denomPaths, acc.GetAddress(), coin)
 		if err != nil {
-			panic(err)
+			continue
 		}
-		found[coin.Denom] = true
+		balances = append(balances, balance)
 	}
 
 	toDelete := make(Balances, 0)
-	for k := range denomPaths {
+	for _, k := range denomPaths.Denoms() {
 		if !found[k] {
-			balance, err := coinToBalance(acc.GetAddress(), sdk.NewCoin(k, sdk.ZeroInt()))
-			if err != nil {
-				panic(err)
-			}
+			balance, _ := coinToBalance(denomPaths, acc.GetAddress(), sdk.NewCoin(k, sdk.ZeroInt()))
 			toDelete = append(toDelete, balance)
 		}
 	}
@@ -168,6 +159,34 @@ func coinsToBalances(acc exported.Account) (Balances, Balances) {
 	return balances, toDelete
 }
 
+// Get coins with not registered denom paths (not backed by VM balances).
+func unregisteredCoins(denomPaths DenomPaths, coins sdk.Coins) sdk.Coins {
+	unregistered := sdk.NewCoins()
+	for _, coin := range coins {
+		if _, ok := denomPaths[coin.Denom]; !ok {
+			unregistered = append(unregistered, coin)
+		}
+	}
+
+	return unregistered
+}
+
+// Check coins are equal (unlike sdk.Coins.IsEqual doesn't panic on different denoms).
+func coinsEqual(coinsA, coinsB sdk.Coins) bool {
+	if len(coinsA) != len(coinsB) {
+		return false
+	}
+
+	coinsA, coinsB = coinsA.Sort(), coinsB.Sort()
+	for i := range coinsA {
+		if coinsA[i].Denom != coinsB[i].Denom || !coinsA[i].Amount.Equal(coinsB[i].Amount) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Convert balance to sdk.Coin.
 func balanceToCoin(balance Balance) sdk.Coin {
 	return sdk.NewCoin(balance.denom, sdk.NewIntFromBigInt(balance.balance.Value))
@@ -301,3 +320,23 @@ func BytesToAccRes(bz []byte) AccountResource {
 
 	return accRes
 }
+
+// Convert sent payment event to bytes.
+func SentPaymentEventToBytes(event SentPaymentEvent) []byte {
+	bytes, err := lcs.Marshal(event)
+	if err != nil {
+		panic(err)
+	}
+
+	return bytes
+}
+
+// Convert received payment event to bytes.
+func ReceivedPaymentEventToBytes(event ReceivedPaymentEvent) []byte {
+	bytes, err := lcs.Marshal(event)
+	if err != nil {
+		panic(err)
+	}
+
+	return bytes
+}
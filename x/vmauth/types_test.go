@@ -111,7 +111,7 @@ func TestCoinsToBalances(t *testing.T) {
 	err := acc.SetCoins(coins)
 	require.NoError(t, err)
 
-	balances, _ := coinsToBalances(&acc)
+	balances, _ := coinsToBalances(newTestDenomPaths(), &acc)
 	require.Len(t, balances, len(coins))
 
 	for i := range coins {
@@ -202,7 +202,9 @@ func TestCreateVMAccount(t *testing.T) {
 // Test access paths loading.
 func TestLoadAccessPaths(t *testing.T) {
 	addr := secp256k1.GenPrivKey().PubKey().Address().Bytes()
-	balances := loadAccessPaths(addr)
+	denomPaths := newTestDenomPaths()
+	balances := loadAccessPaths(denomPaths, addr)
+	require.Len(t, balances, len(denomPaths))
 
 	for _, balance := range balances {
 		denomPath, isOk := denomPaths[balance.denom]
@@ -234,3 +236,31 @@ func TestGetEHPath(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, path, bz)
 }
+
+// Check payment events are serialized with standard library layout.
+func TestPaymentEventsToBytes(t *testing.T) {
+	addr := common_vm.Bech32ToLibra(secp256k1.GenPrivKey().PubKey().Address().Bytes())
+
+	// amount (u128), denom (vector<u8>), payee / payer (address), metadata (vector<u8>).
+	expected := make([]byte, 16)
+	expected[0] = 100
+	expected = append(expected, 3, 'd', 'f', 'i')
+	expected = append(expected, addr...)
+	expected = append(expected, 0)
+
+	sentBz := SentPaymentEventToBytes(SentPaymentEvent{
+		Amount:   types.NewInt(100).BigInt(),
+		Denom:    []byte("dfi"),
+		Payee:    addr,
+		Metadata: []byte{},
+	})
+	require.Equal(t, expected, sentBz)
+
+	receivedBz := ReceivedPaymentEventToBytes(ReceivedPaymentEvent{
+		Amount:   types.NewInt(100).BigInt(),
+		Denom:    []byte("dfi"),
+		Payer:    addr,
+		Metadata: []byte{},
+	})
+	require.Equal(t, expected, receivedBz)
+}